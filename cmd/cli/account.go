@@ -20,6 +20,9 @@ func registerOrgAccountModule(core *core.Core) {
 	orgAccountGetOneCmd(core, orgAccountCmd)
 	orgAccountUpdateCmd(core, orgAccountCmd)
 	orgAccountDeleteCmd(core, orgAccountCmd)
+	orgAccountRoleGetCmd(core, orgAccountCmd)
+	orgAccountRoleSetCmd(core, orgAccountCmd)
+	orgAccountRoleRemoveCmd(core, orgAccountCmd)
 
 	rootCmd.AddCommand(orgAccountCmd)
 
@@ -218,3 +221,91 @@ func orgAccountDeleteCmd(core *core.Core, parentCmd *cobra.Command) {
 
 	parentCmd.AddCommand(deleteCmd)
 }
+
+func orgAccountRoleGetCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var accountID int64
+	roleGetCmd := &cobra.Command{
+		Use:   "roleGet",
+		Short: "Get the effective role of an organization account",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			roles, err := core.OrgAccountRolesGet(opCtx, orgSlug, accountID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(roles)
+		},
+	}
+	roleGetCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	roleGetCmd.MarkFlagRequired("orgSlug")
+	roleGetCmd.Flags().Int64Var(&accountID, "id", 0, "account.id")
+	roleGetCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(roleGetCmd)
+}
+
+func orgAccountRoleSetCmd(core *core.Core, parentCmd *cobra.Command) {
+	args := struct {
+		orgSlug   string
+		accountID int64
+		role      string
+	}{}
+	roleSetCmd := &cobra.Command{
+		Use:   "roleSet",
+		Short: "Assign an organization role (owner, admin, editor, viewer) to an account",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			role, err := core.OrgAccountRoleSet(opCtx,
+				core.NewOrgAccountRoleSetArgs(
+					args.orgSlug,
+					args.accountID,
+					types.OrgRole(args.role),
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(role)
+		},
+	}
+	roleSetCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	roleSetCmd.MarkFlagRequired("orgSlug")
+	roleSetCmd.Flags().Int64Var(&args.accountID, "id", 0, "account.id")
+	roleSetCmd.MarkFlagRequired("id")
+	roleSetCmd.Flags().StringVar(&args.role, "role", "", "owner, admin, editor, or viewer")
+	roleSetCmd.MarkFlagRequired("role")
+
+	parentCmd.AddCommand(roleSetCmd)
+}
+
+func orgAccountRoleRemoveCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var accountID int64
+	roleRemoveCmd := &cobra.Command{
+		Use:   "roleRemove",
+		Short: "Remove the role assigned directly to an organization account",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			if err := core.OrgAccountRoleDelete(opCtx, orgSlug, accountID); err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("Role removed from organization account '%v'\n", accountID)
+		},
+	}
+	roleRemoveCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	roleRemoveCmd.MarkFlagRequired("orgSlug")
+	roleRemoveCmd.Flags().Int64Var(&accountID, "id", 0, "account.id")
+	roleRemoveCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(roleRemoveCmd)
+}
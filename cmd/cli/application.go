@@ -20,6 +20,10 @@ func registerAppModule(core *core.Core) {
 	appGetOneCmd(core, appCmd)
 	appUpdateCmd(core, appCmd)
 	appDeleteCmd(core, appCmd)
+	appSDKKeyCreateCmd(core, appCmd)
+	appSDKKeyGetManyCmd(core, appCmd)
+	appSDKKeyRotateCmd(core, appCmd)
+	appSDKKeyRevokeCmd(core, appCmd)
 
 	rootCmd.AddCommand(appCmd)
 }
@@ -202,3 +206,129 @@ func appDeleteCmd(core *core.Core, parentCmd *cobra.Command) {
 
 	parentCmd.AddCommand(deleteCmd)
 }
+
+func appSDKKeyCreateCmd(core *core.Core, parentCmd *cobra.Command) {
+	args := struct {
+		orgSlug string
+		appSlug string
+		envSlug string
+		name    string
+		keyType string
+	}{}
+	createCmd := &cobra.Command{
+		Use:   "sdkKeyCreate",
+		Short: "Create a new application SDK key",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			sdkKey, err := core.SDKKeyCreate(opCtx,
+				core.NewSDKKeyCreateArgs(
+					args.orgSlug,
+					args.appSlug,
+					args.envSlug,
+					args.name,
+					types.SDKKeyType(args.keyType),
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(sdkKey)
+		},
+	}
+	createCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	createCmd.MarkFlagRequired("orgSlug")
+	createCmd.Flags().StringVar(&args.appSlug, "slug", "", "application.slug")
+	createCmd.MarkFlagRequired("slug")
+	createCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
+	createCmd.Flags().StringVar(&args.name, "name", "", "sdkKey.name")
+	createCmd.MarkFlagRequired("name")
+	createCmd.Flags().StringVar(&args.keyType, "keyType", string(types.SDKKeyTypeServer), "sdkKey.keyType (server or client)")
+
+	parentCmd.AddCommand(createCmd)
+}
+
+func appSDKKeyGetManyCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var appSlug string
+	getManyCmd := &cobra.Command{
+		Use:   "sdkKeyGetMany",
+		Short: "Get all SDK keys for an application",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			sdkKeys, err := core.SDKKeyGetMany(opCtx, orgSlug, appSlug)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(sdkKeys)
+		},
+	}
+	getManyCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	getManyCmd.MarkFlagRequired("orgSlug")
+	getManyCmd.Flags().StringVar(&appSlug, "slug", "", "application.slug")
+	getManyCmd.MarkFlagRequired("slug")
+
+	parentCmd.AddCommand(getManyCmd)
+}
+
+func appSDKKeyRotateCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var appSlug string
+	var id int64
+	rotateCmd := &cobra.Command{
+		Use:   "sdkKeyRotate",
+		Short: "Replace the secret of an application SDK key",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			sdkKey, err := core.SDKKeyRotate(opCtx, orgSlug, appSlug, id)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(sdkKey)
+		},
+	}
+	rotateCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	rotateCmd.MarkFlagRequired("orgSlug")
+	rotateCmd.Flags().StringVar(&appSlug, "slug", "", "application.slug")
+	rotateCmd.MarkFlagRequired("slug")
+	rotateCmd.Flags().Int64Var(&id, "id", 0, "sdkKey.id")
+	rotateCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(rotateCmd)
+}
+
+func appSDKKeyRevokeCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var appSlug string
+	var id int64
+	revokeCmd := &cobra.Command{
+		Use:   "sdkKeyRevoke",
+		Short: "Revoke an application SDK key",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			if _, err := core.SDKKeyRevoke(opCtx, orgSlug, appSlug, id); err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("SDK key '%v' revoked successfully\n", id)
+		},
+	}
+	revokeCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	revokeCmd.MarkFlagRequired("orgSlug")
+	revokeCmd.Flags().StringVar(&appSlug, "slug", "", "application.slug")
+	revokeCmd.MarkFlagRequired("slug")
+	revokeCmd.Flags().Int64Var(&id, "id", 0, "sdkKey.id")
+	revokeCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(revokeCmd)
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"switchcraft/core"
+	"switchcraft/types"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func registerApplyModule(core *core.Core) {
+	var args = struct {
+		file   string
+		prune  bool
+		dryRun bool
+		json   bool
+	}{}
+	applyCmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Make organizations match a YAML or JSON config file",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			plan, err := core.Apply(opCtx,
+				core.NewApplyArgs(mustParseConfigFile(args.file), args.prune, args.dryRun),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if args.json {
+				printJSON(plan)
+				return
+			}
+			if plan.DryRun {
+				fmt.Println("Dry run, nothing was changed")
+			}
+			printApplyPlan(plan)
+		},
+	}
+	applyCmd.Flags().StringVarP(&args.file, "file", "f", "", "Path to a YAML or JSON config file, in the seed file format")
+	applyCmd.MarkFlagRequired("file")
+	applyCmd.Flags().BoolVar(&args.prune, "prune", false, "Delete groups, applications and flags missing from the organizations in the file")
+	applyCmd.Flags().BoolVar(&args.dryRun, "dryRun", false, "Show the plan without changing anything")
+	applyCmd.Flags().BoolVar(&args.json, "json", false, "Print the plan as JSON")
+
+	rootCmd.AddCommand(applyCmd)
+}
+
+// printApplyPlan prints one line per change, marked + for creates, ~ for
+// updates and - for deletes, followed by a summary
+func printApplyPlan(plan *types.ApplyPlan) {
+	if len(plan.Changes) == 0 {
+		fmt.Println("No changes, the organizations match the config file")
+		return
+	}
+
+	for _, change := range plan.Changes {
+		mark := "~"
+		switch change.Action {
+		case types.ApplyActionCreate:
+			mark = "+"
+		case types.ApplyActionDelete:
+			mark = "-"
+		}
+
+		if len(change.Fields) > 0 {
+			fmt.Printf("%s %s %s: %s\n", mark, change.Resource, change.Address, strings.Join(change.Fields, ", "))
+		} else {
+			fmt.Printf("%s %s %s\n", mark, change.Resource, change.Address)
+		}
+	}
+
+	creates, updates, deletes := plan.Counts()
+	fmt.Printf("Plan: %d to create, %d to update, %d to delete\n", creates, updates, deletes)
+}
+
+// mustParseConfigFile reads a YAML or JSON config file. YAML is converted to
+// JSON first so the file uses the same field names either way.
+func mustParseConfigFile(filepath string) types.ApplyConfig {
+	file, err := os.ReadFile(filepath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var decoded any
+	if err = yaml.Unmarshal(file, &decoded); err != nil {
+		log.Fatalf("invalid config file: %s", err)
+	}
+	raw, err := json.Marshal(decoded)
+	if err != nil {
+		log.Fatalf("invalid config file: %s", err)
+	}
+
+	var config types.ApplyConfig
+	if err = json.Unmarshal(raw, &config); err != nil {
+		log.Fatalf("invalid config file: %s", err)
+	}
+
+	return config
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"log"
+	"switchcraft/core"
+	"switchcraft/types"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func registerAuditModule(core *core.Core) {
+	var auditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: "SwitchCraft CLI audit log module",
+	}
+	auditGetManyCmd(core, auditCmd)
+
+	rootCmd.AddCommand(auditCmd)
+}
+
+func auditGetManyCmd(core *core.Core, parentCmd *cobra.Command) {
+	args := struct {
+		orgSlug    string
+		entityType string
+		entityID   int64
+		actorID    int64
+		from       string
+		to         string
+		limit      int
+	}{}
+	getManyCmd := &cobra.Command{
+		Use:   "getMany",
+		Short: "Get org audit log entries, newest first",
+		Run: func(cmd *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			var (
+				entityType *types.AuditEntityType
+				entityID   *int64
+				actorID    *int64
+				from       *time.Time
+				to         *time.Time
+			)
+
+			if cmd.Flags().Changed("entityType") {
+				t := types.AuditEntityType(args.entityType)
+				entityType = &t
+			}
+			if cmd.Flags().Changed("entityID") {
+				entityID = &args.entityID
+			}
+			if cmd.Flags().Changed("actorID") {
+				actorID = &args.actorID
+			}
+			if cmd.Flags().Changed("from") {
+				t, err := time.Parse(time.RFC3339, args.from)
+				if err != nil {
+					log.Fatal(err)
+				}
+				from = &t
+			}
+			if cmd.Flags().Changed("to") {
+				t, err := time.Parse(time.RFC3339, args.to)
+				if err != nil {
+					log.Fatal(err)
+				}
+				to = &t
+			}
+
+			entries, err := core.AuditLogGetMany(opCtx,
+				core.NewAuditLogGetManyArgs(
+					args.orgSlug,
+					entityType,
+					entityID,
+					actorID,
+					from,
+					to,
+					args.limit,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(entries)
+		},
+	}
+	getManyCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "org.slug")
+	getManyCmd.MarkFlagRequired("orgSlug")
+	getManyCmd.Flags().StringVar(&args.entityType, "entityType", "", "auditLog.entityType")
+	getManyCmd.Flags().Int64Var(&args.entityID, "entityID", 0, "auditLog.entityID")
+	getManyCmd.Flags().Int64Var(&args.actorID, "actorID", 0, "auditLog.actorID")
+	getManyCmd.Flags().StringVar(&args.from, "from", "", "entries created at or after, RFC 3339")
+	getManyCmd.Flags().StringVar(&args.to, "to", "", "entries created before, RFC 3339")
+	getManyCmd.Flags().IntVar(&args.limit, "limit", 0, "max entries returned (default 100)")
+
+	parentCmd.AddCommand(getManyCmd)
+}
@@ -28,7 +28,7 @@ func authzCmd(core *core.Core, parentCmd *cobra.Command) {
 		Short: "Create signed JWT for current user",
 		Run: func(_ *cobra.Command, _ []string) {
 			authAccount := mustAuthn(core)
-			jwt, err := core.AuthCreateJWT(authAccount)
+			jwt, err := core.AuthCreateJWT(baseCtx, authAccount)
 			if err != nil {
 				log.Fatal(err)
 			}
@@ -25,13 +25,21 @@ func Start(logger *types.Logger, core *core.Core) {
 
 	registerMigrationsModule(core)
 	registerSeedModule(core)
+	registerApplyModule(core)
 	registerOrgAccountModule(core)
 	registerOrgGroupModule(core)
+	registerOrgSegmentModule(core)
 	registerOrgModule(core)
 	registerAuthModule(core)
 	registerAppModule(core)
+	registerEnvironmentModule(core)
 	registerFeatureFlagModule(core)
+	registerGroupFlagModule(core)
+	registerAuditModule(core)
+	registerWebhookModule(core)
+	registerJobModule(core)
 	registerRestModule(logger, core)
+	registerWorkerModule(core)
 
 	rootCmd.Execute()
 }
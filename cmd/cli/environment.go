@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"switchcraft/core"
+	"switchcraft/types"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func registerEnvironmentModule(core *core.Core) {
+	var envCmd = &cobra.Command{
+		Use:   "environment",
+		Short: "SwitchCraft CLI environment module",
+	}
+	envCreateCmd(core, envCmd)
+	envGetManyCmd(core, envCmd)
+	envGetOneCmd(core, envCmd)
+	envUpdateCmd(core, envCmd)
+	envDeleteCmd(core, envCmd)
+
+	rootCmd.AddCommand(envCmd)
+}
+
+func envCreateCmd(core *core.Core, parentCmd *cobra.Command) {
+	args := struct {
+		orgSlug string
+		appSlug string
+		name    string
+		slug    string
+	}{}
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create new application environment",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			env, err := core.EnvCreate(opCtx,
+				core.NewEnvCreateArgs(
+					args.orgSlug,
+					args.appSlug,
+					args.name,
+					args.slug,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(env)
+		},
+	}
+	createCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	createCmd.MarkFlagRequired("orgSlug")
+	createCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "Application slug")
+	createCmd.MarkFlagRequired("applicationSlug")
+	createCmd.Flags().StringVar(&args.name, "name", "", "environment.name")
+	createCmd.MarkFlagRequired("name")
+	createCmd.Flags().StringVar(&args.slug, "slug", "", "environment.slug")
+	createCmd.MarkFlagRequired("slug")
+
+	parentCmd.AddCommand(createCmd)
+}
+
+func envGetManyCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var appSlug string
+	getManyCmd := &cobra.Command{
+		Use:   "getMany",
+		Short: "Get all environments for an application",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			envs, err := core.EnvGetMany(opCtx, orgSlug, appSlug)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(envs)
+		},
+	}
+	getManyCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	getManyCmd.MarkFlagRequired("orgSlug")
+	getManyCmd.Flags().StringVar(&appSlug, "applicationSlug", "", "Application slug")
+	getManyCmd.MarkFlagRequired("applicationSlug")
+
+	parentCmd.AddCommand(getManyCmd)
+}
+
+func envGetOneCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var appSlug string
+	var slug string
+	getOneCmd := &cobra.Command{
+		Use:   "getOne",
+		Short: "Get a single application environment",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			env, err := core.EnvGetOne(opCtx, orgSlug, appSlug, slug)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(env)
+		},
+	}
+	getOneCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	getOneCmd.MarkFlagRequired("orgSlug")
+	getOneCmd.Flags().StringVar(&appSlug, "applicationSlug", "", "Application slug")
+	getOneCmd.MarkFlagRequired("applicationSlug")
+	getOneCmd.Flags().StringVar(&slug, "slug", "", "environment.slug")
+	getOneCmd.MarkFlagRequired("slug")
+
+	parentCmd.AddCommand(getOneCmd)
+}
+
+func envUpdateCmd(core *core.Core, parentCmd *cobra.Command) {
+	args := struct {
+		orgSlug string
+		appSlug string
+		envSlug string
+		name    string
+		slug    string
+	}{}
+	updateCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update an existing application environment",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			env, err := core.EnvUpdate(opCtx,
+				core.NewEnvUpdateArgs(
+					args.orgSlug,
+					args.appSlug,
+					args.envSlug,
+					args.name,
+					args.slug,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(env)
+		},
+	}
+	updateCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	updateCmd.MarkFlagRequired("orgSlug")
+	updateCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "Application slug")
+	updateCmd.MarkFlagRequired("applicationSlug")
+	updateCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Slug of the environment to update")
+	updateCmd.MarkFlagRequired("envSlug")
+	updateCmd.Flags().StringVar(&args.name, "name", "", "environment.name")
+	updateCmd.MarkFlagRequired("name")
+	updateCmd.Flags().StringVar(&args.slug, "slug", "", "environment.slug")
+	updateCmd.MarkFlagRequired("slug")
+
+	parentCmd.AddCommand(updateCmd)
+}
+
+func envDeleteCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var appSlug string
+	var slug string
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete an application environment",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			if err := core.EnvDelete(opCtx, orgSlug, appSlug, slug); err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("Environment '%v' deleted successfully\n", slug)
+		},
+	}
+	deleteCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	deleteCmd.MarkFlagRequired("orgSlug")
+	deleteCmd.Flags().StringVar(&appSlug, "applicationSlug", "", "Application slug")
+	deleteCmd.MarkFlagRequired("applicationSlug")
+	deleteCmd.Flags().StringVar(&slug, "slug", "", "environment.slug")
+	deleteCmd.MarkFlagRequired("slug")
+
+	parentCmd.AddCommand(deleteCmd)
+}
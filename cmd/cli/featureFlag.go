@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"switchcraft/core"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func registerFeatureFlagModule(core *core.Core) {
@@ -20,34 +22,77 @@ func registerFeatureFlagModule(core *core.Core) {
 	featureFlagGetOneCmd(core, featureFlagCmd)
 	featureFlagUpdateCmd(core, featureFlagCmd)
 	featureFlagDeleteCmd(core, featureFlagCmd)
+	featureFlagEvaluateCmd(core, featureFlagCmd)
+	featureFlagExplainCmd(core, featureFlagCmd)
+	featureFlagAccountsCmd(core, featureFlagCmd)
+	featureFlagAccountFlagsCmd(core, featureFlagCmd)
+	featureFlagHistoryCmd(core, featureFlagCmd)
+	featureFlagRollbackCmd(core, featureFlagCmd)
+	featureFlagDiffCmd(core, featureFlagCmd)
+	featureFlagPromoteCmd(core, featureFlagCmd)
+	featureFlagScheduleCreateCmd(core, featureFlagCmd)
+	featureFlagScheduleGetManyCmd(core, featureFlagCmd)
+	featureFlagScheduleCancelCmd(core, featureFlagCmd)
+	featureFlagArchiveCmd(core, featureFlagCmd)
+	featureFlagRestoreCmd(core, featureFlagCmd)
+	featureFlagDeprecateCmd(core, featureFlagCmd)
+	featureFlagStaleCmd(core, featureFlagCmd)
 
 	rootCmd.AddCommand(featureFlagCmd)
 }
 
 func featureFlagCreateCmd(core *core.Core, parentCmd *cobra.Command) {
 	args := struct {
-		orgSlug     string
-		appSlug     string
-		name        string
-		label       string
-		description string
-		isEnabled   bool
+		orgSlug           string
+		appSlug           string
+		envSlug           string
+		name              string
+		label             string
+		description       string
+		isEnabled         bool
+		rolloutPercentage int
+		flagType          string
+		variants          string
+		defaultVariant    string
+		rules             string
+		prerequisites     string
+		expiresAt         string
 	}{}
 	createCmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new feature flag",
-		Run: func(_ *cobra.Command, _ []string) {
+		Run: func(cmd *cobra.Command, _ []string) {
 			authAccount := mustAuthn(core)
 			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
 
+			var (
+				rolloutPercentage *int
+				defaultVariant    *string
+			)
+			if cmd.Flags().Changed("rolloutPercentage") {
+				rolloutPercentage = &args.rolloutPercentage
+			}
+			if cmd.Flags().Changed("defaultVariant") {
+				defaultVariant = &args.defaultVariant
+			}
+			expiresAt := mustParseFlagExpiresAt(args.expiresAt)
+
 			featureFlag, err := core.FeatFlagCreate(opCtx,
 				core.NewFeatFlagCreateArgs(
 					args.orgSlug,
 					args.appSlug,
+					args.envSlug,
 					args.name,
 					args.label,
 					args.description,
 					args.isEnabled,
+					rolloutPercentage,
+					types.FeatureFlagType(args.flagType),
+					mustParseFlagVariants(args.variants),
+					defaultVariant,
+					mustParseFlagRules(args.rules),
+					mustParseFlagPrerequisites(args.prerequisites),
+					expiresAt,
 				),
 			)
 			if err != nil {
@@ -61,12 +106,20 @@ func featureFlagCreateCmd(core *core.Core, parentCmd *cobra.Command) {
 	createCmd.MarkFlagRequired("orgSlug")
 	createCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
 	createCmd.MarkFlagRequired("applicationSlug")
+	createCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
 	createCmd.Flags().StringVar(&args.name, "name", "", "featureFlag.name")
 	createCmd.MarkFlagRequired("name")
 	createCmd.Flags().StringVar(&args.label, "label", "", "featureFlag.label")
 	createCmd.MarkFlagRequired("label")
 	createCmd.Flags().StringVar(&args.description, "description", "", "featureFlag.description")
 	createCmd.Flags().BoolVar(&args.isEnabled, "isEnabled", false, "featureFlag.isEnabled")
+	createCmd.Flags().IntVar(&args.rolloutPercentage, "rolloutPercentage", 0, "featureFlag.rolloutPercentage, omit for all accounts")
+	createCmd.Flags().StringVar(&args.flagType, "flagType", string(types.FeatureFlagTypeBoolean), "featureFlag.flagType (boolean, string, number, json)")
+	createCmd.Flags().StringVar(&args.variants, "variants", "", `featureFlag.variants as JSON, e.g. '[{"name":"blue","value":"#00f"}]'`)
+	createCmd.Flags().StringVar(&args.defaultVariant, "defaultVariant", "", "featureFlag.defaultVariant")
+	createCmd.Flags().StringVar(&args.rules, "rules", "", `featureFlag.rules as JSON, e.g. '[{"conditions":[{"attribute":"plan","operator":"equals","value":"enterprise"}],"isEnabled":true}]'`)
+	createCmd.Flags().StringVar(&args.prerequisites, "prerequisites", "", `featureFlag.prerequisites as JSON, e.g. '[{"flagId":1,"variant":"blue"}]'`)
+	createCmd.Flags().StringVar(&args.expiresAt, "expiresAt", "", "featureFlag.expiresAt as an RFC 3339 time, omit for no expiry")
 
 	parentCmd.AddCommand(createCmd)
 }
@@ -74,6 +127,7 @@ func featureFlagCreateCmd(core *core.Core, parentCmd *cobra.Command) {
 func featureFlagGetManyCmd(core *core.Core, parentCmd *cobra.Command) {
 	var orgSlug string
 	var appSlug string
+	var envSlug string
 	getManyCmd := &cobra.Command{
 		Use:   "getMany",
 		Short: "Get multiple feature flags",
@@ -81,7 +135,7 @@ func featureFlagGetManyCmd(core *core.Core, parentCmd *cobra.Command) {
 			authAccount := mustAuthn(core)
 			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
 
-			featureFlags, err := core.FeatFlagGetMany(opCtx, orgSlug, appSlug)
+			featureFlags, err := core.FeatFlagGetMany(opCtx, orgSlug, appSlug, envSlug)
 			if err != nil {
 				log.Fatal(err)
 			}
@@ -93,6 +147,7 @@ func featureFlagGetManyCmd(core *core.Core, parentCmd *cobra.Command) {
 	getManyCmd.MarkFlagRequired("orgSlug")
 	getManyCmd.Flags().StringVar(&appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
 	getManyCmd.MarkFlagRequired("applicationSlug")
+	getManyCmd.Flags().StringVar(&envSlug, "envSlug", "", "Environment slug, defaults to production")
 
 	parentCmd.AddCommand(getManyCmd)
 }
@@ -101,6 +156,7 @@ func featureFlagGetOneCmd(core *core.Core, parentCmd *cobra.Command) {
 	var args = struct {
 		orgSlug string
 		appSlug string
+		envSlug string
 		id      int64
 		uuid    string
 		name    string
@@ -131,6 +187,7 @@ func featureFlagGetOneCmd(core *core.Core, parentCmd *cobra.Command) {
 				core.NewFeatFlagGetOneArgs(
 					args.orgSlug,
 					args.appSlug,
+					args.envSlug,
 					id,
 					uuid,
 					name,
@@ -147,6 +204,7 @@ func featureFlagGetOneCmd(core *core.Core, parentCmd *cobra.Command) {
 	getOneCmd.MarkFlagRequired("orgSlug")
 	getOneCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
 	getOneCmd.MarkFlagRequired("applicationSlug")
+	getOneCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
 	getOneCmd.Flags().Int64Var(&args.id, "id", 0, "featureFlag.id")
 	getOneCmd.Flags().StringVar(&args.uuid, "uuid", "", "featureFlag.uuid")
 	getOneCmd.Flags().StringVar(&args.name, "name", "", "featureFlag.name")
@@ -156,30 +214,58 @@ func featureFlagGetOneCmd(core *core.Core, parentCmd *cobra.Command) {
 
 func featureFlagUpdateCmd(core *core.Core, parentCmd *cobra.Command) {
 	args := struct {
-		orgSlug     string
-		appSlug     string
-		id          int64
-		name        string
-		label       string
-		description string
-		isEnabled   bool
+		orgSlug           string
+		appSlug           string
+		envSlug           string
+		id                int64
+		name              string
+		label             string
+		description       string
+		isEnabled         bool
+		rolloutPercentage int
+		flagType          string
+		variants          string
+		defaultVariant    string
+		rules             string
+		prerequisites     string
+		expiresAt         string
 	}{}
 	updateCmd := &cobra.Command{
 		Use:   "update",
 		Short: "Update an existing feature flag",
-		Run: func(_ *cobra.Command, _ []string) {
+		Run: func(cmd *cobra.Command, _ []string) {
 			authAccount := mustAuthn(core)
 			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
 
+			var (
+				rolloutPercentage *int
+				defaultVariant    *string
+			)
+			if cmd.Flags().Changed("rolloutPercentage") {
+				rolloutPercentage = &args.rolloutPercentage
+			}
+			if cmd.Flags().Changed("defaultVariant") {
+				defaultVariant = &args.defaultVariant
+			}
+			expiresAt := mustParseFlagExpiresAt(args.expiresAt)
+
 			featureFlag, err := core.FeatFlagUpdate(opCtx,
 				core.NewFeatFlagUpdateArgs(
 					args.orgSlug,
 					args.appSlug,
+					args.envSlug,
 					args.id,
 					args.name,
 					args.label,
 					args.description,
 					args.isEnabled,
+					rolloutPercentage,
+					types.FeatureFlagType(args.flagType),
+					mustParseFlagVariants(args.variants),
+					defaultVariant,
+					mustParseFlagRules(args.rules),
+					mustParseFlagPrerequisites(args.prerequisites),
+					expiresAt,
 				),
 			)
 			if err != nil {
@@ -192,6 +278,7 @@ func featureFlagUpdateCmd(core *core.Core, parentCmd *cobra.Command) {
 	updateCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
 	updateCmd.MarkFlagRequired("orgSlug")
 	updateCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	updateCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
 	updateCmd.Flags().Int64Var(&args.id, "id", 0, "featureFlag.id")
 	updateCmd.MarkFlagRequired("id")
 	updateCmd.Flags().StringVar(&args.name, "name", "", "featureFlag.name")
@@ -201,6 +288,13 @@ func featureFlagUpdateCmd(core *core.Core, parentCmd *cobra.Command) {
 	updateCmd.Flags().StringVar(&args.description, "description", "", "featureFlag.description")
 	updateCmd.Flags().BoolVar(&args.isEnabled, "isEnabled", false, "featureFlag.isEnabled")
 	updateCmd.MarkFlagRequired("isEnabled")
+	updateCmd.Flags().IntVar(&args.rolloutPercentage, "rolloutPercentage", 0, "featureFlag.rolloutPercentage, omit for all accounts")
+	updateCmd.Flags().StringVar(&args.flagType, "flagType", string(types.FeatureFlagTypeBoolean), "featureFlag.flagType (boolean, string, number, json)")
+	updateCmd.Flags().StringVar(&args.variants, "variants", "", `featureFlag.variants as JSON, e.g. '[{"name":"blue","value":"#00f"}]'`)
+	updateCmd.Flags().StringVar(&args.defaultVariant, "defaultVariant", "", "featureFlag.defaultVariant")
+	updateCmd.Flags().StringVar(&args.rules, "rules", "", `featureFlag.rules as JSON, e.g. '[{"conditions":[{"attribute":"plan","operator":"equals","value":"enterprise"}],"isEnabled":true}]'`)
+	updateCmd.Flags().StringVar(&args.prerequisites, "prerequisites", "", `featureFlag.prerequisites as JSON, e.g. '[{"flagId":1,"variant":"blue"}]'`)
+	updateCmd.Flags().StringVar(&args.expiresAt, "expiresAt", "", "featureFlag.expiresAt as an RFC 3339 time, omit for no expiry")
 
 	parentCmd.AddCommand(updateCmd)
 }
@@ -208,7 +302,9 @@ func featureFlagUpdateCmd(core *core.Core, parentCmd *cobra.Command) {
 func featureFlagDeleteCmd(core *core.Core, parentCmd *cobra.Command) {
 	var orgSlug string
 	var appSlug string
+	var envSlug string
 	var id int64
+	var force bool
 	deleteCmd := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete a feature flag",
@@ -216,7 +312,7 @@ func featureFlagDeleteCmd(core *core.Core, parentCmd *cobra.Command) {
 			authAccount := mustAuthn(core)
 			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
 
-			err := core.FeatFlagDelete(opCtx, orgSlug, appSlug, id)
+			err := core.FeatFlagDelete(opCtx, orgSlug, appSlug, envSlug, id, force)
 			if err != nil {
 				log.Fatal(err)
 			}
@@ -228,8 +324,278 @@ func featureFlagDeleteCmd(core *core.Core, parentCmd *cobra.Command) {
 	deleteCmd.MarkFlagRequired("orgSlug")
 	deleteCmd.Flags().StringVar(&appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
 	deleteCmd.MarkFlagRequired("applicationSlug")
+	deleteCmd.Flags().StringVar(&envSlug, "envSlug", "", "Environment slug, defaults to production")
 	deleteCmd.Flags().Int64Var(&id, "id", 0, "featureFlag.id")
 	deleteCmd.MarkFlagRequired("id")
+	deleteCmd.Flags().BoolVar(&force, "force", false, "Delete even if other flags have it as a prerequisite, removing it from theirs")
 
 	parentCmd.AddCommand(deleteCmd)
 }
+
+func featureFlagEvaluateCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug    string
+		appSlug    string
+		envSlug    string
+		id         int64
+		name       string
+		accountID  int64
+		attributes string
+	}{}
+	evaluateCmd := &cobra.Command{
+		Use:   "evaluate",
+		Short: "Resolve a feature flag for an organization account",
+		Run: func(cmd *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			var (
+				id   *int64
+				name *string
+			)
+			if cmd.Flags().Changed("id") {
+				id = &args.id
+			}
+			if cmd.Flags().Changed("name") {
+				name = &args.name
+			}
+
+			var attributes map[string]any
+			if args.attributes != "" {
+				if err := json.Unmarshal([]byte(args.attributes), &attributes); err != nil {
+					log.Fatalf("invalid attributes: %s", err)
+				}
+			}
+
+			evaluation, err := core.FeatFlagEvaluate(opCtx,
+				core.NewFeatFlagEvaluateArgs(
+					args.orgSlug,
+					args.appSlug,
+					args.envSlug,
+					id,
+					name,
+					args.accountID,
+					attributes,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(evaluation)
+		},
+	}
+	evaluateCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	evaluateCmd.MarkFlagRequired("orgSlug")
+	evaluateCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	evaluateCmd.MarkFlagRequired("applicationSlug")
+	evaluateCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
+	evaluateCmd.Flags().Int64Var(&args.id, "id", 0, "featureFlag.id")
+	evaluateCmd.Flags().StringVar(&args.name, "name", "", "featureFlag.name")
+	evaluateCmd.Flags().Int64Var(&args.accountID, "accountID", 0, "account.id")
+	evaluateCmd.MarkFlagRequired("accountID")
+	evaluateCmd.Flags().StringVar(&args.attributes, "attributes", "", `Targeting attributes as JSON, e.g. '{"country":"DE"}'`)
+
+	parentCmd.AddCommand(evaluateCmd)
+}
+
+func featureFlagExplainCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug    string
+		appSlug    string
+		envSlug    string
+		id         int64
+		name       string
+		accountID  int64
+		attributes string
+	}{}
+	explainCmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Show how a feature flag is resolved for an organization account",
+		Run: func(cmd *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			var (
+				id   *int64
+				name *string
+			)
+			if cmd.Flags().Changed("id") {
+				id = &args.id
+			}
+			if cmd.Flags().Changed("name") {
+				name = &args.name
+			}
+
+			var attributes map[string]any
+			if args.attributes != "" {
+				if err := json.Unmarshal([]byte(args.attributes), &attributes); err != nil {
+					log.Fatalf("invalid attributes: %s", err)
+				}
+			}
+
+			explanation, err := core.FeatFlagExplain(opCtx,
+				core.NewFeatFlagEvaluateArgs(
+					args.orgSlug,
+					args.appSlug,
+					args.envSlug,
+					id,
+					name,
+					args.accountID,
+					attributes,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(explanation)
+		},
+	}
+	explainCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	explainCmd.MarkFlagRequired("orgSlug")
+	explainCmd.Flags().StringVar(&args.appSlug, "appSlug", "", "featureFlag.applicationSlug")
+	explainCmd.MarkFlagRequired("appSlug")
+	// --applicationSlug as used by the other flag commands still works
+	explainCmd.Flags().SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "applicationSlug" {
+			name = "appSlug"
+		}
+		return pflag.NormalizedName(name)
+	})
+	explainCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
+	explainCmd.Flags().Int64Var(&args.id, "id", 0, "featureFlag.id")
+	explainCmd.Flags().StringVar(&args.name, "name", "", "featureFlag.name")
+	explainCmd.Flags().Int64Var(&args.accountID, "accountID", 0, "account.id")
+	explainCmd.MarkFlagRequired("accountID")
+	explainCmd.Flags().StringVar(&args.attributes, "attributes", "", `Targeting attributes as JSON, e.g. '{"country":"DE"}'`)
+
+	parentCmd.AddCommand(explainCmd)
+}
+
+func featureFlagHistoryCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var appSlug string
+	var envSlug string
+	var id int64
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "Get the version history of a feature flag",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			versions, err := core.FeatFlagVersionGetMany(opCtx, orgSlug, appSlug, envSlug, id)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(versions)
+		},
+	}
+	historyCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	historyCmd.MarkFlagRequired("orgSlug")
+	historyCmd.Flags().StringVar(&appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	historyCmd.MarkFlagRequired("applicationSlug")
+	historyCmd.Flags().StringVar(&envSlug, "envSlug", "", "Environment slug, defaults to production")
+	historyCmd.Flags().Int64Var(&id, "id", 0, "featureFlag.id")
+	historyCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(historyCmd)
+}
+
+func featureFlagRollbackCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug string
+		appSlug string
+		envSlug string
+		id      int64
+		version int
+	}{}
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Restore a feature flag and its group overrides to a previous version",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			version, err := core.FeatFlagRollback(opCtx,
+				core.NewFeatFlagRollbackArgs(
+					args.orgSlug,
+					args.appSlug,
+					args.envSlug,
+					args.id,
+					args.version,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(version)
+		},
+	}
+	rollbackCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	rollbackCmd.MarkFlagRequired("orgSlug")
+	rollbackCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	rollbackCmd.MarkFlagRequired("applicationSlug")
+	rollbackCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
+	rollbackCmd.Flags().Int64Var(&args.id, "id", 0, "featureFlag.id")
+	rollbackCmd.MarkFlagRequired("id")
+	rollbackCmd.Flags().IntVar(&args.version, "version", 0, "featureFlagVersion.version")
+	rollbackCmd.MarkFlagRequired("version")
+
+	parentCmd.AddCommand(rollbackCmd)
+}
+
+func mustParseFlagVariants(raw string) []types.FeatureFlagVariant {
+	if raw == "" {
+		return nil
+	}
+
+	var variants []types.FeatureFlagVariant
+	if err := json.Unmarshal([]byte(raw), &variants); err != nil {
+		log.Fatalf("invalid variants: %s", err)
+	}
+
+	return variants
+}
+
+func mustParseFlagRules(raw string) []types.TargetingRule {
+	if raw == "" {
+		return nil
+	}
+
+	var rules []types.TargetingRule
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		log.Fatalf("invalid rules: %s", err)
+	}
+
+	return rules
+}
+
+func mustParseFlagPrerequisites(raw string) []types.FeatureFlagPrerequisite {
+	if raw == "" {
+		return nil
+	}
+
+	var prerequisites []types.FeatureFlagPrerequisite
+	if err := json.Unmarshal([]byte(raw), &prerequisites); err != nil {
+		log.Fatalf("invalid prerequisites: %s", err)
+	}
+
+	return prerequisites
+}
+
+func mustParseFlagExpiresAt(raw string) *time.Time {
+	if raw == "" {
+		return nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		log.Fatalf("invalid expiresAt: %s", err)
+	}
+
+	return &expiresAt
+}
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"switchcraft/core"
+	"switchcraft/types"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func featureFlagDiffCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug       string
+		appSlug       string
+		envSlug       string
+		targetAppSlug string
+		targetEnvSlug string
+		json          bool
+	}{}
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the feature flags of two environments or applications",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			if args.targetAppSlug == "" {
+				args.targetAppSlug = args.appSlug
+			}
+
+			diff, err := core.FeatFlagDiff(opCtx,
+				core.NewFeatFlagDiffArgs(
+					args.orgSlug,
+					args.appSlug,
+					args.envSlug,
+					args.targetAppSlug,
+					args.targetEnvSlug,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if args.json {
+				printJSON(diff)
+				return
+			}
+			printFlagSetDiff(*diff)
+		},
+	}
+	diffCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	diffCmd.MarkFlagRequired("orgSlug")
+	diffCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "Source application slug")
+	diffCmd.MarkFlagRequired("applicationSlug")
+	diffCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Source environment slug, defaults to production")
+	diffCmd.Flags().StringVar(&args.targetAppSlug, "targetApplicationSlug", "", "Target application slug, defaults to the source application")
+	diffCmd.Flags().StringVar(&args.targetEnvSlug, "targetEnvSlug", "", "Target environment slug, defaults to production")
+	diffCmd.Flags().BoolVar(&args.json, "json", false, "Print the diff as JSON")
+
+	parentCmd.AddCommand(diffCmd)
+}
+
+func featureFlagPromoteCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug       string
+		appSlug       string
+		envSlug       string
+		targetAppSlug string
+		targetEnvSlug string
+		flagNames     []string
+		dryRun        bool
+		json          bool
+	}{}
+	promoteCmd := &cobra.Command{
+		Use:   "promote",
+		Short: "Copy feature flags and their group overrides to another environment or application",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			if args.targetAppSlug == "" {
+				args.targetAppSlug = args.appSlug
+			}
+
+			promotion, err := core.FeatFlagPromote(opCtx,
+				core.NewFeatFlagPromoteArgs(
+					args.orgSlug,
+					args.appSlug,
+					args.envSlug,
+					args.targetAppSlug,
+					args.targetEnvSlug,
+					args.flagNames,
+					args.dryRun,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if args.json {
+				printJSON(promotion)
+				return
+			}
+			if promotion.DryRun {
+				fmt.Println("Dry run, nothing was changed")
+			}
+			printFlagSetDiff(promotion.FeatureFlagSetDiff)
+		},
+	}
+	promoteCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	promoteCmd.MarkFlagRequired("orgSlug")
+	promoteCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "Source application slug")
+	promoteCmd.MarkFlagRequired("applicationSlug")
+	promoteCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Source environment slug, defaults to production")
+	promoteCmd.Flags().StringVar(&args.targetAppSlug, "targetApplicationSlug", "", "Target application slug, defaults to the source application")
+	promoteCmd.Flags().StringVar(&args.targetEnvSlug, "targetEnvSlug", "", "Target environment slug, defaults to production")
+	promoteCmd.Flags().StringSliceVar(&args.flagNames, "flags", nil, "Names of the flags to promote")
+	promoteCmd.MarkFlagRequired("flags")
+	promoteCmd.Flags().BoolVar(&args.dryRun, "dryRun", false, "Show what would change without promoting")
+	promoteCmd.Flags().BoolVar(&args.json, "json", false, "Print the result as JSON")
+
+	parentCmd.AddCommand(promoteCmd)
+}
+
+// printFlagSetDiff prints one line per differing flag, marked + when only in
+// the source, - when only in the target and ~ when changed
+func printFlagSetDiff(diff types.FeatureFlagSetDiff) {
+	fmt.Printf("%s/%s -> %s/%s\n",
+		diff.SourceAppSlug,
+		diff.SourceEnvSlug,
+		diff.TargetAppSlug,
+		diff.TargetEnvSlug,
+	)
+
+	unchanged := 0
+	for _, flag := range diff.Flags {
+		switch flag.Status {
+		case types.FlagDiffStatusUnchanged:
+			unchanged++
+			continue
+		case types.FlagDiffStatusAdded:
+			fmt.Printf("+ %s\n", flag.Name)
+		case types.FlagDiffStatusRemoved:
+			fmt.Printf("- %s\n", flag.Name)
+		case types.FlagDiffStatusChanged:
+			if len(flag.Fields) > 0 {
+				fmt.Printf("~ %s: %s\n", flag.Name, strings.Join(flag.Fields, ", "))
+			} else {
+				fmt.Printf("~ %s\n", flag.Name)
+			}
+		}
+
+		for _, groupFlag := range flag.GroupFlags {
+			mark := "~"
+			switch groupFlag.Status {
+			case types.FlagDiffStatusAdded:
+				mark = "+"
+			case types.FlagDiffStatusRemoved:
+				mark = "-"
+			}
+			fmt.Printf("    %s group %d override\n", mark, groupFlag.GroupID)
+		}
+	}
+
+	fmt.Printf("%d unchanged\n", unchanged)
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"switchcraft/core"
+	"switchcraft/types"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func featureFlagArchiveCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var appSlug string
+	var envSlug string
+	var id int64
+	archiveCmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Archive a feature flag, disabling it while keeping its history",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			featureFlag, err := core.FeatFlagArchive(opCtx, orgSlug, appSlug, envSlug, id)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(featureFlag)
+		},
+	}
+	archiveCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	archiveCmd.MarkFlagRequired("orgSlug")
+	archiveCmd.Flags().StringVar(&appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	archiveCmd.MarkFlagRequired("applicationSlug")
+	archiveCmd.Flags().StringVar(&envSlug, "envSlug", "", "Environment slug, defaults to production")
+	archiveCmd.Flags().Int64Var(&id, "id", 0, "featureFlag.id")
+	archiveCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(archiveCmd)
+}
+
+func featureFlagRestoreCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var appSlug string
+	var envSlug string
+	var id int64
+	restoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Make an archived or deprecated feature flag active again",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			featureFlag, err := core.FeatFlagRestore(opCtx, orgSlug, appSlug, envSlug, id)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(featureFlag)
+		},
+	}
+	restoreCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	restoreCmd.MarkFlagRequired("orgSlug")
+	restoreCmd.Flags().StringVar(&appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	restoreCmd.MarkFlagRequired("applicationSlug")
+	restoreCmd.Flags().StringVar(&envSlug, "envSlug", "", "Environment slug, defaults to production")
+	restoreCmd.Flags().Int64Var(&id, "id", 0, "featureFlag.id")
+	restoreCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(restoreCmd)
+}
+
+func featureFlagDeprecateCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var appSlug string
+	var envSlug string
+	var id int64
+	deprecateCmd := &cobra.Command{
+		Use:   "deprecate",
+		Short: "Mark a feature flag for removal from code",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			featureFlag, err := core.FeatFlagDeprecate(opCtx, orgSlug, appSlug, envSlug, id)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(featureFlag)
+		},
+	}
+	deprecateCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	deprecateCmd.MarkFlagRequired("orgSlug")
+	deprecateCmd.Flags().StringVar(&appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	deprecateCmd.MarkFlagRequired("applicationSlug")
+	deprecateCmd.Flags().StringVar(&envSlug, "envSlug", "", "Environment slug, defaults to production")
+	deprecateCmd.Flags().Int64Var(&id, "id", 0, "featureFlag.id")
+	deprecateCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(deprecateCmd)
+}
+
+func featureFlagStaleCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug   string
+		appSlug   string
+		staleDays int
+		json      bool
+	}{}
+	staleCmd := &cobra.Command{
+		Use:   "stale",
+		Short: "Report feature flags that are candidates for removal, exits 1 when there are any",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			report, err := core.FeatFlagStaleReport(opCtx,
+				core.NewFeatFlagStaleReportArgs(args.orgSlug, args.appSlug, args.staleDays),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if args.json {
+				printJSON(report)
+			} else {
+				printStaleReport(*report)
+			}
+
+			// Lets builds be gated on having no stale flags
+			if len(report.Flags) > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+	staleCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	staleCmd.MarkFlagRequired("orgSlug")
+	staleCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	staleCmd.MarkFlagRequired("applicationSlug")
+	staleCmd.Flags().IntVar(&args.staleDays, "days", 30, "Report flags unchanged for this many days")
+	staleCmd.Flags().BoolVar(&args.json, "json", false, "Print the report as JSON")
+
+	parentCmd.AddCommand(staleCmd)
+}
+
+func printStaleReport(report types.FeatureFlagStaleReport) {
+	for _, flag := range report.Flags {
+		reasons := make([]string, len(flag.Reasons))
+		for i, reason := range flag.Reasons {
+			reasons[i] = string(reason)
+		}
+		fmt.Printf("%s: %s, last modified %s\n",
+			flag.Name,
+			strings.Join(reasons, ", "),
+			flag.LastModified.Format(time.DateOnly),
+		)
+	}
+
+	fmt.Printf("%d stale flags in %s\n", len(report.Flags), report.AppSlug)
+}
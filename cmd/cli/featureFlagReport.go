@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"encoding/csv"
+	"log"
+	"os"
+	"switchcraft/core"
+	"switchcraft/types"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func featureFlagAccountsCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug string
+		appSlug string
+		envSlug string
+		id      int64
+		name    string
+		format  string
+	}{}
+	accountsCmd := &cobra.Command{
+		Use:   "accounts",
+		Short: "Resolve a feature flag for every account in the organization",
+		Run: func(cmd *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			var (
+				id   *int64
+				name *string
+			)
+			if cmd.Flags().Changed("id") {
+				id = &args.id
+			}
+			if cmd.Flags().Changed("name") {
+				name = &args.name
+			}
+
+			evaluations, err := core.FeatFlagAccountsReport(opCtx,
+				core.NewFeatFlagAccountsReportArgs(args.orgSlug, args.appSlug, args.envSlug, id, name),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printEvaluations(evaluations, args.format)
+		},
+	}
+	accountsCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	accountsCmd.MarkFlagRequired("orgSlug")
+	accountsCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	accountsCmd.MarkFlagRequired("applicationSlug")
+	accountsCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
+	accountsCmd.Flags().Int64Var(&args.id, "id", 0, "featureFlag.id")
+	accountsCmd.Flags().StringVar(&args.name, "name", "", "featureFlag.name")
+	accountsCmd.Flags().StringVar(&args.format, "format", "json", "Output format, json or csv")
+
+	parentCmd.AddCommand(accountsCmd)
+}
+
+func featureFlagAccountFlagsCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug   string
+		appSlug   string
+		envSlug   string
+		accountID int64
+		format    string
+	}{}
+	accountFlagsCmd := &cobra.Command{
+		Use:   "accountFlags",
+		Short: "Resolve every feature flag of an application for an organization account",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			evaluations, err := core.FeatFlagAccountFlagsReport(opCtx,
+				core.NewFeatFlagAccountFlagsReportArgs(args.orgSlug, args.appSlug, args.envSlug, args.accountID),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printEvaluations(evaluations, args.format)
+		},
+	}
+	accountFlagsCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	accountFlagsCmd.MarkFlagRequired("orgSlug")
+	accountFlagsCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	accountFlagsCmd.MarkFlagRequired("applicationSlug")
+	accountFlagsCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
+	accountFlagsCmd.Flags().Int64Var(&args.accountID, "accountID", 0, "account.id")
+	accountFlagsCmd.MarkFlagRequired("accountID")
+	accountFlagsCmd.Flags().StringVar(&args.format, "format", "json", "Output format, json or csv")
+
+	parentCmd.AddCommand(accountFlagsCmd)
+}
+
+func printEvaluations(evaluations []types.FeatureFlagEvaluation, format string) {
+	switch format {
+	case "json":
+		printJSON(evaluations)
+	case "csv":
+		if err := csv.NewWriter(os.Stdout).WriteAll(types.FeatureFlagEvaluationsCSV(evaluations)); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Fatalf("invalid format '%s', must be json or csv", format)
+	}
+}
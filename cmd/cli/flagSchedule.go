@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"log"
+	"switchcraft/core"
+	"switchcraft/types"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func featureFlagScheduleCreateCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug           string
+		appSlug           string
+		envSlug           string
+		id                int64
+		groupID           int64
+		isEnabled         bool
+		rolloutPercentage int
+		executeAt         string
+	}{}
+	scheduleCmd := &cobra.Command{
+		Use:   "scheduleCreate",
+		Short: "Schedule a feature flag or group override change",
+		Run: func(cmd *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			executeAt, err := time.Parse(time.RFC3339, args.executeAt)
+			if err != nil {
+				log.Fatalf("invalid executeAt: %s", err)
+			}
+
+			var (
+				groupID           *int64
+				rolloutPercentage *int
+			)
+			if cmd.Flags().Changed("groupID") {
+				groupID = &args.groupID
+			}
+			if cmd.Flags().Changed("rolloutPercentage") {
+				rolloutPercentage = &args.rolloutPercentage
+			}
+
+			schedule, err := core.FlagScheduleCreate(opCtx,
+				core.NewFlagScheduleCreateArgs(
+					args.orgSlug,
+					args.appSlug,
+					args.envSlug,
+					args.id,
+					groupID,
+					args.isEnabled,
+					rolloutPercentage,
+					executeAt,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(schedule)
+		},
+	}
+	scheduleCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	scheduleCmd.MarkFlagRequired("orgSlug")
+	scheduleCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	scheduleCmd.MarkFlagRequired("applicationSlug")
+	scheduleCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
+	scheduleCmd.Flags().Int64Var(&args.id, "id", 0, "featureFlag.id")
+	scheduleCmd.MarkFlagRequired("id")
+	scheduleCmd.Flags().Int64Var(&args.groupID, "groupID", 0, "Group whose override to change, omit to change the flag")
+	scheduleCmd.Flags().BoolVar(&args.isEnabled, "isEnabled", false, "flagSchedule.isEnabled")
+	scheduleCmd.Flags().IntVar(&args.rolloutPercentage, "rolloutPercentage", 0, "flagSchedule.rolloutPercentage, omit for all accounts")
+	scheduleCmd.Flags().StringVar(&args.executeAt, "executeAt", "", "RFC 3339 time to apply the change at")
+	scheduleCmd.MarkFlagRequired("executeAt")
+
+	parentCmd.AddCommand(scheduleCmd)
+}
+
+func featureFlagScheduleGetManyCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var appSlug string
+	var envSlug string
+	var id int64
+	getManyCmd := &cobra.Command{
+		Use:   "scheduleGetMany",
+		Short: "Get the scheduled changes of a feature flag",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			schedules, err := core.FlagScheduleGetMany(opCtx, orgSlug, appSlug, envSlug, id)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(schedules)
+		},
+	}
+	getManyCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	getManyCmd.MarkFlagRequired("orgSlug")
+	getManyCmd.Flags().StringVar(&appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	getManyCmd.MarkFlagRequired("applicationSlug")
+	getManyCmd.Flags().StringVar(&envSlug, "envSlug", "", "Environment slug, defaults to production")
+	getManyCmd.Flags().Int64Var(&id, "id", 0, "featureFlag.id")
+	getManyCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(getManyCmd)
+}
+
+func featureFlagScheduleCancelCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var appSlug string
+	var envSlug string
+	var id int64
+	var scheduleID int64
+	cancelCmd := &cobra.Command{
+		Use:   "scheduleCancel",
+		Short: "Cancel a pending feature flag change",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			schedule, err := core.FlagScheduleCancel(opCtx, orgSlug, appSlug, envSlug, id, scheduleID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(schedule)
+		},
+	}
+	cancelCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	cancelCmd.MarkFlagRequired("orgSlug")
+	cancelCmd.Flags().StringVar(&appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	cancelCmd.MarkFlagRequired("applicationSlug")
+	cancelCmd.Flags().StringVar(&envSlug, "envSlug", "", "Environment slug, defaults to production")
+	cancelCmd.Flags().Int64Var(&id, "id", 0, "featureFlag.id")
+	cancelCmd.MarkFlagRequired("id")
+	cancelCmd.Flags().Int64Var(&scheduleID, "scheduleID", 0, "flagSchedule.id")
+	cancelCmd.MarkFlagRequired("scheduleID")
+
+	parentCmd.AddCommand(cancelCmd)
+}
@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"switchcraft/core"
+	"switchcraft/types"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func registerGroupFlagModule(core *core.Core) {
+	var groupFlagCmd = &cobra.Command{
+		Use:   "groupFlag",
+		Short: "SwitchCraft CLI organization group feature flag override module",
+	}
+	groupFlagGetManyCmd(core, groupFlagCmd)
+	groupFlagGetOneCmd(core, groupFlagCmd)
+	groupFlagUpsertCmd(core, groupFlagCmd)
+	groupFlagSetCmd(core, groupFlagCmd)
+	groupFlagDeleteCmd(core, groupFlagCmd)
+
+	rootCmd.AddCommand(groupFlagCmd)
+}
+
+func groupFlagGetManyCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug string
+		appSlug string
+		envSlug string
+		flagID  int64
+	}{}
+	getManyCmd := &cobra.Command{
+		Use:   "getMany",
+		Short: "Get every group override of a feature flag",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			groupFlags, err := core.GroupFlagsGetByFlagID(opCtx,
+				core.NewGroupFlagsGetByFlagIDArgs(args.orgSlug, args.appSlug, args.envSlug, args.flagID),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(groupFlags)
+		},
+	}
+	getManyCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	getManyCmd.MarkFlagRequired("orgSlug")
+	getManyCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	getManyCmd.MarkFlagRequired("applicationSlug")
+	getManyCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
+	getManyCmd.Flags().Int64Var(&args.flagID, "flagID", 0, "featureFlag.id")
+	getManyCmd.MarkFlagRequired("flagID")
+
+	parentCmd.AddCommand(getManyCmd)
+}
+
+func groupFlagGetOneCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug string
+		appSlug string
+		envSlug string
+		flagID  int64
+		groupID int64
+	}{}
+	getOneCmd := &cobra.Command{
+		Use:   "getOne",
+		Short: "Get a single group override of a feature flag",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			groupFlag, err := core.GroupFlagGetOne(opCtx,
+				core.NewGroupFlagGetOneArgs(args.orgSlug, args.groupID, args.appSlug, args.envSlug, args.flagID),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(groupFlag)
+		},
+	}
+	getOneCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	getOneCmd.MarkFlagRequired("orgSlug")
+	getOneCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	getOneCmd.MarkFlagRequired("applicationSlug")
+	getOneCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
+	getOneCmd.Flags().Int64Var(&args.flagID, "flagID", 0, "featureFlag.id")
+	getOneCmd.MarkFlagRequired("flagID")
+	getOneCmd.Flags().Int64Var(&args.groupID, "groupID", 0, "group.id")
+	getOneCmd.MarkFlagRequired("groupID")
+
+	parentCmd.AddCommand(getOneCmd)
+}
+
+func groupFlagUpsertCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug           string
+		appSlug           string
+		envSlug           string
+		flagID            int64
+		groupID           int64
+		isEnabled         bool
+		rolloutPercentage int
+		variant           string
+	}{}
+	upsertCmd := &cobra.Command{
+		Use:   "upsert",
+		Short: "Create or update a group override of a feature flag",
+		Run: func(cmd *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			var (
+				rolloutPercentage *int
+				variant           *string
+			)
+			if cmd.Flags().Changed("rolloutPercentage") {
+				rolloutPercentage = &args.rolloutPercentage
+			}
+			if cmd.Flags().Changed("variant") {
+				variant = &args.variant
+			}
+
+			groupFlag, err := core.GroupFlagUpsert(opCtx,
+				core.NewGroupFlagUpsertArgs(
+					args.orgSlug,
+					args.groupID,
+					args.appSlug,
+					args.envSlug,
+					args.flagID,
+					args.isEnabled,
+					rolloutPercentage,
+					variant,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(groupFlag)
+		},
+	}
+	upsertCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	upsertCmd.MarkFlagRequired("orgSlug")
+	upsertCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	upsertCmd.MarkFlagRequired("applicationSlug")
+	upsertCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
+	upsertCmd.Flags().Int64Var(&args.flagID, "flagID", 0, "featureFlag.id")
+	upsertCmd.MarkFlagRequired("flagID")
+	upsertCmd.Flags().Int64Var(&args.groupID, "groupID", 0, "group.id")
+	upsertCmd.MarkFlagRequired("groupID")
+	upsertCmd.Flags().BoolVar(&args.isEnabled, "isEnabled", false, "groupFlag.isEnabled")
+	upsertCmd.Flags().IntVar(&args.rolloutPercentage, "rolloutPercentage", 0, "groupFlag.rolloutPercentage, omit for all group members")
+	upsertCmd.Flags().StringVar(&args.variant, "variant", "", "groupFlag.variant, omit to serve the flag's variant")
+
+	parentCmd.AddCommand(upsertCmd)
+}
+
+func groupFlagSetCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug    string
+		appSlug    string
+		envSlug    string
+		flagID     int64
+		groupFlags string
+	}{}
+	setCmd := &cobra.Command{
+		Use:   "set",
+		Short: "Replace every group override of a feature flag, groups left out are removed",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			var groupFlags []types.GroupFlagOverride
+			if err := json.Unmarshal([]byte(args.groupFlags), &groupFlags); err != nil {
+				log.Fatal(fmt.Errorf("invalid groupFlags: %w", err))
+			}
+
+			result, err := core.GroupFlagsSet(opCtx,
+				core.NewGroupFlagsSetArgs(args.orgSlug, args.appSlug, args.envSlug, args.flagID, groupFlags),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(result)
+		},
+	}
+	setCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	setCmd.MarkFlagRequired("orgSlug")
+	setCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	setCmd.MarkFlagRequired("applicationSlug")
+	setCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
+	setCmd.Flags().Int64Var(&args.flagID, "flagID", 0, "featureFlag.id")
+	setCmd.MarkFlagRequired("flagID")
+	setCmd.Flags().StringVar(&args.groupFlags, "groupFlags", "[]", "Group overrides as JSON, e.g. '[{\"groupId\":1,\"isEnabled\":true}]'")
+
+	parentCmd.AddCommand(setCmd)
+}
+
+func groupFlagDeleteCmd(core *core.Core, parentCmd *cobra.Command) {
+	var args = struct {
+		orgSlug string
+		appSlug string
+		envSlug string
+		flagID  int64
+		groupID int64
+	}{}
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a group override of a feature flag",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			if err := core.GroupFlagDelete(opCtx,
+				core.NewGroupFlagDeleteArgs(args.orgSlug, args.groupID, args.appSlug, args.envSlug, args.flagID),
+			); err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("Group override for group '%v' deleted successfully\n", args.groupID)
+		},
+	}
+	deleteCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	deleteCmd.MarkFlagRequired("orgSlug")
+	deleteCmd.Flags().StringVar(&args.appSlug, "applicationSlug", "", "featureFlag.applicationSlug")
+	deleteCmd.MarkFlagRequired("applicationSlug")
+	deleteCmd.Flags().StringVar(&args.envSlug, "envSlug", "", "Environment slug, defaults to production")
+	deleteCmd.Flags().Int64Var(&args.flagID, "flagID", 0, "featureFlag.id")
+	deleteCmd.MarkFlagRequired("flagID")
+	deleteCmd.Flags().Int64Var(&args.groupID, "groupID", 0, "group.id")
+	deleteCmd.MarkFlagRequired("groupID")
+
+	parentCmd.AddCommand(deleteCmd)
+}
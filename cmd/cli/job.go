@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"log"
+	"switchcraft/core"
+	"switchcraft/types"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func registerJobModule(core *core.Core) {
+	var jobCmd = &cobra.Command{
+		Use:   "job",
+		Short: "SwitchCraft CLI background job module",
+	}
+	jobGetManyCmd(core, jobCmd)
+	jobGetOneCmd(core, jobCmd)
+	jobRetryCmd(core, jobCmd)
+
+	rootCmd.AddCommand(jobCmd)
+}
+
+func jobGetManyCmd(core *core.Core, parentCmd *cobra.Command) {
+	var jobStatus string
+	var limit int
+	getManyCmd := &cobra.Command{
+		Use:   "getMany",
+		Short: "Get the latest background jobs",
+		Run: func(cmd *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			var status *types.JobStatus
+			if cmd.Flags().Changed("status") {
+				s := types.JobStatus(jobStatus)
+				status = &s
+			}
+
+			jobs, err := core.JobGetMany(opCtx, status, limit)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(jobs)
+		},
+	}
+	getManyCmd.Flags().StringVar(&jobStatus, "status", "", "Only jobs with status pending, running, succeeded or dead")
+	getManyCmd.Flags().IntVar(&limit, "limit", 50, "Max number of jobs")
+
+	parentCmd.AddCommand(getManyCmd)
+}
+
+func jobGetOneCmd(core *core.Core, parentCmd *cobra.Command) {
+	var jobID int64
+	getOneCmd := &cobra.Command{
+		Use:   "getOne",
+		Short: "Get a background job by id",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			job, err := core.JobGetOne(opCtx, jobID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(job)
+		},
+	}
+	getOneCmd.Flags().Int64Var(&jobID, "id", 0, "job.id")
+	getOneCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(getOneCmd)
+}
+
+func jobRetryCmd(core *core.Core, parentCmd *cobra.Command) {
+	var jobID int64
+	retryCmd := &cobra.Command{
+		Use:   "retry",
+		Short: "Queue a dead background job to run again",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			job, err := core.JobRetry(opCtx, jobID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(job)
+		},
+	}
+	retryCmd.Flags().Int64Var(&jobID, "id", 0, "job.id")
+	retryCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(retryCmd)
+}
@@ -20,6 +20,8 @@ func registerOrgGroupModule(core *core.Core) {
 	orgGroupGetOneCmd(core, orgGroupCmd)
 	orgGroupUpdateCmd(core, orgGroupCmd)
 	orgGroupDeleteCmd(core, orgGroupCmd)
+	orgGroupRoleSetCmd(core, orgGroupCmd)
+	orgGroupRoleRemoveCmd(core, orgGroupCmd)
 
 	rootCmd.AddCommand(orgGroupCmd)
 }
@@ -192,3 +194,65 @@ func orgGroupDeleteCmd(core *core.Core, parentCmd *cobra.Command) {
 
 	parentCmd.AddCommand(deleteCmd)
 }
+
+func orgGroupRoleSetCmd(core *core.Core, parentCmd *cobra.Command) {
+	args := struct {
+		orgSlug string
+		groupID int64
+		role    string
+	}{}
+	roleSetCmd := &cobra.Command{
+		Use:   "roleSet",
+		Short: "Grant an organization role to every member of a group",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			role, err := core.OrgGroupRoleSet(opCtx,
+				core.NewOrgGroupRoleSetArgs(
+					args.orgSlug,
+					args.groupID,
+					types.OrgRole(args.role),
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(role)
+		},
+	}
+	roleSetCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	roleSetCmd.MarkFlagRequired("orgSlug")
+	roleSetCmd.Flags().Int64Var(&args.groupID, "id", 0, "group.id")
+	roleSetCmd.MarkFlagRequired("id")
+	roleSetCmd.Flags().StringVar(&args.role, "role", "", "owner, admin, editor, or viewer")
+	roleSetCmd.MarkFlagRequired("role")
+
+	parentCmd.AddCommand(roleSetCmd)
+}
+
+func orgGroupRoleRemoveCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var groupID int64
+	roleRemoveCmd := &cobra.Command{
+		Use:   "roleRemove",
+		Short: "Remove the organization role granted by a group",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			if err := core.OrgGroupRoleDelete(opCtx, orgSlug, groupID); err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("Role removed from organization group '%v'\n", groupID)
+		},
+	}
+	roleRemoveCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	roleRemoveCmd.MarkFlagRequired("orgSlug")
+	roleRemoveCmd.Flags().Int64Var(&groupID, "id", 0, "group.id")
+	roleRemoveCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(roleRemoveCmd)
+}
@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"switchcraft/core"
+	"switchcraft/types"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func registerOrgSegmentModule(core *core.Core) {
+	var orgSegmentCmd = &cobra.Command{
+		Use:   "orgSegment",
+		Short: "SwitchCraft CLI organization segment module",
+	}
+	orgSegmentCreateCmd(core, orgSegmentCmd)
+	orgSegmentGetManyCmd(core, orgSegmentCmd)
+	orgSegmentGetOneCmd(core, orgSegmentCmd)
+	orgSegmentUpdateCmd(core, orgSegmentCmd)
+	orgSegmentDeleteCmd(core, orgSegmentCmd)
+	orgSegmentFlagsCmd(core, orgSegmentCmd)
+
+	rootCmd.AddCommand(orgSegmentCmd)
+}
+
+func orgSegmentCreateCmd(core *core.Core, parentCmd *cobra.Command) {
+	args := struct {
+		orgSlug     string
+		name        string
+		description string
+		rules       string
+		accountIDs  []int64
+	}{}
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create new organization segment",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			segment, err := core.OrgSegmentCreate(opCtx,
+				core.NewOrgSegmentCreateArgs(
+					args.orgSlug,
+					args.name,
+					args.description,
+					mustParseSegmentRules(args.rules),
+					args.accountIDs,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(segment)
+		},
+	}
+	createCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	createCmd.MarkFlagRequired("orgSlug")
+	createCmd.Flags().StringVar(&args.name, "name", "", "segment.name")
+	createCmd.MarkFlagRequired("name")
+	createCmd.Flags().StringVar(&args.description, "description", "", "segment.description")
+	createCmd.Flags().StringVar(&args.rules, "rules", "", "segment.rules as JSON, e.g. '[{\"conditions\":[...]}]'")
+	createCmd.Flags().Int64SliceVar(&args.accountIDs, "accountIDs", nil, "Accounts explicitly in the segment")
+
+	parentCmd.AddCommand(createCmd)
+}
+
+func orgSegmentGetManyCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	getManyCmd := &cobra.Command{
+		Use:   "getMany",
+		Short: "Get multiple organization segments",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			segments, err := core.OrgSegmentGetMany(opCtx, orgSlug)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(segments)
+		},
+	}
+	getManyCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	getManyCmd.MarkFlagRequired("orgSlug")
+
+	parentCmd.AddCommand(getManyCmd)
+}
+
+func orgSegmentGetOneCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var segmentID int64
+	var segmentUUID string
+	getOneCmd := &cobra.Command{
+		Use:   "getOne",
+		Short: "Get an organization segment by id or uuid",
+		Run: func(cmd *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			var (
+				id   *int64
+				uuid *string
+			)
+			if cmd.Flags().Changed("id") {
+				id = &segmentID
+			}
+			if cmd.Flags().Changed("uuid") {
+				uuid = &segmentUUID
+			}
+
+			segment, err := core.OrgSegmentGetOne(opCtx,
+				core.NewOrgSegmentGetOneArgs(
+					orgSlug,
+					id,
+					uuid,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(segment)
+		},
+	}
+	getOneCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	getOneCmd.MarkFlagRequired("orgSlug")
+	getOneCmd.Flags().Int64Var(&segmentID, "id", 0, "segment.id")
+	getOneCmd.Flags().StringVar(&segmentUUID, "uuid", "", "segment.uuid")
+
+	parentCmd.AddCommand(getOneCmd)
+}
+
+func orgSegmentUpdateCmd(core *core.Core, parentCmd *cobra.Command) {
+	args := struct {
+		orgSlug     string
+		id          int64
+		name        string
+		description string
+		rules       string
+		accountIDs  []int64
+	}{}
+	updateCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update an existing organization segment",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			segment, err := core.OrgSegmentUpdate(opCtx,
+				core.NewOrgSegmentUpdateArgs(
+					args.orgSlug,
+					args.id,
+					args.name,
+					args.description,
+					mustParseSegmentRules(args.rules),
+					args.accountIDs,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(segment)
+		},
+	}
+	updateCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	updateCmd.MarkFlagRequired("orgSlug")
+	updateCmd.Flags().Int64Var(&args.id, "id", 0, "segment.id")
+	updateCmd.MarkFlagRequired("id")
+	updateCmd.Flags().StringVar(&args.name, "name", "", "segment.name")
+	updateCmd.MarkFlagRequired("name")
+	updateCmd.Flags().StringVar(&args.description, "description", "", "segment.description")
+	updateCmd.Flags().StringVar(&args.rules, "rules", "", "segment.rules as JSON, replaces existing rules")
+	updateCmd.Flags().Int64SliceVar(&args.accountIDs, "accountIDs", nil, "Accounts explicitly in the segment, replaces existing accounts")
+
+	parentCmd.AddCommand(updateCmd)
+}
+
+func orgSegmentDeleteCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var segmentID int64
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete an organization segment no longer referenced by any flag",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			if err := core.OrgSegmentDelete(opCtx, orgSlug, segmentID); err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("Organization segment '%v' deleted successfully\n", segmentID)
+		},
+	}
+	deleteCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	deleteCmd.MarkFlagRequired("orgSlug")
+	deleteCmd.Flags().Int64Var(&segmentID, "id", 0, "segment.id")
+	deleteCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(deleteCmd)
+}
+
+func orgSegmentFlagsCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var segmentID int64
+	flagsCmd := &cobra.Command{
+		Use:   "flags",
+		Short: "List flags whose targeting rules reference a segment",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			refs, err := core.OrgSegmentFlagRefsGetMany(opCtx, orgSlug, segmentID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(refs)
+		},
+	}
+	flagsCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	flagsCmd.MarkFlagRequired("orgSlug")
+	flagsCmd.Flags().Int64Var(&segmentID, "id", 0, "segment.id")
+	flagsCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(flagsCmd)
+}
+
+func mustParseSegmentRules(raw string) []types.SegmentRule {
+	if raw == "" {
+		return nil
+	}
+
+	var rules []types.SegmentRule
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		log.Fatalf("invalid rules: %s", err)
+	}
+
+	return rules
+}
@@ -10,14 +10,23 @@ import (
 
 func registerRestModule(logger *types.Logger, core *core.Core) {
 	var restPort string
+	var workers int
 	var restCmd = &cobra.Command{
 		Use:   "serve",
 		Short: "SwitchCraft REST API server",
 		Run: func(_ *cobra.Command, _ []string) {
+			// Run on every instance, only one executes schedules at a time and
+			// each job is only run by one worker
+			go core.FlagScheduleRun(baseCtx)
+			if workers > 0 {
+				go core.JobWorkerRun(baseCtx, workers)
+			}
+
 			rest.Start(logger, core, restPort)
 		},
 	}
 	restCmd.Flags().StringVar(&restPort, "port", "8080", "REST API server port")
+	restCmd.Flags().IntVar(&workers, "workers", 4, "Background job workers, 0 leaves jobs to worker processes")
 
 	rootCmd.AddCommand(restCmd)
 }
@@ -2,9 +2,7 @@ package cli
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"log"
 	"os"
 	"switchcraft/core"
 	"switchcraft/types"
@@ -30,13 +28,13 @@ func seedAllCmd(core *core.Core, parentCmd *cobra.Command) {
 		Use:   "all",
 		Short: "Seed all tables with test data",
 		Run: func(cmd *cobra.Command, _ []string) {
-			seed := mustParseSeedFile(dataFile)
+			config := mustParseConfigFile(dataFile)
 
 			wg := &sync.WaitGroup{}
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				seedOrganizations(wg, core, seed.Organizations)
+				seedOrganizations(wg, core, config.Organizations)
 			}()
 			wg.Wait()
 		},
@@ -47,85 +45,66 @@ func seedAllCmd(core *core.Core, parentCmd *cobra.Command) {
 	parentCmd.AddCommand(allCmd)
 }
 
-func seedOrganizations(wg *sync.WaitGroup, core *core.Core, seedOrgs []seedOrganization) {
+func seedOrganizations(wg *sync.WaitGroup, core *core.Core, seedOrgs []types.ApplyOrganization) {
 	wg.Add(len(seedOrgs))
 	for _, seedOrg := range seedOrgs {
 		defer wg.Done()
 
-		account, err := core.Signup(context.Background(), core.NewOrgAccountSignupArgs(
+		signupCtx := types.NewOperationCtx(context.Background(), "", time.Now(), types.Account{})
+		owner, org, err := core.OrgSignup(signupCtx, core.NewOrgSignupArgs(
 			seedOrg.Owner.FirstName,
 			seedOrg.Owner.LastName,
 			seedOrg.Owner.Email,
 			seedOrg.Owner.Username,
 			os.Getenv("SWITCHCRAFT_SEED_PASS"),
+			seedOrg.Name,
+			seedOrg.Slug,
 		))
 		if err != nil {
 			fmt.Printf(
-				"error creating owner '%s' for org '%s' - %s\n",
-				seedOrg.Owner.Username,
-				seedOrg.Name,
-				err,
-			)
-			continue
-		}
-		fmt.Printf("Account created - '%s'\n", account.Username)
-
-		initialCtx := types.NewOperationCtx(context.Background(), "", time.Now(), *account)
-
-		org, err := core.OrgCreate(
-			initialCtx,
-			core.NewOrgCreateArgs(
+				"error creating org '%s' with owner '%s' - %s\n",
 				seedOrg.Name,
-				seedOrg.Slug,
-				account.ID,
-			),
-		)
-		if err != nil {
-			fmt.Printf(
-				"error creating org '%s' - %s",
-				seedOrg.Name,
-				err,
-			)
-			continue
-		}
-		fmt.Printf("Organization created - '%s'\n", org.Name)
-
-		owner, err := core.OrgAccountSetOrgID(initialCtx, org.ID, account.ID)
-		if err != nil {
-			fmt.Printf(
-				"error setting owner '%s' orgID (orgName: %s, orgID: %v) - %s",
-				account.Username,
-				org.Name,
-				org.ID,
+				seedOrg.Owner.Username,
 				err,
 			)
 			continue
 		}
-		fmt.Printf("Set org '%s' owner's orgID - '%s' orgID '%v'\n", org.Name, owner.Username, *owner.OrgID)
+		fmt.Printf("Organization created - '%s' owned by '%s'\n", org.Name, owner.Username)
 
 		opCtx := types.NewOperationCtx(context.Background(), "", time.Now(), *owner)
 
+		// Group overrides need both the flags and the groups, so they are
+		// seeded once the applications and groups are done
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			seedApplications(wg, core, opCtx, org.Slug, seedOrg.Applications)
-		}()
 
-		// Ensure orgAccounts are created before attempting to create groups
-		// and populate with members
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
+			orgWg := &sync.WaitGroup{}
+			orgWg.Add(1)
+			go func() {
+				defer orgWg.Done()
+				seedApplications(orgWg, core, opCtx, org.Slug, seedOrg.Applications)
+			}()
 
-			acctWg := &sync.WaitGroup{}
-			acctWg.Add(1)
+			// Ensure orgAccounts are created before attempting to create groups
+			// and populate with members
+			orgWg.Add(1)
 			go func() {
-				defer acctWg.Done()
-				seedOrgAccounts(acctWg, core, opCtx, org.Slug, seedOrg.Accounts)
+				defer orgWg.Done()
+
+				acctWg := &sync.WaitGroup{}
+				acctWg.Add(1)
+				go func() {
+					defer acctWg.Done()
+					seedOrgAccounts(acctWg, core, opCtx, org.Slug, seedOrg.Accounts)
+				}()
+				acctWg.Wait()
+
+				seedOrgGroups(orgWg, core, opCtx, org.Slug, seedOrg.Groups)
 			}()
-			acctWg.Wait()
+			orgWg.Wait()
 
-			seedOrgGroups(wg, core, opCtx, org.Slug, seedOrg.Groups)
+			seedGroupFlags(core, opCtx, org.Slug, seedOrg.Applications)
 		}()
 	}
 }
@@ -135,7 +114,7 @@ func seedOrgAccounts(
 	core *core.Core,
 	ctx context.Context,
 	orgSlug string,
-	seedAccounts []seedAccount,
+	seedAccounts []types.ApplyAccount,
 ) {
 	wg.Add(len(seedAccounts))
 	for _, seedAccount := range seedAccounts {
@@ -169,7 +148,7 @@ func seedOrgGroups(
 	core *core.Core,
 	ctx context.Context,
 	orgSlug string,
-	seedOrgGroups []seedGroup,
+	seedOrgGroups []types.ApplyGroup,
 ) {
 	wg.Add(len(seedOrgGroups))
 	for _, seedGroup := range seedOrgGroups {
@@ -239,7 +218,7 @@ func seedApplications(
 	core *core.Core,
 	ctx context.Context,
 	orgSlug string,
-	seedApps []seedApplication,
+	seedApps []types.ApplyApplication,
 ) {
 	wg.Add(len(seedApps))
 	for _, seedApp := range seedApps {
@@ -265,7 +244,7 @@ func seedApplications(
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			seedFeatureFlags(wg, core, ctx, orgSlug, seedApp.Slug, seedApp.FeatureFlags)
+			seedFeatureFlags(wg, core, ctx, orgSlug, seedApp.Slug, seedApp.Environment, seedApp.FeatureFlags)
 		}()
 	}
 
@@ -277,7 +256,8 @@ func seedFeatureFlags(
 	ctx context.Context,
 	orgSlug string,
 	appSlug string,
-	seedFlags []seedFeatureFlag,
+	envSlug string,
+	seedFlags []types.ApplyFeatureFlag,
 ) {
 	wg.Add(len(seedFlags))
 	for _, seedFlag := range seedFlags {
@@ -286,10 +266,18 @@ func seedFeatureFlags(
 			core.NewFeatFlagCreateArgs(
 				orgSlug,
 				appSlug,
+				envSlug,
 				seedFlag.Name,
 				seedFlag.Label,
 				seedFlag.Description,
 				seedFlag.IsEnabled,
+				seedFlag.RolloutPercentage,
+				seedFlag.FlagType,
+				seedFlag.Variants,
+				seedFlag.DefaultVariant,
+				seedFlag.Rules,
+				nil,
+				nil,
 			),
 		)
 		if err != nil {
@@ -305,55 +293,70 @@ func seedFeatureFlags(
 	}
 }
 
-type seed struct {
-	Organizations []seedOrganization `json:"organizations"`
-}
-
-type seedOrganization struct {
-	Name         string            `json:"name"`
-	Slug         string            `json:"slug"`
-	Owner        seedAccount       `json:"owner"`
-	Accounts     []seedAccount     `json:"accounts"`
-	Groups       []seedGroup       `json:"groups"`
-	Applications []seedApplication `json:"applications"`
-}
-
-type seedAccount struct {
-	FirstName string `json:"firstName"`
-	LastName  string `json:"lastName"`
-	Email     string `json:"email"`
-	Username  string `json:"username"`
-}
-
-type seedGroup struct {
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	Members     []string `json:"members"`
-}
-
-type seedApplication struct {
-	Name         string            `json:"name"`
-	Slug         string            `json:"slug"`
-	FeatureFlags []seedFeatureFlag `json:"featureFlags"`
-}
-
-type seedFeatureFlag struct {
-	Name        string `json:"name"`
-	Label       string `json:"label"`
-	Description string `json:"description"`
-	IsEnabled   bool   `json:"isEnabled"`
-}
-
-func mustParseSeedFile(filepath string) seed {
-	seedFile, err := os.ReadFile(filepath)
+func seedGroupFlags(
+	core *core.Core,
+	ctx context.Context,
+	orgSlug string,
+	seedApps []types.ApplyApplication,
+) {
+	groups, err := core.OrgGroupGetMany(ctx, orgSlug)
 	if err != nil {
-		log.Fatal(err)
+		fmt.Printf("error getting groups for org '%s' - %s\n", orgSlug, err)
+		return
 	}
-
-	var seed seed
-	if err = json.Unmarshal([]byte(seedFile), &seed); err != nil {
-		log.Fatal(err)
+	groupIDs := make(map[string]int64, len(groups))
+	for _, group := range groups {
+		groupIDs[group.Name] = group.ID
 	}
 
-	return seed
+	for _, seedApp := range seedApps {
+		for _, seedFlag := range seedApp.FeatureFlags {
+			if len(seedFlag.GroupFlags) == 0 {
+				continue
+			}
+
+			flag, err := core.FeatFlagGetOne(ctx,
+				core.NewFeatFlagGetOneArgs(orgSlug, seedApp.Slug, seedApp.Environment, nil, nil, &seedFlag.Name),
+			)
+			if err != nil {
+				fmt.Printf(
+					"error locating feature flag '%s' for app '%s' - %s\n",
+					seedFlag.Name,
+					seedApp.Slug,
+					err,
+				)
+				continue
+			}
+
+			for _, seedGroupFlag := range seedFlag.GroupFlags {
+				groupID, ok := groupIDs[seedGroupFlag.Group]
+				if !ok {
+					fmt.Printf("error locating group '%s'\n", seedGroupFlag.Group)
+					continue
+				}
+
+				if _, err := core.GroupFlagUpsert(ctx,
+					core.NewGroupFlagUpsertArgs(
+						orgSlug,
+						groupID,
+						seedApp.Slug,
+						seedApp.Environment,
+						flag.ID,
+						seedGroupFlag.IsEnabled,
+						seedGroupFlag.RolloutPercentage,
+						seedGroupFlag.Variant,
+					),
+				); err != nil {
+					fmt.Printf(
+						"error overriding feature flag '%s' for group '%s' - %s\n",
+						flag.Name,
+						seedGroupFlag.Group,
+						err,
+					)
+					continue
+				}
+				fmt.Printf("Group override created - '%s' for '%s'\n", flag.Name, seedGroupFlag.Group)
+			}
+		}
+	}
 }
@@ -0,0 +1,253 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"switchcraft/core"
+	"switchcraft/types"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func registerWebhookModule(core *core.Core) {
+	var webhookCmd = &cobra.Command{
+		Use:   "webhook",
+		Short: "SwitchCraft CLI organization webhook module",
+	}
+	webhookCreateCmd(core, webhookCmd)
+	webhookGetManyCmd(core, webhookCmd)
+	webhookGetOneCmd(core, webhookCmd)
+	webhookUpdateCmd(core, webhookCmd)
+	webhookDeleteCmd(core, webhookCmd)
+	webhookDeliveriesCmd(core, webhookCmd)
+	webhookRedeliverCmd(core, webhookCmd)
+
+	rootCmd.AddCommand(webhookCmd)
+}
+
+func webhookCreateCmd(core *core.Core, parentCmd *cobra.Command) {
+	args := struct {
+		orgSlug    string
+		url        string
+		secret     string
+		eventTypes []string
+		isEnabled  bool
+	}{}
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create new organization webhook",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			webhook, err := core.WebhookCreate(opCtx,
+				core.NewWebhookCreateArgs(
+					args.orgSlug,
+					args.url,
+					args.secret,
+					webhookEventTypes(args.eventTypes),
+					args.isEnabled,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(webhook)
+		},
+	}
+	createCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	createCmd.MarkFlagRequired("orgSlug")
+	createCmd.Flags().StringVar(&args.url, "url", "", "webhook.url")
+	createCmd.MarkFlagRequired("url")
+	createCmd.Flags().StringVar(&args.secret, "secret", "", "webhook.secret used to sign deliveries")
+	createCmd.MarkFlagRequired("secret")
+	createCmd.Flags().StringSliceVar(&args.eventTypes, "eventTypes", nil, "Event types to send, defaults to all")
+	createCmd.Flags().BoolVar(&args.isEnabled, "isEnabled", true, "webhook.isEnabled")
+
+	parentCmd.AddCommand(createCmd)
+}
+
+func webhookGetManyCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	getManyCmd := &cobra.Command{
+		Use:   "getMany",
+		Short: "Get all organization webhooks",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			webhooks, err := core.WebhookGetMany(opCtx, orgSlug)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(webhooks)
+		},
+	}
+	getManyCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	getManyCmd.MarkFlagRequired("orgSlug")
+
+	parentCmd.AddCommand(getManyCmd)
+}
+
+func webhookGetOneCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var webhookID int64
+	getOneCmd := &cobra.Command{
+		Use:   "getOne",
+		Short: "Get an organization webhook by id",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			webhook, err := core.WebhookGetOne(opCtx, orgSlug, webhookID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(webhook)
+		},
+	}
+	getOneCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	getOneCmd.MarkFlagRequired("orgSlug")
+	getOneCmd.Flags().Int64Var(&webhookID, "id", 0, "webhook.id")
+	getOneCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(getOneCmd)
+}
+
+func webhookUpdateCmd(core *core.Core, parentCmd *cobra.Command) {
+	args := struct {
+		orgSlug    string
+		id         int64
+		url        string
+		secret     string
+		eventTypes []string
+		isEnabled  bool
+	}{}
+	updateCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update an existing organization webhook",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			webhook, err := core.WebhookUpdate(opCtx,
+				core.NewWebhookUpdateArgs(
+					args.orgSlug,
+					args.id,
+					args.url,
+					args.secret,
+					webhookEventTypes(args.eventTypes),
+					args.isEnabled,
+				),
+			)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(webhook)
+		},
+	}
+	updateCmd.Flags().StringVar(&args.orgSlug, "orgSlug", "", "Organization slug")
+	updateCmd.MarkFlagRequired("orgSlug")
+	updateCmd.Flags().Int64Var(&args.id, "id", 0, "webhook.id")
+	updateCmd.MarkFlagRequired("id")
+	updateCmd.Flags().StringVar(&args.url, "url", "", "webhook.url")
+	updateCmd.MarkFlagRequired("url")
+	updateCmd.Flags().StringVar(&args.secret, "secret", "", "webhook.secret, keeps the current secret when omitted")
+	updateCmd.Flags().StringSliceVar(&args.eventTypes, "eventTypes", nil, "Event types to send, defaults to all")
+	updateCmd.Flags().BoolVar(&args.isEnabled, "isEnabled", true, "webhook.isEnabled")
+
+	parentCmd.AddCommand(updateCmd)
+}
+
+func webhookDeleteCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var webhookID int64
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete an organization webhook and its delivery log",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			if err := core.WebhookDelete(opCtx, orgSlug, webhookID); err != nil {
+				log.Fatal(err)
+			}
+
+			fmt.Printf("Webhook '%v' deleted successfully\n", webhookID)
+		},
+	}
+	deleteCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	deleteCmd.MarkFlagRequired("orgSlug")
+	deleteCmd.Flags().Int64Var(&webhookID, "id", 0, "webhook.id")
+	deleteCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(deleteCmd)
+}
+
+func webhookDeliveriesCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var webhookID int64
+	deliveriesCmd := &cobra.Command{
+		Use:   "deliveries",
+		Short: "List the latest deliveries of an organization webhook",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			deliveries, err := core.WebhookDeliveryGetMany(opCtx, orgSlug, webhookID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(deliveries)
+		},
+	}
+	deliveriesCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	deliveriesCmd.MarkFlagRequired("orgSlug")
+	deliveriesCmd.Flags().Int64Var(&webhookID, "id", 0, "webhook.id")
+	deliveriesCmd.MarkFlagRequired("id")
+
+	parentCmd.AddCommand(deliveriesCmd)
+}
+
+func webhookRedeliverCmd(core *core.Core, parentCmd *cobra.Command) {
+	var orgSlug string
+	var webhookID int64
+	var deliveryID int64
+	redeliverCmd := &cobra.Command{
+		Use:   "redeliver",
+		Short: "Queue a webhook delivery to be sent again",
+		Run: func(_ *cobra.Command, _ []string) {
+			authAccount := mustAuthn(core)
+			opCtx := types.NewOperationCtx(baseCtx, "", time.Now(), *authAccount)
+
+			delivery, err := core.WebhookRedeliver(opCtx, orgSlug, webhookID, deliveryID)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			printJSON(delivery)
+		},
+	}
+	redeliverCmd.Flags().StringVar(&orgSlug, "orgSlug", "", "Organization slug")
+	redeliverCmd.MarkFlagRequired("orgSlug")
+	redeliverCmd.Flags().Int64Var(&webhookID, "id", 0, "webhook.id")
+	redeliverCmd.MarkFlagRequired("id")
+	redeliverCmd.Flags().Int64Var(&deliveryID, "deliveryID", 0, "webhookDelivery.id")
+	redeliverCmd.MarkFlagRequired("deliveryID")
+
+	parentCmd.AddCommand(redeliverCmd)
+}
+
+func webhookEventTypes(raw []string) []types.WebhookEventType {
+	eventTypes := make([]types.WebhookEventType, 0, len(raw))
+	for _, eventType := range raw {
+		eventTypes = append(eventTypes, types.WebhookEventType(eventType))
+	}
+	return eventTypes
+}
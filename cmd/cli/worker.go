@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"switchcraft/core"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+func registerWorkerModule(core *core.Core) {
+	var workers int
+	var workerCmd = &cobra.Command{
+		Use:   "worker",
+		Short: "SwitchCraft background job worker",
+		Run: func(_ *cobra.Command, _ []string) {
+			if workers < 1 {
+				log.Fatal("--workers must be at least 1")
+			}
+
+			// Jobs interrupted by shutdown are picked up again once their
+			// lock runs out
+			ctx, stop := signal.NotifyContext(baseCtx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			go core.FlagScheduleRun(ctx)
+
+			fmt.Printf("Worker running with %d job workers\n", workers)
+			core.JobWorkerRun(ctx, workers)
+		},
+	}
+	workerCmd.Flags().IntVar(&workers, "workers", 4, "Number of concurrent job workers")
+
+	rootCmd.AddCommand(workerCmd)
+}
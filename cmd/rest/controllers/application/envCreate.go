@@ -0,0 +1,36 @@
+package application
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+)
+
+type envCreateArgs struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+func (c *appController) EnvCreate(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	appSlug := r.PathValue("appSlug")
+	if orgSlug == "" || appSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	body := &envCreateArgs{}
+	if err := restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	env, err := c.core.EnvCreate(r.Context(),
+		c.core.NewEnvCreateArgs(orgSlug, appSlug, body.Name, body.Slug),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, env)
+}
@@ -0,0 +1,23 @@
+package application
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *appController) EnvDelete(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	appSlug := r.PathValue("appSlug")
+	envSlug := r.PathValue("envSlug")
+	if orgSlug == "" || appSlug == "" || envSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	if err := c.core.EnvDelete(r.Context(), orgSlug, appSlug, envSlug); err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, nil)
+}
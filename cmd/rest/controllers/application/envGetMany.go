@@ -0,0 +1,23 @@
+package application
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *appController) EnvGetMany(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	appSlug := r.PathValue("appSlug")
+	if orgSlug == "" || appSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	envs, err := c.core.EnvGetMany(r.Context(), orgSlug, appSlug)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, envs)
+}
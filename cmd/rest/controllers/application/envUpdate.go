@@ -0,0 +1,37 @@
+package application
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+)
+
+type envUpdateArgs struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+func (c *appController) EnvUpdate(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	appSlug := r.PathValue("appSlug")
+	envSlug := r.PathValue("envSlug")
+	if orgSlug == "" || appSlug == "" || envSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	body := &envUpdateArgs{}
+	if err := restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	env, err := c.core.EnvUpdate(r.Context(),
+		c.core.NewEnvUpdateArgs(orgSlug, appSlug, envSlug, body.Name, body.Slug),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, env)
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+// Evaluation context for targeting rules. Attributes can only be sent with
+// POST, GET takes the account ID as a query param.
+type appEvaluateArgs struct {
+	AccountID  int64          `json:"accountId"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+func (c *appController) Evaluate(w http.ResponseWriter, r *http.Request) {
+	var (
+		orgSlug      = r.PathValue("orgSlug")
+		appSlug      = r.PathValue("appSlug")
+		envSlug      = r.PathValue("envSlug")
+		accountIDStr = r.URL.Query().Get("accountID")
+		accountID    int64
+		attributes   map[string]any
+		err          error
+	)
+	if orgSlug == "" || appSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		body := &appEvaluateArgs{}
+		if err = restutils.DecodeBody(r, body); err != nil {
+			restutils.JSONParseError(w, r)
+			return
+		}
+		accountID = body.AccountID
+		attributes = body.Attributes
+	} else if accountID, err = strconv.ParseInt(accountIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	flags, err := c.core.FeatFlagEvaluateMany(r.Context(),
+		c.core.NewFeatFlagEvaluateManyArgs(orgSlug, appSlug, envSlug, accountID, attributes),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, flags)
+}
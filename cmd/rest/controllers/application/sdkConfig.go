@@ -0,0 +1,24 @@
+package application
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *appController) SDKConfig(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	appSlug := r.PathValue("appSlug")
+	envSlug := r.PathValue("envSlug")
+	if orgSlug == "" || appSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	config, err := c.core.SDKConfigGet(r.Context(), orgSlug, appSlug, envSlug)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, config)
+}
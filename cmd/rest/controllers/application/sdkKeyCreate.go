@@ -0,0 +1,44 @@
+package application
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+)
+
+type sdkKeyCreateArgs struct {
+	Name    string           `json:"name"`
+	KeyType types.SDKKeyType `json:"keyType"`
+}
+
+func (c *appController) SDKKeyCreate(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	appSlug := r.PathValue("appSlug")
+	envSlug := r.PathValue("envSlug")
+	if orgSlug == "" || appSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	body := &sdkKeyCreateArgs{}
+	if err := restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	sdkKey, err := c.core.SDKKeyCreate(r.Context(),
+		c.core.NewSDKKeyCreateArgs(
+			orgSlug,
+			appSlug,
+			envSlug,
+			body.Name,
+			body.KeyType,
+		),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, sdkKey)
+}
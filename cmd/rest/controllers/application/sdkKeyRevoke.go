@@ -0,0 +1,34 @@
+package application
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *appController) SDKKeyRevoke(w http.ResponseWriter, r *http.Request) {
+	var (
+		orgSlug  = r.PathValue("orgSlug")
+		appSlug  = r.PathValue("appSlug")
+		keyIDStr = r.PathValue("keyID")
+		keyID    int64
+		err      error
+	)
+	if orgSlug == "" || appSlug == "" || keyIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	if keyID, err = strconv.ParseInt(keyIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	sdkKey, err := c.core.SDKKeyRevoke(r.Context(), orgSlug, appSlug, keyID)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, sdkKey)
+}
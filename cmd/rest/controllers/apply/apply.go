@@ -0,0 +1,46 @@
+package apply
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/core"
+	"switchcraft/types"
+)
+
+type applyController struct {
+	logger *types.Logger
+	core   *core.Core
+}
+
+func NewApplyController(logger *types.Logger, core *core.Core) *applyController {
+	return &applyController{
+		logger: logger,
+		core:   core,
+	}
+}
+
+// applyArgs is a config file with the apply options alongside its
+// organizations
+type applyArgs struct {
+	types.ApplyConfig
+	Prune  bool `json:"prune"`
+	DryRun bool `json:"dryRun"`
+}
+
+func (c *applyController) Apply(w http.ResponseWriter, r *http.Request) {
+	body := &applyArgs{}
+	if err := restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	plan, err := c.core.Apply(r.Context(),
+		c.core.NewApplyArgs(body.ApplyConfig, body.Prune, body.DryRun),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, plan)
+}
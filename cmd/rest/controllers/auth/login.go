@@ -27,7 +27,7 @@ func (c *authController) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := c.core.AuthCreateJWT(account)
+	token, err := c.core.AuthCreateJWT(r.Context(), account)
 	if err != nil {
 		restutils.HandleCoreErr(w, r, err)
 		return
@@ -0,0 +1,51 @@
+package featureflag
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+)
+
+// AccountFlagsReport resolves every flag of the app environment for one
+// account. Responds with CSV when the format query param is csv, JSON
+// otherwise.
+func (c *featureFlagController) AccountFlagsReport(w http.ResponseWriter, r *http.Request) {
+	var (
+		orgSlug      = r.PathValue("orgSlug")
+		appSlug      = r.PathValue("appSlug")
+		envSlug      = r.PathValue("envSlug")
+		accountIDStr = r.PathValue("accountID")
+		format       = r.URL.Query().Get("format")
+		accountID    int64
+		err          error
+	)
+	if orgSlug == "" || appSlug == "" || accountIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+	if format != "" && format != "json" && format != "csv" {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	if accountID, err = strconv.ParseInt(accountIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	evaluations, err := c.core.FeatFlagAccountFlagsReport(r.Context(),
+		c.core.NewFeatFlagAccountFlagsReportArgs(orgSlug, appSlug, envSlug, accountID),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	if format == "csv" {
+		restutils.RenderCSV(w, r, http.StatusOK, types.FeatureFlagEvaluationsCSV(evaluations))
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, evaluations)
+}
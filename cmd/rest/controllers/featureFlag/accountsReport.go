@@ -0,0 +1,50 @@
+package featureflag
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+)
+
+// AccountsReport resolves a flag for every account in the org. Responds with
+// CSV when the format query param is csv, JSON otherwise.
+func (c *featureFlagController) AccountsReport(w http.ResponseWriter, r *http.Request) {
+	var (
+		orgSlug   = r.PathValue("orgSlug")
+		appSlug   = r.PathValue("appSlug")
+		envSlug   = r.PathValue("envSlug")
+		flagIDStr = r.PathValue("flagID")
+		format    = r.URL.Query().Get("format")
+		flagID    int64
+		err       error
+	)
+	if orgSlug == "" || appSlug == "" || flagIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+	if format != "" && format != "json" && format != "csv" {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	if flagID, err = strconv.ParseInt(flagIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	evaluations, err := c.core.FeatFlagAccountsReport(r.Context(),
+		c.core.NewFeatFlagAccountsReportArgs(orgSlug, appSlug, envSlug, &flagID, nil),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	if format == "csv" {
+		restutils.RenderCSV(w, r, http.StatusOK, types.FeatureFlagEvaluationsCSV(evaluations))
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, evaluations)
+}
@@ -3,18 +3,28 @@ package featureflag
 import (
 	"net/http"
 	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+	"time"
 )
 
 type featFlagCreateArgs struct {
-	Name        string `json:"name"`
-	Label       string `json:"label"`
-	Description string `json:"description"`
-	IsEnabled   bool   `json:"isEnabled"`
+	Name              string                          `json:"name"`
+	Label             string                          `json:"label"`
+	Description       string                          `json:"description"`
+	IsEnabled         bool                            `json:"isEnabled"`
+	RolloutPercentage *int                            `json:"rolloutPercentage"`
+	FlagType          types.FeatureFlagType           `json:"flagType"`
+	Variants          []types.FeatureFlagVariant      `json:"variants"`
+	DefaultVariant    *string                         `json:"defaultVariant"`
+	Rules             []types.TargetingRule           `json:"rules"`
+	Prerequisites     []types.FeatureFlagPrerequisite `json:"prerequisites"`
+	ExpiresAt         *time.Time                      `json:"expiresAt"`
 }
 
 func (c *featureFlagController) Create(w http.ResponseWriter, r *http.Request) {
 	orgSlug := r.PathValue("orgSlug")
 	appSlug := r.PathValue("appSlug")
+	envSlug := r.PathValue("envSlug")
 	if orgSlug == "" || appSlug == "" {
 		restutils.NotFound(w, r)
 		return
@@ -29,10 +39,18 @@ func (c *featureFlagController) Create(w http.ResponseWriter, r *http.Request) {
 	flag, err := c.core.FeatFlagCreate(r.Context(),
 		c.core.NewFeatFlagCreateArgs(orgSlug,
 			appSlug,
+			envSlug,
 			body.Name,
 			body.Label,
 			body.Description,
 			body.IsEnabled,
+			body.RolloutPercentage,
+			body.FlagType,
+			body.Variants,
+			body.DefaultVariant,
+			body.Rules,
+			body.Prerequisites,
+			body.ExpiresAt,
 		),
 	)
 	if err != nil {
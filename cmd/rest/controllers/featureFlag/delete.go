@@ -9,6 +9,7 @@ import (
 func (c *featureFlagController) Delete(w http.ResponseWriter, r *http.Request) {
 	orgSlug := r.PathValue("orgSlug")
 	appSlug := r.PathValue("appSlug")
+	envSlug := r.PathValue("envSlug")
 	flagIDStr := r.PathValue("flagID")
 	if orgSlug == "" || appSlug == "" || flagIDStr == "" {
 		restutils.NotFound(w, r)
@@ -24,7 +25,10 @@ func (c *featureFlagController) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = c.core.FeatFlagDelete(r.Context(), orgSlug, appSlug, flagID)
+	// Flags that are prerequisites of others are only deleted when forced
+	force := r.URL.Query().Get("force") == "true"
+
+	err = c.core.FeatFlagDelete(r.Context(), orgSlug, appSlug, envSlug, flagID, force)
 	if err != nil {
 		restutils.HandleCoreErr(w, r, err)
 		return
@@ -0,0 +1,37 @@
+package featureflag
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+)
+
+// Diff compares the app environment in the path with the one given by the
+// targetApp and targetEnv query params. The target app defaults to the same
+// application.
+func (c *featureFlagController) Diff(w http.ResponseWriter, r *http.Request) {
+	var (
+		orgSlug       = r.PathValue("orgSlug")
+		appSlug       = r.PathValue("appSlug")
+		envSlug       = r.PathValue("envSlug")
+		query         = r.URL.Query()
+		targetAppSlug = query.Get("targetApp")
+		targetEnvSlug = query.Get("targetEnv")
+	)
+	if orgSlug == "" || appSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+	if targetAppSlug == "" {
+		targetAppSlug = appSlug
+	}
+
+	diff, err := c.core.FeatFlagDiff(r.Context(),
+		c.core.NewFeatFlagDiffArgs(orgSlug, appSlug, envSlug, targetAppSlug, targetEnvSlug),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, diff)
+}
@@ -0,0 +1,67 @@
+package featureflag
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+// Evaluation context for targeting rules. Attributes can only be sent with
+// POST, GET takes the account ID as a query param.
+type featFlagEvaluateArgs struct {
+	AccountID  int64          `json:"accountId"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+func (c *featureFlagController) Evaluate(w http.ResponseWriter, r *http.Request) {
+	var (
+		orgSlug      = r.PathValue("orgSlug")
+		appSlug      = r.PathValue("appSlug")
+		envSlug      = r.PathValue("envSlug")
+		flagIDStr    = r.PathValue("flagID")
+		flagID       int64
+		accountIDStr = r.URL.Query().Get("accountID")
+		accountID    int64
+		attributes   map[string]any
+		err          error
+	)
+	if orgSlug == "" || appSlug == "" || flagIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	if flagID, err = strconv.ParseInt(flagIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+	if r.Method == http.MethodPost {
+		body := &featFlagEvaluateArgs{}
+		if err = restutils.DecodeBody(r, body); err != nil {
+			restutils.JSONParseError(w, r)
+			return
+		}
+		accountID = body.AccountID
+		attributes = body.Attributes
+	} else if accountID, err = strconv.ParseInt(accountIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	evaluation, err := c.core.FeatFlagEvaluate(r.Context(),
+		c.core.NewFeatFlagEvaluateArgs(
+			orgSlug,
+			appSlug,
+			envSlug,
+			&flagID,
+			nil,
+			accountID,
+			attributes,
+		),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, evaluation)
+}
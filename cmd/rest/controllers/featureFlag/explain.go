@@ -0,0 +1,62 @@
+package featureflag
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+// Explain takes the same evaluation context as Evaluate and returns the
+// decision trace behind the result
+func (c *featureFlagController) Explain(w http.ResponseWriter, r *http.Request) {
+	var (
+		orgSlug      = r.PathValue("orgSlug")
+		appSlug      = r.PathValue("appSlug")
+		envSlug      = r.PathValue("envSlug")
+		flagIDStr    = r.PathValue("flagID")
+		flagID       int64
+		accountIDStr = r.URL.Query().Get("accountID")
+		accountID    int64
+		attributes   map[string]any
+		err          error
+	)
+	if orgSlug == "" || appSlug == "" || flagIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	if flagID, err = strconv.ParseInt(flagIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+	if r.Method == http.MethodPost {
+		body := &featFlagEvaluateArgs{}
+		if err = restutils.DecodeBody(r, body); err != nil {
+			restutils.JSONParseError(w, r)
+			return
+		}
+		accountID = body.AccountID
+		attributes = body.Attributes
+	} else if accountID, err = strconv.ParseInt(accountIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	explanation, err := c.core.FeatFlagExplain(r.Context(),
+		c.core.NewFeatFlagEvaluateArgs(
+			orgSlug,
+			appSlug,
+			envSlug,
+			&flagID,
+			nil,
+			accountID,
+			attributes,
+		),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, explanation)
+}
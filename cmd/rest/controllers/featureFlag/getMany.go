@@ -8,12 +8,13 @@ import (
 func (c *featureFlagController) GetMany(w http.ResponseWriter, r *http.Request) {
 	orgSlug := r.PathValue("orgSlug")
 	appSlug := r.PathValue("appSlug")
+	envSlug := r.PathValue("envSlug")
 	if orgSlug == "" || appSlug == "" {
 		restutils.NotFound(w, r)
 		return
 	}
 
-	flags, err := c.core.FeatFlagGetMany(r.Context(), orgSlug, appSlug)
+	flags, err := c.core.FeatFlagGetMany(r.Context(), orgSlug, appSlug, envSlug)
 	if err != nil {
 		restutils.HandleCoreErr(w, r, err)
 		return
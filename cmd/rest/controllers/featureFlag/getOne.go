@@ -9,6 +9,7 @@ import (
 func (c *featureFlagController) GetOne(w http.ResponseWriter, r *http.Request) {
 	orgSlug := r.PathValue("orgSlug")
 	appSlug := r.PathValue("appSlug")
+	envSlug := r.PathValue("envSlug")
 	flagIDStr := r.PathValue("flagID")
 	if orgSlug == "" || appSlug == "" || flagIDStr == "" {
 		restutils.NotFound(w, r)
@@ -24,7 +25,7 @@ func (c *featureFlagController) GetOne(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	flag, err := c.core.FeatFlagGetOne(r.Context(), c.core.NewFeatFlagGetOneArgs(orgSlug, appSlug, &flagID, nil, nil))
+	flag, err := c.core.FeatFlagGetOne(r.Context(), c.core.NewFeatFlagGetOneArgs(orgSlug, appSlug, envSlug, &flagID, nil, nil))
 	if err != nil {
 		restutils.HandleCoreErr(w, r, err)
 		return
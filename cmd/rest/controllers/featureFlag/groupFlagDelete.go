@@ -10,6 +10,7 @@ func (c *featureFlagController) GroupFlagDelete(w http.ResponseWriter, r *http.R
 	var (
 		orgSlug    = r.PathValue("orgSlug")
 		appSlug    = r.PathValue("appSlug")
+		envSlug    = r.PathValue("envSlug")
 		flagIDStr  = r.PathValue("flagID")
 		flagID     int64
 		groupIDStr = r.PathValue("groupID")
@@ -35,6 +36,7 @@ func (c *featureFlagController) GroupFlagDelete(w http.ResponseWriter, r *http.R
 			orgSlug,
 			groupID,
 			appSlug,
+			envSlug,
 			flagID,
 		),
 	); err != nil {
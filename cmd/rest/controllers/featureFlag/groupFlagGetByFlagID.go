@@ -10,6 +10,7 @@ func (c *featureFlagController) GroupFlagGetMany(w http.ResponseWriter, r *http.
 	var (
 		orgSlug   = r.PathValue("orgSlug")
 		appSlug   = r.PathValue("appSlug")
+		envSlug   = r.PathValue("envSlug")
 		flagIDStr = r.PathValue("flagID")
 		flagID    int64
 		err       error
@@ -28,6 +29,7 @@ func (c *featureFlagController) GroupFlagGetMany(w http.ResponseWriter, r *http.
 		c.core.NewGroupFlagsGetByFlagIDArgs(
 			orgSlug,
 			appSlug,
+			envSlug,
 			flagID,
 		),
 	)
@@ -0,0 +1,52 @@
+package featureflag
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+)
+
+// GroupFlagsSet replaces every group override of the flag with the overrides
+// in the body, an empty array removes them all
+func (c *featureFlagController) GroupFlagsSet(w http.ResponseWriter, r *http.Request) {
+	var (
+		orgSlug   = r.PathValue("orgSlug")
+		appSlug   = r.PathValue("appSlug")
+		envSlug   = r.PathValue("envSlug")
+		flagIDStr = r.PathValue("flagID")
+		flagID    int64
+		err       error
+	)
+	if orgSlug == "" || appSlug == "" || flagIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	if flagID, err = strconv.ParseInt(flagIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	var body []types.GroupFlagOverride
+	if err = restutils.DecodeBody(r, &body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	groupFlags, err := c.core.GroupFlagsSet(r.Context(),
+		c.core.NewGroupFlagsSetArgs(
+			orgSlug,
+			appSlug,
+			envSlug,
+			flagID,
+			body,
+		),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, groupFlags)
+}
@@ -0,0 +1,50 @@
+package featureflag
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+)
+
+type featFlagPromoteArgs struct {
+	TargetAppSlug string   `json:"targetAppSlug"`
+	TargetEnvSlug string   `json:"targetEnvSlug"`
+	Flags         []string `json:"flags"`
+	DryRun        bool     `json:"dryRun"`
+}
+
+func (c *featureFlagController) Promote(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	appSlug := r.PathValue("appSlug")
+	envSlug := r.PathValue("envSlug")
+	if orgSlug == "" || appSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	body := &featFlagPromoteArgs{}
+	if err := restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+	if body.TargetAppSlug == "" {
+		body.TargetAppSlug = appSlug
+	}
+
+	promotion, err := c.core.FeatFlagPromote(r.Context(),
+		c.core.NewFeatFlagPromoteArgs(
+			orgSlug,
+			appSlug,
+			envSlug,
+			body.TargetAppSlug,
+			body.TargetEnvSlug,
+			body.Flags,
+			body.DryRun,
+		),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, promotion)
+}
@@ -0,0 +1,47 @@
+package featureflag
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+type featFlagRollbackArgs struct {
+	Version int `json:"version"`
+}
+
+func (c *featureFlagController) Rollback(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	appSlug := r.PathValue("appSlug")
+	envSlug := r.PathValue("envSlug")
+	flagIDStr := r.PathValue("flagID")
+	if orgSlug == "" || appSlug == "" || flagIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	var (
+		flagID int64
+		err    error
+	)
+	if flagID, err = strconv.ParseInt(flagIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	body := &featFlagRollbackArgs{}
+	if err = restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	version, err := c.core.FeatFlagRollback(r.Context(),
+		c.core.NewFeatFlagRollbackArgs(orgSlug, appSlug, envSlug, flagID, body.Version),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, version)
+}
@@ -0,0 +1,41 @@
+package featureflag
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *featureFlagController) ScheduleCancel(w http.ResponseWriter, r *http.Request) {
+	var (
+		orgSlug       = r.PathValue("orgSlug")
+		appSlug       = r.PathValue("appSlug")
+		envSlug       = r.PathValue("envSlug")
+		flagIDStr     = r.PathValue("flagID")
+		flagID        int64
+		scheduleIDStr = r.PathValue("scheduleID")
+		scheduleID    int64
+		err           error
+	)
+	if orgSlug == "" || appSlug == "" || flagIDStr == "" || scheduleIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	if flagID, err = strconv.ParseInt(flagIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+	if scheduleID, err = strconv.ParseInt(scheduleIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	schedule, err := c.core.FlagScheduleCancel(r.Context(), orgSlug, appSlug, envSlug, flagID, scheduleID)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, schedule)
+}
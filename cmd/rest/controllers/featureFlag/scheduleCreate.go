@@ -0,0 +1,60 @@
+package featureflag
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+	"time"
+)
+
+type flagScheduleCreateArgs struct {
+	GroupID           *int64    `json:"groupId"`
+	IsEnabled         bool      `json:"isEnabled"`
+	RolloutPercentage *int      `json:"rolloutPercentage"`
+	ExecuteAt         time.Time `json:"executeAt"`
+}
+
+func (c *featureFlagController) ScheduleCreate(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	appSlug := r.PathValue("appSlug")
+	envSlug := r.PathValue("envSlug")
+	flagIDStr := r.PathValue("flagID")
+	if orgSlug == "" || appSlug == "" || flagIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	var (
+		flagID int64
+		err    error
+	)
+	if flagID, err = strconv.ParseInt(flagIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	body := &flagScheduleCreateArgs{}
+	if err = restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	schedule, err := c.core.FlagScheduleCreate(r.Context(),
+		c.core.NewFlagScheduleCreateArgs(
+			orgSlug,
+			appSlug,
+			envSlug,
+			flagID,
+			body.GroupID,
+			body.IsEnabled,
+			body.RolloutPercentage,
+			body.ExecuteAt,
+		),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, schedule)
+}
@@ -0,0 +1,39 @@
+package featureflag
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+// Stale reports the app's flags that are candidates for removal. The days
+// query param sets how long a flag must be unchanged to count as stale.
+func (c *featureFlagController) Stale(w http.ResponseWriter, r *http.Request) {
+	var (
+		orgSlug = r.PathValue("orgSlug")
+		appSlug = r.PathValue("appSlug")
+		daysStr = r.URL.Query().Get("days")
+		days    int
+		err     error
+	)
+	if orgSlug == "" || appSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+	if daysStr != "" {
+		if days, err = strconv.Atoi(daysStr); err != nil || days < 1 {
+			restutils.BadRequest(w, r)
+			return
+		}
+	}
+
+	report, err := c.core.FeatFlagStaleReport(r.Context(),
+		c.core.NewFeatFlagStaleReportArgs(orgSlug, appSlug, days),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, report)
+}
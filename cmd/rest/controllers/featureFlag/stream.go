@@ -0,0 +1,105 @@
+package featureflag
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+	"time"
+)
+
+const streamHeartbeatInterval = 15 * time.Second
+
+// Stream sends flag change events for an application as server-sent events.
+// Clients resume after a disconnect by sending the Last-Event-ID header, or a
+// lastEventID query param when the header cannot be set.
+func (c *featureFlagController) Stream(w http.ResponseWriter, r *http.Request) {
+	var (
+		orgSlug        = r.PathValue("orgSlug")
+		appSlug        = r.PathValue("appSlug")
+		envSlug        = r.PathValue("envSlug")
+		lastEventIDStr = r.Header.Get("Last-Event-ID")
+		lastEventID    int64
+		err            error
+	)
+	if orgSlug == "" || appSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	if lastEventIDStr == "" {
+		lastEventIDStr = r.URL.Query().Get("lastEventID")
+	}
+	if lastEventIDStr != "" {
+		if lastEventID, err = strconv.ParseInt(lastEventIDStr, 10, 64); err != nil {
+			restutils.BadRequest(w, r)
+			return
+		}
+	}
+
+	events, err := c.core.FlagEventSubscribe(r.Context(),
+		c.core.NewFlagEventSubscribeArgs(orgSlug, appSlug, envSlug, lastEventID),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	// The stream is long lived, lift the server read/write timeouts for this
+	// request. An expired read deadline would otherwise cancel the request ctx.
+	rc := http.NewResponseController(w)
+	if err = rc.SetReadDeadline(time.Time{}); err != nil {
+		restutils.InternalServerError(w, r)
+		return
+	}
+	if err = rc.SetWriteDeadline(time.Time{}); err != nil {
+		restutils.InternalServerError(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err = rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err = writeStreamEvent(w, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err = fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+
+		if err = rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeStreamEvent(w http.ResponseWriter, event types.FlagEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.EventType, data)
+	return err
+}
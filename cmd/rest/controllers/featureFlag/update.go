@@ -5,19 +5,28 @@ import (
 	"strconv"
 	"switchcraft/cmd/rest/restutils"
 	"switchcraft/types"
+	"time"
 )
 
 type featFlagUpdateArgs struct {
-	ID          int64  `json:"id"`
-	Name        string `json:"name"`
-	Label       string `json:"label"`
-	Description string `json:"Description"`
-	IsEnabled   bool   `json:"isEnabled"`
+	ID                int64                           `json:"id"`
+	Name              string                          `json:"name"`
+	Label             string                          `json:"label"`
+	Description       string                          `json:"Description"`
+	IsEnabled         bool                            `json:"isEnabled"`
+	RolloutPercentage *int                            `json:"rolloutPercentage"`
+	FlagType          types.FeatureFlagType           `json:"flagType"`
+	Variants          []types.FeatureFlagVariant      `json:"variants"`
+	DefaultVariant    *string                         `json:"defaultVariant"`
+	Rules             []types.TargetingRule           `json:"rules"`
+	Prerequisites     []types.FeatureFlagPrerequisite `json:"prerequisites"`
+	ExpiresAt         *time.Time                      `json:"expiresAt"`
 }
 
 func (c *featureFlagController) Update(w http.ResponseWriter, r *http.Request) {
 	orgSlug := r.PathValue("orgSlug")
 	appSlug := r.PathValue("appSlug")
+	envSlug := r.PathValue("envSlug")
 	flagIDStr := r.PathValue("flagID")
 	if orgSlug == "" || appSlug == "" || flagIDStr == "" {
 		restutils.NotFound(w, r)
@@ -54,11 +63,19 @@ func (c *featureFlagController) Update(w http.ResponseWriter, r *http.Request) {
 		c.core.NewFeatFlagUpdateArgs(
 			orgSlug,
 			appSlug,
+			envSlug,
 			flagID,
 			body.Name,
 			body.Label,
 			body.Description,
 			body.IsEnabled,
+			body.RolloutPercentage,
+			body.FlagType,
+			body.Variants,
+			body.DefaultVariant,
+			body.Rules,
+			body.Prerequisites,
+			body.ExpiresAt,
 		),
 	)
 	if err != nil {
@@ -0,0 +1,98 @@
+package org
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+	"time"
+)
+
+// AuditLog lists an org's audit entries, newest first. Supports the optional
+// query params entityType, entityID, actorID, from and to (RFC 3339), and
+// limit.
+func (c *orgController) AuditLog(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	if orgSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	var (
+		query      = r.URL.Query()
+		entityType *types.AuditEntityType
+		entityID   *int64
+		actorID    *int64
+		from       *time.Time
+		to         *time.Time
+		limit      int
+		err        error
+	)
+
+	if v := query.Get("entityType"); v != "" {
+		t := types.AuditEntityType(v)
+		entityType = &t
+	}
+	if entityID, err = parseOptionalInt64(query.Get("entityID")); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+	if actorID, err = parseOptionalInt64(query.Get("actorID")); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+	if from, err = parseOptionalTime(query.Get("from")); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+	if to, err = parseOptionalTime(query.Get("to")); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+	if v := query.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			restutils.BadRequest(w, r)
+			return
+		}
+	}
+
+	entries, err := c.core.AuditLogGetMany(r.Context(),
+		c.core.NewAuditLogGetManyArgs(
+			orgSlug,
+			entityType,
+			entityID,
+			actorID,
+			from,
+			to,
+			limit,
+		),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, entries)
+}
+
+func parseOptionalInt64(s string) (*int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func parseOptionalTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
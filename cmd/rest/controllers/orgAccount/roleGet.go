@@ -0,0 +1,33 @@
+package orgaccount
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *orgAccountController) RoleGet(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	accountIDStr := r.PathValue("accountID")
+	if orgSlug == "" || accountIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	var (
+		accountID int64
+		err       error
+	)
+	if accountID, err = strconv.ParseInt(accountIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	roles, err := c.core.OrgAccountRolesGet(r.Context(), orgSlug, accountID)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, roles)
+}
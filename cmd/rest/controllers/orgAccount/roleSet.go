@@ -0,0 +1,46 @@
+package orgaccount
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+)
+
+type roleSetArgs struct {
+	Role types.OrgRole `json:"role"`
+}
+
+func (c *orgAccountController) RoleSet(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	accountIDStr := r.PathValue("accountID")
+	if orgSlug == "" || accountIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	var (
+		accountID int64
+		err       error
+	)
+	if accountID, err = strconv.ParseInt(accountIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	body := &roleSetArgs{}
+	if err = restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	role, err := c.core.OrgAccountRoleSet(r.Context(),
+		c.core.NewOrgAccountRoleSetArgs(orgSlug, accountID, body.Role),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, role)
+}
@@ -0,0 +1,46 @@
+package orggroup
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+)
+
+type roleSetArgs struct {
+	Role types.OrgRole `json:"role"`
+}
+
+func (c *orgGroupController) RoleSet(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	groupIDStr := r.PathValue("groupID")
+	if orgSlug == "" || groupIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	var (
+		groupID int64
+		err     error
+	)
+	if groupID, err = strconv.ParseInt(groupIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	body := &roleSetArgs{}
+	if err = restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	role, err := c.core.OrgGroupRoleSet(r.Context(),
+		c.core.NewOrgGroupRoleSetArgs(orgSlug, groupID, body.Role),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, role)
+}
@@ -0,0 +1,44 @@
+package orgsegment
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+)
+
+type createOrgSegmentArgs struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Rules       []types.SegmentRule `json:"rules"`
+	AccountIDs  []int64             `json:"accountIds"`
+}
+
+func (c *orgSegmentController) Create(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	if orgSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	body := &createOrgSegmentArgs{}
+	if err := restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	segment, err := c.core.OrgSegmentCreate(r.Context(),
+		c.core.NewOrgSegmentCreateArgs(
+			orgSlug,
+			body.Name,
+			body.Description,
+			body.Rules,
+			body.AccountIDs,
+		),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, segment)
+}
@@ -0,0 +1,32 @@
+package orgsegment
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *orgSegmentController) Delete(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	segmentIDStr := r.PathValue("segmentID")
+	if orgSlug == "" || segmentIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	var (
+		segmentID int64
+		err       error
+	)
+	if segmentID, err = strconv.ParseInt(segmentIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	if err := c.core.OrgSegmentDelete(r.Context(), orgSlug, segmentID); err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.OK(w, r)
+}
@@ -0,0 +1,31 @@
+package orgsegment
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *orgSegmentController) FlagRefsGet(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	segmentIDStr := r.PathValue("segmentID")
+	if orgSlug == "" || segmentIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	var segmentID int64
+	segmentID, err := strconv.ParseInt(segmentIDStr, 10, 64)
+	if err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	refs, err := c.core.OrgSegmentFlagRefsGetMany(r.Context(), orgSlug, segmentID)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, refs)
+}
@@ -0,0 +1,22 @@
+package orgsegment
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *orgSegmentController) GetMany(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	if orgSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	segments, err := c.core.OrgSegmentGetMany(r.Context(), orgSlug)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, segments)
+}
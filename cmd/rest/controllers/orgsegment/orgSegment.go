@@ -0,0 +1,18 @@
+package orgsegment
+
+import (
+	"switchcraft/core"
+	"switchcraft/types"
+)
+
+type orgSegmentController struct {
+	logger *types.Logger
+	core   *core.Core
+}
+
+func NewOrgSegmentController(logger *types.Logger, core *core.Core) *orgSegmentController {
+	return &orgSegmentController{
+		logger: logger,
+		core:   core,
+	}
+}
@@ -0,0 +1,77 @@
+package orgsegment
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+)
+
+type updateOrgSegmentArgs struct {
+	ID          int64               `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Rules       []types.SegmentRule `json:"rules"`
+	AccountIDs  []int64             `json:"accountIds"`
+}
+
+func (c *orgSegmentController) Update(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	segmentIDStr := r.PathValue("segmentID")
+	if orgSlug == "" || segmentIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	body := &updateOrgSegmentArgs{}
+	if err := restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	var (
+		segmentID int64
+		err       error
+	)
+	if segmentID, err = strconv.ParseInt(segmentIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	segment, err := c.core.OrgSegmentGetOne(r.Context(),
+		c.core.NewOrgSegmentGetOneArgs(orgSlug, &segmentID, nil),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	tracer, _ := r.Context().Value(types.CtxOperationTracer).(types.OperationTracer)
+
+	if segment.ID != body.ID {
+		restutils.BadRequest(w, r)
+		c.logger.Warn(tracer, "Org segment update ID mismatch detected", map[string]any{
+			"user":            tracer.AuthAccount.Username,
+			"requestBody":     body,
+			"existingSegment": segment,
+		})
+		return
+	}
+
+	updatedSegment, err := c.core.OrgSegmentUpdate(r.Context(),
+		c.core.NewOrgSegmentUpdateArgs(
+			orgSlug,
+			segment.ID,
+			body.Name,
+			body.Description,
+			body.Rules,
+			body.AccountIDs,
+		),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, updatedSegment)
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+)
+
+type createWebhookArgs struct {
+	URL        string                   `json:"url"`
+	Secret     string                   `json:"secret"`
+	EventTypes []types.WebhookEventType `json:"eventTypes"`
+	IsEnabled  bool                     `json:"isEnabled"`
+}
+
+func (c *webhookController) Create(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	if orgSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	body := &createWebhookArgs{}
+	if err := restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	webhook, err := c.core.WebhookCreate(r.Context(),
+		c.core.NewWebhookCreateArgs(
+			orgSlug,
+			body.URL,
+			body.Secret,
+			body.EventTypes,
+			body.IsEnabled,
+		),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, webhook)
+}
@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *webhookController) Delete(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	webhookIDStr := r.PathValue("webhookID")
+	if orgSlug == "" || webhookIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	webhookID, err := strconv.ParseInt(webhookIDStr, 10, 64)
+	if err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	if err := c.core.WebhookDelete(r.Context(), orgSlug, webhookID); err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.OK(w, r)
+}
@@ -0,0 +1,22 @@
+package webhook
+
+import (
+	"net/http"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *webhookController) GetMany(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	if orgSlug == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	webhooks, err := c.core.WebhookGetMany(r.Context(), orgSlug)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, webhooks)
+}
@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *webhookController) GetOne(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	webhookIDStr := r.PathValue("webhookID")
+	if orgSlug == "" || webhookIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	webhookID, err := strconv.ParseInt(webhookIDStr, 10, 64)
+	if err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	webhook, err := c.core.WebhookGetOne(r.Context(), orgSlug, webhookID)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, webhook)
+}
@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+)
+
+func (c *webhookController) Redeliver(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	webhookIDStr := r.PathValue("webhookID")
+	deliveryIDStr := r.PathValue("deliveryID")
+	if orgSlug == "" || webhookIDStr == "" || deliveryIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	var (
+		webhookID  int64
+		deliveryID int64
+		err        error
+	)
+	if webhookID, err = strconv.ParseInt(webhookIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+	if deliveryID, err = strconv.ParseInt(deliveryIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	delivery, err := c.core.WebhookRedeliver(r.Context(), orgSlug, webhookID, deliveryID)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, delivery)
+}
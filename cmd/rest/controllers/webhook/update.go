@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"net/http"
+	"strconv"
+	"switchcraft/cmd/rest/restutils"
+	"switchcraft/types"
+)
+
+// An empty secret keeps the webhook's current secret
+type updateWebhookArgs struct {
+	ID         int64                    `json:"id"`
+	URL        string                   `json:"url"`
+	Secret     string                   `json:"secret"`
+	EventTypes []types.WebhookEventType `json:"eventTypes"`
+	IsEnabled  bool                     `json:"isEnabled"`
+}
+
+func (c *webhookController) Update(w http.ResponseWriter, r *http.Request) {
+	orgSlug := r.PathValue("orgSlug")
+	webhookIDStr := r.PathValue("webhookID")
+	if orgSlug == "" || webhookIDStr == "" {
+		restutils.NotFound(w, r)
+		return
+	}
+
+	body := &updateWebhookArgs{}
+	if err := restutils.DecodeBody(r, body); err != nil {
+		restutils.JSONParseError(w, r)
+		return
+	}
+
+	var (
+		webhookID int64
+		err       error
+	)
+	if webhookID, err = strconv.ParseInt(webhookIDStr, 10, 64); err != nil {
+		restutils.BadRequest(w, r)
+		return
+	}
+
+	if webhookID != body.ID {
+		tracer, _ := r.Context().Value(types.CtxOperationTracer).(types.OperationTracer)
+
+		restutils.BadRequest(w, r)
+		c.logger.Warn(tracer, "Webhook update ID mismatch detected", map[string]any{
+			"user":        tracer.AuthAccount.Username,
+			"requestBody": body,
+			"webhookID":   webhookID,
+		})
+		return
+	}
+
+	webhook, err := c.core.WebhookUpdate(r.Context(),
+		c.core.NewWebhookUpdateArgs(
+			orgSlug,
+			webhookID,
+			body.URL,
+			body.Secret,
+			body.EventTypes,
+			body.IsEnabled,
+		),
+	)
+	if err != nil {
+		restutils.HandleCoreErr(w, r, err)
+		return
+	}
+
+	restutils.Render(w, r, http.StatusOK, webhook)
+}
@@ -0,0 +1,18 @@
+package webhook
+
+import (
+	"switchcraft/core"
+	"switchcraft/types"
+)
+
+type webhookController struct {
+	logger *types.Logger
+	core   *core.Core
+}
+
+func NewWebhookController(logger *types.Logger, core *core.Core) *webhookController {
+	return &webhookController{
+		logger: logger,
+		core:   core,
+	}
+}
@@ -73,3 +73,46 @@ func createAuthMiddleware(logger *types.Logger, core *core.Core) func(next http.
 		}
 	}
 }
+
+// createSDKKeyMiddleware accepts application SDK keys in the Authorization
+// header and falls back to the JWT auth middleware for anything else. Core is
+// responsible for limiting what an SDK key may do.
+func createSDKKeyMiddleware(
+	logger *types.Logger,
+	core *core.Core,
+	authMiddleware func(next http.HandlerFunc) http.HandlerFunc,
+) func(next http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		jwtHandler := authMiddleware(next)
+
+		return func(w http.ResponseWriter, r *http.Request) {
+			token := strings.Trim(
+				tokenRegexp.ReplaceAllString(r.Header.Get("Authorization"), ""),
+				" ",
+			)
+
+			if !strings.HasPrefix(token, types.SDKKeyPrefix) {
+				jwtHandler(w, r)
+				return
+			}
+
+			tracer, ok := r.Context().Value(types.CtxOperationTracer).(types.OperationTracer)
+			if !ok {
+				restutils.InternalServerError(w, r)
+				return
+			}
+
+			sdkKey, err := core.AuthValidateSDKKey(r.Context(), token)
+			if err != nil {
+				logger.Error(tracer, err.Error(), nil)
+				restutils.Unauthorized(w, r)
+				return
+			}
+
+			tracer.SDKKey = sdkKey
+
+			ctx := context.WithValue(r.Context(), types.CtxOperationTracer, tracer)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+	}
+}
@@ -20,6 +20,14 @@ func HandleCoreErr(w http.ResponseWriter, r *http.Request, err error) {
 		BadRequest(w, r)
 	} else if errors.Is(err, types.ErrLinkedItemNotFound) {
 		BadRequest(w, r)
+	} else if errors.Is(err, types.ErrItemInUse) {
+		// Core names what the item is still in use by
+		Conflict(w, r, err.Error())
+	} else if errors.Is(err, types.ErrInvalidState) {
+		// Core names the state the item needs to be in
+		Conflict(w, r, err.Error())
+	} else if errors.Is(err, types.ErrOperationNotPermitted) {
+		Forbidden(w, r)
 	} else {
 		InternalServerError(w, r)
 	}
@@ -1,6 +1,7 @@
 package restutils
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -57,6 +58,31 @@ func Render(w http.ResponseWriter, r *http.Request, status HTTPStatusCode, data
 	})
 }
 
+// RenderCSV writes records as a CSV document, the first record being the
+// header row
+func RenderCSV(w http.ResponseWriter, r *http.Request, status HTTPStatusCode, records [][]string) {
+	trace, ok := r.Context().Value(types.CtxOperationTracer).(types.OperationTracer)
+	if !ok {
+		fmt.Println("rest.renderCSV invalid operation context")
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.WriteHeader(int(status))
+	if err := csv.NewWriter(w).WriteAll(records); err != nil {
+		logger.Error(trace, "CSV write failed", map[string]any{
+			"method": r.Method,
+			"path":   r.URL.Path,
+			"error":  err.Error(),
+		})
+	}
+
+	logger.Info(trace, "Request end", map[string]any{
+		"method": r.Method,
+		"path":   r.URL.Path,
+		"status": status,
+	})
+}
+
 func BadRequest(w http.ResponseWriter, r *http.Request) {
 	Render(w, r, http.StatusBadRequest, "Bad request")
 }
@@ -65,10 +91,18 @@ func Unauthorized(w http.ResponseWriter, r *http.Request) {
 	Render(w, r, http.StatusUnauthorized, "Unauthorized")
 }
 
+func Forbidden(w http.ResponseWriter, r *http.Request) {
+	Render(w, r, http.StatusForbidden, "Forbidden")
+}
+
 func InternalServerError(w http.ResponseWriter, r *http.Request) {
 	Render(w, r, http.StatusInternalServerError, "Internal server error")
 }
 
+func Conflict(w http.ResponseWriter, r *http.Request, message string) {
+	Render(w, r, http.StatusConflict, message)
+}
+
 func JSONParseError(w http.ResponseWriter, r *http.Request) {
 	Render(w, r, http.StatusBadRequest, "JSON parse error")
 }
@@ -3,12 +3,15 @@ package rest
 import (
 	"net/http"
 	"switchcraft/cmd/rest/controllers/application"
+	"switchcraft/cmd/rest/controllers/apply"
 	"switchcraft/cmd/rest/controllers/auth"
 	"switchcraft/cmd/rest/controllers/featureflag"
 	"switchcraft/cmd/rest/controllers/globalaccount"
 	"switchcraft/cmd/rest/controllers/org"
 	"switchcraft/cmd/rest/controllers/orgaccount"
 	"switchcraft/cmd/rest/controllers/orggroup"
+	"switchcraft/cmd/rest/controllers/orgsegment"
+	"switchcraft/cmd/rest/controllers/webhook"
 	"switchcraft/cmd/rest/restutils"
 	"switchcraft/core"
 	"switchcraft/types"
@@ -21,11 +24,15 @@ func addRoutes(logger *types.Logger, core *core.Core, router *http.ServeMux) {
 		orgController           = org.NewOrgController(logger, core)
 		orgAccountController    = orgaccount.NewOrgAccountController(logger, core)
 		orgGroupController      = orggroup.NewOrgGroupController(logger, core)
+		orgSegmentController    = orgsegment.NewOrgSegmentController(logger, core)
 		appController           = application.NewAppController(logger, core)
 		featFlagController      = featureflag.NewFeatureFlagController(logger, core)
+		webhookController       = webhook.NewWebhookController(logger, core)
+		applyController         = apply.NewApplyController(logger, core)
 	)
 
 	authMiddleware := createAuthMiddleware(logger, core)
+	sdkKeyMiddleware := createSDKKeyMiddleware(logger, core, authMiddleware)
 
 	router.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
 		restutils.Render(w, r, 200, map[string]any{
@@ -47,6 +54,7 @@ func addRoutes(logger *types.Logger, core *core.Core, router *http.ServeMux) {
 	router.HandleFunc("GET /org", authMiddleware(orgController.GetMany))
 	router.HandleFunc("GET /org/{orgSlug}", authMiddleware(orgController.GetOne))
 	router.HandleFunc("PUT /org/{orgSlug}", authMiddleware(orgController.Update))
+	router.HandleFunc("GET /org/{orgSlug}/audit", authMiddleware(orgController.AuditLog))
 
 	/* === ORG ACCOUNT ROUTES === */
 	router.HandleFunc("POST /org/{orgSlug}/account", authMiddleware(orgAccountController.Create))
@@ -55,6 +63,9 @@ func addRoutes(logger *types.Logger, core *core.Core, router *http.ServeMux) {
 	router.HandleFunc("GET /org/{orgSlug}/account/{accountID}", authMiddleware(orgAccountController.GetOne))
 	router.HandleFunc("PUT /org/{orgSlug}/account/{accountID}", authMiddleware(orgAccountController.Update))
 	router.HandleFunc("DELETE /org/{orgSlug}/account/{accountID}", authMiddleware(orgAccountController.Delete))
+	router.HandleFunc("GET /org/{orgSlug}/account/{accountID}/role", authMiddleware(orgAccountController.RoleGet))
+	router.HandleFunc("PUT /org/{orgSlug}/account/{accountID}/role", authMiddleware(orgAccountController.RoleSet))
+	router.HandleFunc("DELETE /org/{orgSlug}/account/{accountID}/role", authMiddleware(orgAccountController.RoleDelete))
 
 	/* === ORG GROUP ROUTES === */
 	router.HandleFunc("POST /org/{orgSlug}/group", authMiddleware(orgGroupController.Create))
@@ -62,6 +73,8 @@ func addRoutes(logger *types.Logger, core *core.Core, router *http.ServeMux) {
 	router.HandleFunc("GET /org/{orgSlug}/group/{groupID}", authMiddleware(orgGroupController.GetOne))
 	router.HandleFunc("PUT /org/{orgSlug}/group/{groupID}", authMiddleware(orgGroupController.Update))
 	router.HandleFunc("DELETE /org/{orgSlug}/group/{groupID}", authMiddleware(orgGroupController.Delete))
+	router.HandleFunc("PUT /org/{orgSlug}/group/{groupID}/role", authMiddleware(orgGroupController.RoleSet))
+	router.HandleFunc("DELETE /org/{orgSlug}/group/{groupID}/role", authMiddleware(orgGroupController.RoleDelete))
 
 	/* === ORG GROUP ACCOUNT ROUTES === */
 	router.HandleFunc(
@@ -81,6 +94,29 @@ func addRoutes(logger *types.Logger, core *core.Core, router *http.ServeMux) {
 		authMiddleware(orgGroupController.AccountRemove),
 	)
 
+	/* === ORG SEGMENT ROUTES === */
+	router.HandleFunc("POST /org/{orgSlug}/segment", authMiddleware(orgSegmentController.Create))
+	router.HandleFunc("GET /org/{orgSlug}/segment", authMiddleware(orgSegmentController.GetMany))
+	router.HandleFunc("GET /org/{orgSlug}/segment/{segmentID}", authMiddleware(orgSegmentController.GetOne))
+	router.HandleFunc("PUT /org/{orgSlug}/segment/{segmentID}", authMiddleware(orgSegmentController.Update))
+	router.HandleFunc("DELETE /org/{orgSlug}/segment/{segmentID}", authMiddleware(orgSegmentController.Delete))
+	router.HandleFunc("GET /org/{orgSlug}/segment/{segmentID}/flag", authMiddleware(orgSegmentController.FlagRefsGet))
+
+	/* === WEBHOOK ROUTES === */
+	router.HandleFunc("POST /org/{orgSlug}/webhook", authMiddleware(webhookController.Create))
+	router.HandleFunc("GET /org/{orgSlug}/webhook", authMiddleware(webhookController.GetMany))
+	router.HandleFunc("GET /org/{orgSlug}/webhook/{webhookID}", authMiddleware(webhookController.GetOne))
+	router.HandleFunc("PUT /org/{orgSlug}/webhook/{webhookID}", authMiddleware(webhookController.Update))
+	router.HandleFunc("DELETE /org/{orgSlug}/webhook/{webhookID}", authMiddleware(webhookController.Delete))
+	router.HandleFunc("GET /org/{orgSlug}/webhook/{webhookID}/delivery", authMiddleware(webhookController.Deliveries))
+	router.HandleFunc(
+		"POST /org/{orgSlug}/webhook/{webhookID}/delivery/{deliveryID}/redeliver",
+		authMiddleware(webhookController.Redeliver),
+	)
+
+	/* === APPLY ROUTES === */
+	router.HandleFunc("POST /apply", authMiddleware(applyController.Apply))
+
 	/* === APPLICATION ROUTES === */
 	router.HandleFunc("POST /org/{orgSlug}/app", authMiddleware(appController.Create))
 	router.HandleFunc("GET /org/{orgSlug}/app", authMiddleware(appController.GetMany))
@@ -88,39 +124,88 @@ func addRoutes(logger *types.Logger, core *core.Core, router *http.ServeMux) {
 	router.HandleFunc("PUT /org/{orgSlug}/app/{appSlug}", authMiddleware(appController.Update))
 	router.HandleFunc("DELETE /org/{orgSlug}/app/{appSlug}", authMiddleware(appController.Delete))
 
-	/* === FEATURE FLAG ROUTES === */
-	router.HandleFunc(
-		"POST /org/{orgSlug}/app/{appSlug}/flag",
-		authMiddleware(featFlagController.Create),
-	)
-	router.HandleFunc(
-		"GET /org/{orgSlug}/app/{appSlug}/flag",
-		authMiddleware(featFlagController.GetMany),
-	)
-	router.HandleFunc(
-		"GET /org/{orgSlug}/app/{appSlug}/flag/{flagID}",
-		authMiddleware(featFlagController.GetOne),
-	)
-	router.HandleFunc(
-		"PUT /org/{orgSlug}/app/{appSlug}/flag/{flagID}",
-		authMiddleware(featFlagController.Update),
-	)
-	router.HandleFunc(
-		"DELETE /org/{orgSlug}/app/{appSlug}/flag/{flagID}",
-		authMiddleware(featFlagController.Delete),
-	)
+	/* === APPLICATION ENVIRONMENT ROUTES === */
+	router.HandleFunc("POST /org/{orgSlug}/app/{appSlug}/env", authMiddleware(appController.EnvCreate))
+	router.HandleFunc("GET /org/{orgSlug}/app/{appSlug}/env", authMiddleware(appController.EnvGetMany))
+	router.HandleFunc("GET /org/{orgSlug}/app/{appSlug}/env/{envSlug}", authMiddleware(appController.EnvGetOne))
+	router.HandleFunc("PUT /org/{orgSlug}/app/{appSlug}/env/{envSlug}", authMiddleware(appController.EnvUpdate))
+	router.HandleFunc("DELETE /org/{orgSlug}/app/{appSlug}/env/{envSlug}", authMiddleware(appController.EnvDelete))
 
-	/* === ORG GROUP FLAG ROUTES === */
+	/* === APPLICATION SDK KEY ROUTES === */
 	router.HandleFunc(
-		"PUT /org/{orgSlug}/app/{appSlug}/flag/{flagID}/group-flag/{groupID}",
-		authMiddleware(featFlagController.GroupFlagUpsert),
+		"GET /org/{orgSlug}/app/{appSlug}/sdk-key",
+		authMiddleware(appController.SDKKeyGetMany),
 	)
 	router.HandleFunc(
-		"GET /org/{orgSlug}/app/{appSlug}/flag/{flagID}/group-flag",
-		authMiddleware(featFlagController.GroupFlagGetMany),
+		"POST /org/{orgSlug}/app/{appSlug}/sdk-key/{keyID}/rotate",
+		authMiddleware(appController.SDKKeyRotate),
 	)
 	router.HandleFunc(
-		"DELETE /org/{orgSlug}/app/{appSlug}/flag/{flagID}/group-flag/{groupID}",
-		authMiddleware(featFlagController.GroupFlagDelete),
+		"DELETE /org/{orgSlug}/app/{appSlug}/sdk-key/{keyID}",
+		authMiddleware(appController.SDKKeyRevoke),
 	)
+
+	// Covers every environment of the app
+	router.HandleFunc("GET /org/{orgSlug}/app/{appSlug}/flag/stale", authMiddleware(featFlagController.Stale))
+
+	// Flag state lives in an environment. Routes without one use the SDK
+	// key's environment, or production for everyone else.
+	for _, appPath := range []string{
+		"/org/{orgSlug}/app/{appSlug}",
+		"/org/{orgSlug}/app/{appSlug}/env/{envSlug}",
+	} {
+		router.HandleFunc("GET "+appPath+"/evaluate", sdkKeyMiddleware(appController.Evaluate))
+		router.HandleFunc("POST "+appPath+"/evaluate", sdkKeyMiddleware(appController.Evaluate))
+		router.HandleFunc("GET "+appPath+"/sdk-config", sdkKeyMiddleware(appController.SDKConfig))
+		router.HandleFunc("POST "+appPath+"/sdk-key", authMiddleware(appController.SDKKeyCreate))
+
+		/* === FEATURE FLAG ROUTES === */
+		router.HandleFunc("POST "+appPath+"/flag", authMiddleware(featFlagController.Create))
+		router.HandleFunc("GET "+appPath+"/flag", sdkKeyMiddleware(featFlagController.GetMany))
+		router.HandleFunc("GET "+appPath+"/flag/{flagID}", sdkKeyMiddleware(featFlagController.GetOne))
+		router.HandleFunc("PUT "+appPath+"/flag/{flagID}", authMiddleware(featFlagController.Update))
+		router.HandleFunc("DELETE "+appPath+"/flag/{flagID}", authMiddleware(featFlagController.Delete))
+
+		router.HandleFunc("GET "+appPath+"/flag/{flagID}/evaluate", sdkKeyMiddleware(featFlagController.Evaluate))
+		router.HandleFunc("POST "+appPath+"/flag/{flagID}/evaluate", sdkKeyMiddleware(featFlagController.Evaluate))
+		router.HandleFunc("GET "+appPath+"/flag/{flagID}/explain", authMiddleware(featFlagController.Explain))
+		router.HandleFunc("POST "+appPath+"/flag/{flagID}/explain", authMiddleware(featFlagController.Explain))
+
+		router.HandleFunc("GET "+appPath+"/flag/{flagID}/accounts", authMiddleware(featFlagController.AccountsReport))
+		router.HandleFunc("GET "+appPath+"/account/{accountID}/flags", authMiddleware(featFlagController.AccountFlagsReport))
+
+		router.HandleFunc("GET "+appPath+"/flag/{flagID}/versions", authMiddleware(featFlagController.Versions))
+		router.HandleFunc("POST "+appPath+"/flag/{flagID}/rollback", authMiddleware(featFlagController.Rollback))
+
+		router.HandleFunc("POST "+appPath+"/flag/{flagID}/archive", authMiddleware(featFlagController.Archive))
+		router.HandleFunc("POST "+appPath+"/flag/{flagID}/restore", authMiddleware(featFlagController.Restore))
+		router.HandleFunc("POST "+appPath+"/flag/{flagID}/deprecate", authMiddleware(featFlagController.Deprecate))
+
+		router.HandleFunc("POST "+appPath+"/flag/{flagID}/schedule", authMiddleware(featFlagController.ScheduleCreate))
+		router.HandleFunc("GET "+appPath+"/flag/{flagID}/schedule", authMiddleware(featFlagController.ScheduleGetMany))
+		router.HandleFunc(
+			"DELETE "+appPath+"/flag/{flagID}/schedule/{scheduleID}",
+			authMiddleware(featFlagController.ScheduleCancel),
+		)
+
+		router.HandleFunc("GET "+appPath+"/stream", sdkKeyMiddleware(featFlagController.Stream))
+
+		router.HandleFunc("GET "+appPath+"/diff", authMiddleware(featFlagController.Diff))
+		router.HandleFunc("POST "+appPath+"/promote", authMiddleware(featFlagController.Promote))
+
+		/* === ORG GROUP FLAG ROUTES === */
+		router.HandleFunc("PUT "+appPath+"/flag/{flagID}/group-flag", authMiddleware(featFlagController.GroupFlagsSet))
+		router.HandleFunc(
+			"PUT "+appPath+"/flag/{flagID}/group-flag/{groupID}",
+			authMiddleware(featFlagController.GroupFlagUpsert),
+		)
+		router.HandleFunc(
+			"GET "+appPath+"/flag/{flagID}/group-flag",
+			sdkKeyMiddleware(featFlagController.GroupFlagGetMany),
+		)
+		router.HandleFunc(
+			"DELETE "+appPath+"/flag/{flagID}/group-flag/{groupID}",
+			authMiddleware(featFlagController.GroupFlagDelete),
+		)
+	}
 }
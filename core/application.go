@@ -37,6 +37,8 @@ func (c *Core) NewAppCreateArgs(
 	}
 }
 
+// AppCreate creates an application with development, staging, and production
+// environments
 func (c *Core) AppCreate(ctx context.Context, args appCreateArgs) (*types.Application, error) {
 	tracer, err := c.getOperationTracer(ctx)
 	if err != nil {
@@ -52,12 +54,46 @@ func (c *Core) AppCreate(ctx context.Context, args appCreateArgs) (*types.Applic
 		return nil, err
 	}
 
-	return c.appRepo.Create(ctx,
-		org.ID,
-		args.name,
-		args.slug,
-		tracer.AuthAccount.ID,
-	)
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	var app *types.Application
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if app, err = c.appRepo.Create(ctx,
+			org.ID,
+			args.name,
+			args.slug,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		for _, env := range appDefaultEnvironments {
+			if _, err = c.environmentRepo.Create(ctx,
+				org.ID,
+				app.ID,
+				env.name,
+				env.slug,
+				tracer.AuthAccount.ID,
+			); err != nil {
+				return err
+			}
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionCreate,
+			types.AuditEntityApplication,
+			app.ID,
+			nil,
+			app,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return app, nil
 }
 
 func (c *Core) AppGetMany(ctx context.Context, orgSlug string) ([]types.Application, error) {
@@ -120,7 +156,20 @@ func (c *Core) AppGetOne(ctx context.Context, args appGetOneArgs) (*types.Applic
 		return nil, err
 	}
 
-	return c.appRepo.GetOne(ctx, org.ID, args.id, args.uuid, args.slug)
+	app, err := c.appRepo.GetOne(ctx, org.ID, args.id, args.uuid, args.slug)
+	if err != nil {
+		return nil, err
+	}
+
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := sdkKeyAuthorizeScope(ctx, tracer, org.ID, &app.ID); err != nil {
+		return nil, err
+	}
+
+	return app, nil
 }
 
 type appUpdateArgs struct {
@@ -175,13 +224,39 @@ func (c *Core) AppUpdate(ctx context.Context, args appUpdateArgs) (*types.Applic
 		return nil, err
 	}
 
-	return c.appRepo.Update(ctx,
-		org.ID,
-		args.id,
-		args.name,
-		args.slug,
-		tracer.AuthAccount.ID,
-	)
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	var before, app *types.Application
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.appRepo.GetOne(ctx, org.ID, &args.id, nil, nil); err != nil {
+			return err
+		}
+
+		if app, err = c.appRepo.Update(ctx,
+			org.ID,
+			args.id,
+			args.name,
+			args.slug,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionUpdate,
+			types.AuditEntityApplication,
+			app.ID,
+			before,
+			app,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return app, nil
 }
 
 func (c *Core) AppDelete(ctx context.Context, orgSlug string, appSlug string) error {
@@ -194,9 +269,25 @@ func (c *Core) AppDelete(ctx context.Context, orgSlug string, appSlug string) er
 	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
 		return err
 	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return err
+	}
 	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(orgSlug, nil, nil, &appSlug)); err != nil {
 		return err
 	}
 
-	return c.appRepo.Delete(ctx, org.ID, app.ID)
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.appRepo.Delete(ctx, org.ID, app.ID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionDelete,
+			types.AuditEntityApplication,
+			app.ID,
+			app,
+			nil,
+		)
+	})
 }
@@ -0,0 +1,703 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"switchcraft/types"
+)
+
+// errApplyDryRun rolls back the transaction of a dry run
+var errApplyDryRun = errors.New("core.Apply dry run")
+
+type applyArgs struct {
+	config types.ApplyConfig
+	prune  bool
+	dryRun bool
+}
+
+func (a *applyArgs) Validate() error {
+	if a.prune && len(a.config.Organizations) == 0 {
+		return errors.New("applyArgs: prune needs at least one organization in the config file")
+	}
+
+	orgSlugs := map[string]bool{}
+	for _, org := range a.config.Organizations {
+		if org.Name == "" {
+			return errors.New("applyArgs: organization name cannot be empty")
+		}
+		if err := validateSlug(org.Slug); err != nil {
+			return fmt.Errorf("applyArgs: organization '%s': %w", org.Name, err)
+		}
+		if orgSlugs[org.Slug] {
+			return fmt.Errorf("applyArgs: duplicate organization '%s'", org.Slug)
+		}
+		orgSlugs[org.Slug] = true
+
+		usernames := map[string]bool{}
+		for _, account := range applyOrgAccounts(org) {
+			if account.Username == "" {
+				return fmt.Errorf("applyArgs: organization '%s': account username cannot be empty", org.Slug)
+			}
+			if usernames[account.Username] {
+				return fmt.Errorf("applyArgs: organization '%s': duplicate account '%s'", org.Slug, account.Username)
+			}
+			usernames[account.Username] = true
+		}
+
+		groupNames := map[string]bool{}
+		for _, group := range org.Groups {
+			if group.Name == "" {
+				return fmt.Errorf("applyArgs: organization '%s': group name cannot be empty", org.Slug)
+			}
+			if groupNames[group.Name] {
+				return fmt.Errorf("applyArgs: organization '%s': duplicate group '%s'", org.Slug, group.Name)
+			}
+			groupNames[group.Name] = true
+		}
+
+		appSlugs := map[string]bool{}
+		for _, app := range org.Applications {
+			if err := validateSlug(app.Slug); err != nil {
+				return fmt.Errorf("applyArgs: application '%s': %w", app.Name, err)
+			}
+			if appSlugs[app.Slug] {
+				return fmt.Errorf("applyArgs: organization '%s': duplicate application '%s'", org.Slug, app.Slug)
+			}
+			appSlugs[app.Slug] = true
+
+			flagNames := map[string]bool{}
+			for _, flag := range app.FeatureFlags {
+				if flag.Name == "" {
+					return fmt.Errorf("applyArgs: application '%s': flag name cannot be empty", app.Slug)
+				}
+				if flagNames[flag.Name] {
+					return fmt.Errorf("applyArgs: application '%s': duplicate flag '%s'", app.Slug, flag.Name)
+				}
+				flagNames[flag.Name] = true
+
+				// Groups left out of the file could be pruned along with
+				// their overrides, so overrides must name a declared group
+				flagGroups := map[string]bool{}
+				for _, groupFlag := range flag.GroupFlags {
+					if !groupNames[groupFlag.Group] {
+						return fmt.Errorf("applyArgs: flag '%s': group '%s' is not declared in organization '%s'",
+							flag.Name,
+							groupFlag.Group,
+							org.Slug,
+						)
+					}
+					if flagGroups[groupFlag.Group] {
+						return fmt.Errorf("applyArgs: flag '%s': duplicate override for group '%s'", flag.Name, groupFlag.Group)
+					}
+					flagGroups[groupFlag.Group] = true
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Core) NewApplyArgs(
+	config types.ApplyConfig,
+	prune bool,
+	dryRun bool,
+) applyArgs {
+	return applyArgs{
+		config: config,
+		prune:  prune,
+		dryRun: dryRun,
+	}
+}
+
+// Apply makes organizations match a config file in a single transaction and
+// returns the changes made. Resources are matched by slug, name or username,
+// and only those that differ are changed, so applying the same file twice
+// changes nothing the second time.
+//
+// Without prune, resources missing from the file are left alone. With prune
+// the groups, applications and flags of the organizations in the file that
+// are missing from it are deleted, flags are archived first. Organizations
+// and accounts are never deleted.
+//
+// Changes go through the regular operations so each is authorized and
+// audited, and every changed flag gets one new version. A dry run makes the
+// same changes and rolls them back, returning the plan.
+func (c *Core) Apply(ctx context.Context, args applyArgs) (*types.ApplyPlan, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if tracer.SDKKey != nil || tracer.AuthAccount.ID == 0 {
+		return nil, types.ErrOperationNotPermitted
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	plan := &types.ApplyPlan{
+		Prune:  args.prune,
+		DryRun: args.dryRun,
+	}
+
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		ctx = context.WithValue(ctx, ctxFeatFlagVersionDeferred, true)
+
+		// Reset on every attempt, the transaction may be retried
+		plan.Changes = []types.ApplyChange{}
+
+		for i := range args.config.Organizations {
+			if err := c.applyOrg(ctx, plan, &args.config.Organizations[i]); err != nil {
+				return err
+			}
+		}
+
+		if args.dryRun {
+			return errApplyDryRun
+		}
+		return nil
+	}); err != nil && !errors.Is(err, errApplyDryRun) {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// applyOrg applies an org and everything in it. Ownership is transferred
+// last, the account applying may no longer own the org afterwards.
+func (c *Core) applyOrg(ctx context.Context, plan *types.ApplyPlan, config *types.ApplyOrganization) error {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return err
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &config.Slug))
+	if errors.Is(err, types.ErrNotFound) {
+		if org, err = c.OrgCreate(ctx, c.NewOrgCreateArgs(config.Name, config.Slug, tracer.AuthAccount.ID)); err != nil {
+			return err
+		}
+		applyRecord(plan, types.ApplyActionCreate, types.ApplyResourceOrg, nil, org.Slug)
+	} else if err != nil {
+		return err
+	} else if org.Name != config.Name {
+		if org, err = c.OrgUpdate(ctx, c.NewOrgUpdateArgs(org.ID, config.Name, org.Slug, org.Owner)); err != nil {
+			return err
+		}
+		applyRecord(plan, types.ApplyActionUpdate, types.ApplyResourceOrg, []string{"name"}, org.Slug)
+	}
+
+	accounts, err := c.applyAccounts(ctx, plan, org, config)
+	if err != nil {
+		return err
+	}
+
+	groups, err := c.applyGroups(ctx, plan, org, config, accounts)
+	if err != nil {
+		return err
+	}
+
+	for i := range config.Applications {
+		if err = c.applyApp(ctx, plan, org, &config.Applications[i], groups); err != nil {
+			return err
+		}
+	}
+
+	if plan.Prune {
+		apps, err := c.AppGetMany(ctx, org.Slug)
+		if err != nil {
+			return err
+		}
+		for _, app := range apps {
+			if slices.ContainsFunc(config.Applications, func(a types.ApplyApplication) bool {
+				return a.Slug == app.Slug
+			}) {
+				continue
+			}
+			if err = c.AppDelete(ctx, org.Slug, app.Slug); err != nil {
+				return err
+			}
+			applyRecord(plan, types.ApplyActionDelete, types.ApplyResourceApplication, nil, org.Slug, app.Slug)
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(groups)) {
+			if slices.ContainsFunc(config.Groups, func(g types.ApplyGroup) bool { return g.Name == name }) {
+				continue
+			}
+			if err = c.OrgGroupDelete(ctx, org.Slug, groups[name].ID); err != nil {
+				return err
+			}
+			applyRecord(plan, types.ApplyActionDelete, types.ApplyResourceGroup, nil, org.Slug, name)
+		}
+	}
+
+	if config.Owner.Username != "" {
+		owner := accounts[config.Owner.Username]
+		if org.Owner != owner.ID {
+			if _, err = c.OrgUpdate(ctx, c.NewOrgUpdateArgs(org.ID, org.Name, org.Slug, owner.ID)); err != nil {
+				return err
+			}
+			applyRecord(plan, types.ApplyActionUpdate, types.ApplyResourceOrg, []string{"owner"}, org.Slug)
+		}
+	}
+
+	return nil
+}
+
+// applyAccounts creates and updates the org's accounts, returning every
+// account of the org by username
+func (c *Core) applyAccounts(ctx context.Context,
+	plan *types.ApplyPlan,
+	org *types.Organization,
+	config *types.ApplyOrganization,
+) (map[string]*types.Account, error) {
+	existing, err := c.OrgAccountGetMany(ctx, org.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make(map[string]*types.Account, len(existing))
+	for i := range existing {
+		accounts[existing[i].Username] = &existing[i]
+	}
+
+	for _, config := range applyOrgAccounts(*config) {
+		account, ok := accounts[config.Username]
+		if !ok {
+			if account, err = c.OrgAccountCreate(ctx, c.NewOrgAccountCreateArgs(
+				org.Slug,
+				config.FirstName,
+				config.LastName,
+				config.Email,
+				config.Username,
+				nil,
+			)); err != nil {
+				return nil, err
+			}
+			accounts[account.Username] = account
+			applyRecord(plan, types.ApplyActionCreate, types.ApplyResourceAccount, nil, org.Slug, account.Username)
+			continue
+		}
+
+		fields := []string{}
+		if account.FirstName != config.FirstName {
+			fields = append(fields, "firstName")
+		}
+		if account.LastName != config.LastName {
+			fields = append(fields, "lastName")
+		}
+		if account.Email != config.Email {
+			fields = append(fields, "email")
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		if account, err = c.OrgAccountUpdate(ctx, c.NewOrgAccountUpdateArgs(
+			org.Slug,
+			account.ID,
+			config.FirstName,
+			config.LastName,
+			config.Email,
+			account.Username,
+		)); err != nil {
+			return nil, err
+		}
+		accounts[account.Username] = account
+		applyRecord(plan, types.ApplyActionUpdate, types.ApplyResourceAccount, fields, org.Slug, account.Username)
+	}
+
+	return accounts, nil
+}
+
+// applyGroups creates and updates the org's groups along with their members,
+// returning every group of the org by name
+func (c *Core) applyGroups(ctx context.Context,
+	plan *types.ApplyPlan,
+	org *types.Organization,
+	config *types.ApplyOrganization,
+	accounts map[string]*types.Account,
+) (map[string]*types.OrgGroup, error) {
+	existing, err := c.OrgGroupGetMany(ctx, org.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]*types.OrgGroup, len(existing))
+	for i := range existing {
+		groups[existing[i].Name] = &existing[i]
+	}
+
+	for _, config := range config.Groups {
+		group, ok := groups[config.Name]
+		if !ok {
+			if group, err = c.OrgGroupCreate(ctx, c.NewOrgGroupCreateArgs(
+				org.Slug,
+				config.Name,
+				config.Description,
+			)); err != nil {
+				return nil, err
+			}
+			groups[group.Name] = group
+			applyRecord(plan, types.ApplyActionCreate, types.ApplyResourceGroup, nil, org.Slug, group.Name)
+		} else if group.Description != config.Description {
+			if group, err = c.OrgGroupUpdate(ctx, c.NewOrgGroupUpdateArgs(
+				org.Slug,
+				group.ID,
+				group.Name,
+				config.Description,
+			)); err != nil {
+				return nil, err
+			}
+			groups[group.Name] = group
+			applyRecord(plan, types.ApplyActionUpdate, types.ApplyResourceGroup, []string{"description"}, org.Slug, group.Name)
+		}
+
+		members, err := c.OrgGroupAccountGetAll(ctx, org.Slug, group.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, username := range config.Members {
+			if slices.ContainsFunc(members, func(a types.Account) bool { return a.Username == username }) {
+				continue
+			}
+			account, ok := accounts[username]
+			if !ok {
+				return nil, fmt.Errorf("%w: account '%s' of group '%s' not in organization '%s'",
+					types.ErrLinkedItemNotFound,
+					username,
+					group.Name,
+					org.Slug,
+				)
+			}
+			if _, err = c.OrgGroupAccountAdd(ctx, c.NewOrgGroupAccountAddArgs(org.Slug, group.ID, account.ID)); err != nil {
+				return nil, err
+			}
+			applyRecord(plan, types.ApplyActionCreate, types.ApplyResourceGroupMember, nil, org.Slug, group.Name, username)
+		}
+
+		if !plan.Prune {
+			continue
+		}
+		for _, member := range members {
+			if slices.Contains(config.Members, member.Username) {
+				continue
+			}
+			if err = c.OrgGroupAccountRemove(ctx, org.Slug, group.ID, member.ID); err != nil {
+				return nil, err
+			}
+			applyRecord(plan, types.ApplyActionDelete, types.ApplyResourceGroupMember, nil, org.Slug, group.Name, member.Username)
+		}
+	}
+
+	return groups, nil
+}
+
+func (c *Core) applyApp(ctx context.Context,
+	plan *types.ApplyPlan,
+	org *types.Organization,
+	config *types.ApplyApplication,
+	groups map[string]*types.OrgGroup,
+) error {
+	app, err := c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &config.Slug))
+	if errors.Is(err, types.ErrNotFound) {
+		if app, err = c.AppCreate(ctx, c.NewAppCreateArgs(org.Slug, config.Name, config.Slug)); err != nil {
+			return err
+		}
+		applyRecord(plan, types.ApplyActionCreate, types.ApplyResourceApplication, nil, org.Slug, app.Slug)
+	} else if err != nil {
+		return err
+	} else if app.Name != config.Name {
+		if app, err = c.AppUpdate(ctx, c.NewAppUpdateArgs(org.Slug, app.ID, config.Name, app.Slug)); err != nil {
+			return err
+		}
+		applyRecord(plan, types.ApplyActionUpdate, types.ApplyResourceApplication, []string{"name"}, org.Slug, app.Slug)
+	}
+
+	env, err := c.appEnvGet(ctx, org.ID, app.ID, config.Environment)
+	if err != nil {
+		return err
+	}
+
+	flags, err := c.featureFlagRepo.GetMany(ctx, org.ID, app.ID, env.ID)
+	if err != nil {
+		return err
+	}
+
+	target := featFlagEnv{app: app, env: env}
+	for i := range config.FeatureFlags {
+		var current *types.FeatureFlag
+		if j := slices.IndexFunc(flags, func(f types.FeatureFlag) bool { return f.Name == config.FeatureFlags[i].Name }); j >= 0 {
+			current = &flags[j]
+		}
+		if err = c.applyFeatFlag(ctx, plan, org, target, &config.FeatureFlags[i], current, groups); err != nil {
+			return err
+		}
+	}
+
+	if !plan.Prune {
+		return nil
+	}
+
+	pruned := []types.FeatureFlag{}
+	for _, flag := range flags {
+		if !slices.ContainsFunc(config.FeatureFlags, func(f types.ApplyFeatureFlag) bool { return f.Name == flag.Name }) {
+			pruned = append(pruned, flag)
+		}
+	}
+
+	// Flags cannot be archived or deleted while other flags depend on them
+	for _, flag := range applyPruneOrder(pruned) {
+		if flag.Lifecycle != types.FeatureFlagLifecycleArchived {
+			if _, err = c.FeatFlagArchive(ctx, org.Slug, app.Slug, env.Slug, flag.ID); err != nil {
+				return err
+			}
+		}
+	}
+	for _, flag := range applyPruneOrder(pruned) {
+		if err = c.FeatFlagDelete(ctx, org.Slug, app.Slug, env.Slug, flag.ID, false); err != nil {
+			return err
+		}
+		applyRecord(plan, types.ApplyActionDelete, types.ApplyResourceFeatureFlag, nil,
+			org.Slug,
+			app.Slug,
+			env.Slug,
+			flag.Name,
+		)
+	}
+
+	return nil
+}
+
+// applyFeatFlag creates or updates a flag and its group overrides. It must run
+// within a WithTx with version snapshots deferred.
+func (c *Core) applyFeatFlag(ctx context.Context,
+	plan *types.ApplyPlan,
+	org *types.Organization,
+	target featFlagEnv,
+	config *types.ApplyFeatureFlag,
+	current *types.FeatureFlag,
+	groups map[string]*types.OrgGroup,
+) error {
+	var (
+		address = []string{org.Slug, target.app.Slug, target.env.Slug, config.Name}
+		flag    = current
+		changed bool
+		err     error
+	)
+
+	if current == nil {
+		if flag, err = c.FeatFlagCreate(ctx, c.NewFeatFlagCreateArgs(
+			org.Slug,
+			target.app.Slug,
+			target.env.Slug,
+			config.Name,
+			config.Label,
+			config.Description,
+			config.IsEnabled,
+			config.RolloutPercentage,
+			config.FlagType,
+			config.Variants,
+			config.DefaultVariant,
+			config.Rules,
+			nil,
+			nil,
+		)); err != nil {
+			return err
+		}
+		applyRecord(plan, types.ApplyActionCreate, types.ApplyResourceFeatureFlag, nil, address...)
+	} else {
+		flagType := config.FlagType
+		if flagType == "" {
+			flagType = types.FeatureFlagTypeBoolean
+		}
+		desired := &types.FeatureFlag{
+			Name:              config.Name,
+			Label:             config.Label,
+			Description:       config.Description,
+			IsEnabled:         config.IsEnabled,
+			RolloutPercentage: config.RolloutPercentage,
+			FlagType:          flagType,
+			Variants:          config.Variants,
+			DefaultVariant:    config.DefaultVariant,
+			Rules:             config.Rules,
+			Prerequisites:     current.Prerequisites,
+			ExpiresAt:         current.ExpiresAt,
+		}
+
+		if diff := featFlagDiff(desired, current, nil, nil, nil, nil); len(diff.Fields) > 0 {
+			if flag, err = c.FeatFlagUpdate(ctx, c.NewFeatFlagUpdateArgs(
+				org.Slug,
+				target.app.Slug,
+				target.env.Slug,
+				current.ID,
+				config.Name,
+				config.Label,
+				config.Description,
+				config.IsEnabled,
+				config.RolloutPercentage,
+				config.FlagType,
+				config.Variants,
+				config.DefaultVariant,
+				config.Rules,
+				current.Prerequisites,
+				current.ExpiresAt,
+			)); err != nil {
+				return err
+			}
+			changed = true
+			applyRecord(plan, types.ApplyActionUpdate, types.ApplyResourceFeatureFlag, diff.Fields, address...)
+		}
+	}
+
+	groupFlags, err := c.featureFlagRepo.GroupFlagsGetByFlagID(ctx, org.ID, target.app.ID, target.env.ID, flag.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, groupFlag := range config.GroupFlags {
+		group := groups[groupFlag.Group]
+		groupAddress := append(slices.Clone(address), group.Name)
+
+		var (
+			action = types.ApplyActionCreate
+			fields []string
+		)
+		if i := slices.IndexFunc(groupFlags, func(gf types.OrgGroupFeatureFlag) bool { return gf.GroupID == group.ID }); i >= 0 {
+			action = types.ApplyActionUpdate
+			existing := groupFlags[i]
+			if existing.IsEnabled != groupFlag.IsEnabled {
+				fields = append(fields, "isEnabled")
+			}
+			if !ptrEqual(existing.RolloutPercentage, groupFlag.RolloutPercentage) {
+				fields = append(fields, "rolloutPercentage")
+			}
+			if !ptrEqual(existing.Variant, groupFlag.Variant) {
+				fields = append(fields, "variant")
+			}
+			if len(fields) == 0 {
+				continue
+			}
+		}
+
+		if _, err = c.GroupFlagUpsert(ctx, c.NewGroupFlagUpsertArgs(
+			org.Slug,
+			group.ID,
+			target.app.Slug,
+			target.env.Slug,
+			flag.ID,
+			groupFlag.IsEnabled,
+			groupFlag.RolloutPercentage,
+			groupFlag.Variant,
+		)); err != nil {
+			return err
+		}
+		changed = true
+		applyRecord(plan, action, types.ApplyResourceGroupFlag, fields, groupAddress...)
+	}
+
+	if plan.Prune {
+		for _, groupFlag := range groupFlags {
+			var groupName string
+			for name, group := range groups {
+				if group.ID == groupFlag.GroupID {
+					groupName = name
+				}
+			}
+			if slices.ContainsFunc(config.GroupFlags, func(gf types.ApplyGroupFlag) bool { return gf.Group == groupName }) {
+				continue
+			}
+			if err = c.GroupFlagDelete(ctx, c.NewGroupFlagDeleteArgs(
+				org.Slug,
+				groupFlag.GroupID,
+				target.app.Slug,
+				target.env.Slug,
+				flag.ID,
+			)); err != nil {
+				return err
+			}
+			changed = true
+			applyRecord(plan, types.ApplyActionDelete, types.ApplyResourceGroupFlag, nil,
+				append(slices.Clone(address), groupName)...,
+			)
+		}
+	}
+
+	if current != nil {
+		if !changed {
+			return nil
+		}
+		_, err = c.featFlagVersionCreate(ctx, org.ID, target.app.ID, target.env.ID, flag.ID, nil)
+		return err
+	}
+
+	// A new flag exists in every environment of the application
+	appEnvs, err := c.environmentRepo.GetMany(ctx, org.ID, target.app.ID)
+	if err != nil {
+		return err
+	}
+	for _, appEnv := range appEnvs {
+		if _, err = c.featFlagVersionCreate(ctx, org.ID, target.app.ID, appEnv.ID, flag.ID, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyPruneOrder moves flags before the pruned flags they have as
+// prerequisites, a cycle is left as is and fails when archived
+func applyPruneOrder(flags []types.FeatureFlag) []types.FeatureFlag {
+	var (
+		ordered = make([]types.FeatureFlag, 0, len(flags))
+		pending = slices.Clone(flags)
+	)
+
+	for len(pending) > 0 {
+		next := slices.IndexFunc(pending, func(flag types.FeatureFlag) bool {
+			// Nothing still pending depends on it
+			return !slices.ContainsFunc(pending, func(other types.FeatureFlag) bool {
+				return slices.ContainsFunc(other.Prerequisites, func(p types.FeatureFlagPrerequisite) bool {
+					return p.FlagID == flag.ID
+				})
+			})
+		})
+		if next < 0 {
+			return append(ordered, pending...)
+		}
+		ordered = append(ordered, pending[next])
+		pending = slices.Delete(pending, next, next+1)
+	}
+
+	return ordered
+}
+
+// applyOrgAccounts lists the owner, when set, along with the org's accounts
+func applyOrgAccounts(org types.ApplyOrganization) []types.ApplyAccount {
+	if org.Owner.Username == "" {
+		return org.Accounts
+	}
+	return append([]types.ApplyAccount{org.Owner}, org.Accounts...)
+}
+
+func applyRecord(plan *types.ApplyPlan,
+	action types.ApplyAction,
+	resource types.ApplyResource,
+	fields []string,
+	address ...string,
+) {
+	if fields == nil {
+		fields = []string{}
+	}
+	plan.Changes = append(plan.Changes, types.ApplyChange{
+		Action:   action,
+		Resource: resource,
+		Address:  strings.Join(address, "/"),
+		Fields:   fields,
+	})
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"switchcraft/types"
+	"time"
+)
+
+const (
+	auditLogDefaultLimit = 100
+	auditLogMaxLimit     = 1000
+)
+
+// auditLog records a change made by the current operation. Callers must run
+// it with the ctx of the same WithTx call as the change it records so that
+// neither is persisted without the other.
+func (c *Core) auditLog(ctx context.Context,
+	orgID *int64,
+	action types.AuditAction,
+	entityType types.AuditEntityType,
+	entityID int64,
+	before any,
+	after any,
+) error {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Self signup has no authenticated account
+	var actorID *int64
+	if tracer.AuthAccount.ID != 0 {
+		actorID = &tracer.AuthAccount.ID
+	}
+
+	_, err = c.auditLogRepo.Create(ctx,
+		orgID,
+		actorID,
+		tracer.TraceID,
+		action,
+		entityType,
+		entityID,
+		before,
+		after,
+	)
+	return err
+}
+
+type auditLogGetManyArgs struct {
+	orgSlug    string
+	entityType *types.AuditEntityType
+	entityID   *int64
+	actorID    *int64
+	from       *time.Time
+	to         *time.Time
+	limit      int
+}
+
+func (a *auditLogGetManyArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("auditLogGetManyArgs.orgSlug cannot be empty")
+	}
+	if a.entityID != nil && a.entityType == nil {
+		return errors.New("auditLogGetManyArgs.entityID requires entityType")
+	}
+	if a.entityID != nil && *a.entityID < 1 {
+		return errors.New("auditLogGetManyArgs.entityID must be positive integer")
+	}
+	if a.actorID != nil && *a.actorID < 1 {
+		return errors.New("auditLogGetManyArgs.actorID must be positive integer")
+	}
+	if a.from != nil && a.to != nil && !a.from.Before(*a.to) {
+		return errors.New("auditLogGetManyArgs.from must be before to")
+	}
+	if a.limit < 0 || a.limit > auditLogMaxLimit {
+		return errors.New("auditLogGetManyArgs.limit must be between 1 and 1000")
+	}
+	return nil
+}
+
+// NewAuditLogGetManyArgs builds audit log filters, nil filters are ignored.
+// A limit of 0 uses the default of 100 entries.
+func (c *Core) NewAuditLogGetManyArgs(
+	orgSlug string,
+	entityType *types.AuditEntityType,
+	entityID *int64,
+	actorID *int64,
+	from *time.Time,
+	to *time.Time,
+	limit int,
+) auditLogGetManyArgs {
+	return auditLogGetManyArgs{
+		orgSlug:    orgSlug,
+		entityType: entityType,
+		entityID:   entityID,
+		actorID:    actorID,
+		from:       from,
+		to:         to,
+		limit:      limit,
+	}
+}
+
+// AuditLogGetMany returns an org's audit log, newest first
+func (c *Core) AuditLogGetMany(ctx context.Context, args auditLogGetManyArgs) ([]types.AuditLogEntry, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	limit := args.limit
+	if limit == 0 {
+		limit = auditLogDefaultLimit
+	}
+
+	return c.auditLogRepo.GetMany(ctx,
+		org.ID,
+		args.entityType,
+		args.entityID,
+		args.actorID,
+		args.from,
+		args.to,
+		limit,
+	)
+}
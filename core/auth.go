@@ -139,7 +139,10 @@ func (c *Core) AuthPasswordCheck(password, encodedHash string) (match bool, err
 	return false, nil
 }
 
-func (c *Core) AuthCreateJWT(account *types.Account) (string, error) {
+// AuthCreateJWT signs a JWT for an account. Org accounts also get their
+// effective org role as the orgRole claim. It is informational for clients,
+// core always resolves roles itself when authorizing.
+func (c *Core) AuthCreateJWT(ctx context.Context, account *types.Account) (string, error) {
 	var (
 		token    *jwt.Token
 		tokenStr string
@@ -151,20 +154,27 @@ func (c *Core) AuthCreateJWT(account *types.Account) (string, error) {
 		return "", fmt.Errorf("core.AuthCreateJWT: %w", err)
 	}
 
-	token = jwt.NewWithClaims(
-		jwt.SigningMethodHS512,
-		jwt.MapClaims{
-			// Registered claims
-			"iss": jwtIssuer,
-			"aud": []string{jwtIssuer},
-			"sub": account.Username,
-			"exp": time.Now().Add(jwtLifetime).Unix(), // 1 day
-			"iat": time.Now().Unix(),
+	claims := jwt.MapClaims{
+		// Registered claims
+		"iss": jwtIssuer,
+		"aud": []string{jwtIssuer},
+		"sub": account.Username,
+		"exp": time.Now().Add(jwtLifetime).Unix(), // 1 day
+		"iat": time.Now().Unix(),
 
-			// Custom claims
-			"account": account,
-		},
-	)
+		// Custom claims
+		"account": account,
+	}
+
+	if account.OrgID != nil {
+		roles, err := c.orgAccountRolesGet(ctx, *account.OrgID, account.ID)
+		if err != nil {
+			return "", fmt.Errorf("core.AuthCreateJWT error getting org role: %w", err)
+		}
+		claims["orgRole"] = roles.Role
+	}
+
+	token = jwt.NewWithClaims(jwt.SigningMethodHS512, claims)
 
 	if tokenStr, err = token.SignedString(key); err != nil {
 		return "", fmt.Errorf("core.AuthCreateJWT error signing JWT: %w", err)
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"switchcraft/types"
+)
+
+// authorizeOrg is the tenant check for anything scoped to an org. Instance
+// admins may act in every org, org accounts only within their own. SDK keys
+// are limited to their own org by sdkKeyAuthorizeScope. Operations that look
+// up their org with OrgGetOne are checked there.
+func (c *Core) authorizeOrg(ctx context.Context, orgID int64) error {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if tracer.SDKKey != nil {
+		return sdkKeyAuthorizeScope(ctx, tracer, orgID, nil)
+	}
+
+	account := tracer.AuthAccount
+	if account.ID == 0 {
+		return types.ErrOperationNotPermitted
+	}
+	if account.IsInstanceAdmin {
+		return nil
+	}
+	if account.OrgID == nil || *account.OrgID != orgID {
+		c.logger.Warn(tracer, "Org access denied", map[string]any{
+			"accountID": account.ID,
+			"orgID":     orgID,
+		})
+		return types.ErrOperationNotPermitted
+	}
+
+	return nil
+}
+
+// authorizeInstanceAdmin guards instance wide operations, like managing
+// global accounts
+func (c *Core) authorizeInstanceAdmin(ctx context.Context) error {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if tracer.SDKKey != nil || tracer.AuthAccount.ID == 0 || !tracer.AuthAccount.IsInstanceAdmin {
+		c.logger.Warn(tracer, "Instance admin access denied", map[string]any{
+			"accountID": tracer.AuthAccount.ID,
+		})
+		return types.ErrOperationNotPermitted
+	}
+
+	return nil
+}
+
+// authorizeOrgRole extends authorizeOrg for operations that need more than
+// read access. The account's effective role in the org must include minRole.
+// SDK keys never pass since they are read only.
+func (c *Core) authorizeOrgRole(ctx context.Context, orgID int64, minRole types.OrgRole) error {
+	if err := c.authorizeOrg(ctx, orgID); err != nil {
+		return err
+	}
+
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if tracer.SDKKey != nil {
+		return types.ErrOperationNotPermitted
+	}
+	if tracer.AuthAccount.IsInstanceAdmin {
+		return nil
+	}
+
+	roles, err := c.orgAccountRolesGet(ctx, orgID, tracer.AuthAccount.ID)
+	if err != nil {
+		return err
+	}
+
+	if !roles.Role.Includes(minRole) {
+		c.logger.Warn(tracer, "Org role insufficient", map[string]any{
+			"accountID":    tracer.AuthAccount.ID,
+			"orgID":        orgID,
+			"role":         roles.Role,
+			"requiredRole": minRole,
+		})
+		return types.ErrOperationNotPermitted
+	}
+
+	return nil
+}
@@ -3,8 +3,10 @@ package core
 import (
 	"context"
 	"errors"
+	"net/http"
 	"regexp"
 	"switchcraft/types"
+	"time"
 )
 
 func NewCore(
@@ -13,9 +15,17 @@ func NewCore(
 	globalAccountRepo GlobalAccountRepo,
 	orgAccountRepo OrgAccountRepo,
 	orgGroupRepo OrgGroupRepo,
+	orgSegmentRepo OrgSegmentRepo,
 	orgRepo OrgRepo,
 	appRepo AppRepo,
+	environmentRepo EnvironmentRepo,
 	featureFlagRepo FeatureFlagRepo,
+	sdkKeyRepo SDKKeyRepo,
+	flagEventRepo FlagEventRepo,
+	flagScheduleRepo FlagScheduleRepo,
+	auditLogRepo AuditLogRepo,
+	webhookRepo WebhookRepo,
+	jobRepo JobRepo,
 	jwtSigningKey []byte,
 ) *Core {
 	return &Core{
@@ -24,9 +34,19 @@ func NewCore(
 		globalAccountRepo: globalAccountRepo,
 		orgAccountRepo:    orgAccountRepo,
 		orgGroupRepo:      orgGroupRepo,
+		orgSegmentRepo:    orgSegmentRepo,
 		orgRepo:           orgRepo,
 		appRepo:           appRepo,
+		environmentRepo:   environmentRepo,
 		featureFlagRepo:   featureFlagRepo,
+		sdkKeyRepo:        sdkKeyRepo,
+		flagEventRepo:     flagEventRepo,
+		flagEvents:        newFlagEventBroker(),
+		flagScheduleRepo:  flagScheduleRepo,
+		auditLogRepo:      auditLogRepo,
+		webhookRepo:       webhookRepo,
+		webhookClient:     newWebhookClient(),
+		jobRepo:           jobRepo,
 		jwtSigningKey:     jwtSigningKey,
 	}
 }
@@ -37,9 +57,19 @@ type Core struct {
 	globalAccountRepo GlobalAccountRepo
 	orgAccountRepo    OrgAccountRepo
 	orgGroupRepo      OrgGroupRepo
+	orgSegmentRepo    OrgSegmentRepo
 	orgRepo           OrgRepo
 	appRepo           AppRepo
+	environmentRepo   EnvironmentRepo
 	featureFlagRepo   FeatureFlagRepo
+	sdkKeyRepo        SDKKeyRepo
+	flagEventRepo     FlagEventRepo
+	flagEvents        *flagEventBroker
+	flagScheduleRepo  FlagScheduleRepo
+	auditLogRepo      AuditLogRepo
+	webhookRepo       WebhookRepo
+	webhookClient     *http.Client
+	jobRepo           JobRepo
 	jwtSigningKey     []byte
 }
 
@@ -72,6 +102,8 @@ func validateSlug(slug string) error {
 type Repo interface {
 	MigrateUp() error
 	MigrateDown() error
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	WithSavepoint(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type GlobalAccountRepo interface {
@@ -145,6 +177,21 @@ type OrgAccountRepo interface {
 		accountID int64,
 	) (*types.Account, error)
 	Delete(ctx context.Context, orgID int64, id int64) error
+	RoleGetOne(ctx context.Context,
+		orgID int64,
+		accountID int64,
+	) (*types.OrgAccountRole, error)
+	RoleGetGrants(ctx context.Context,
+		orgID int64,
+		accountID int64,
+	) ([]types.OrgRoleGrant, error)
+	RoleSet(ctx context.Context,
+		orgID int64,
+		accountID int64,
+		role types.OrgRole,
+		createdBy int64,
+	) (*types.OrgAccountRole, error)
+	RoleDelete(ctx context.Context, orgID int64, accountID int64) error
 }
 
 type OrgGroupRepo interface {
@@ -155,6 +202,10 @@ type OrgGroupRepo interface {
 		createdBy int64,
 	) (*types.OrgGroup, error)
 	GetMany(ctx context.Context, orgID int64) ([]types.OrgGroup, error)
+	GetManyByAccountID(ctx context.Context,
+		orgID int64,
+		accountID int64,
+	) ([]types.OrgGroup, error)
 	GetOne(ctx context.Context,
 		orgID int64,
 		id *int64,
@@ -181,6 +232,11 @@ type OrgGroupRepo interface {
 		orgID int64,
 		groupID int64,
 	) ([]types.Account, error)
+	GetAccountsByAppID(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
+	) ([]types.SDKGroupAccount, error)
 	UpdateAccounts(ctx context.Context,
 		orgID int64,
 		groupID int64,
@@ -196,6 +252,68 @@ type OrgGroupRepo interface {
 		orgID int64,
 		groupID int64,
 	) error
+	RoleGetOne(ctx context.Context,
+		orgID int64,
+		groupID int64,
+	) (*types.OrgGroupRole, error)
+	RoleSet(ctx context.Context,
+		orgID int64,
+		groupID int64,
+		role types.OrgRole,
+		createdBy int64,
+	) (*types.OrgGroupRole, error)
+	RoleDelete(ctx context.Context,
+		orgID int64,
+		groupID int64,
+	) error
+}
+
+type OrgSegmentRepo interface {
+	Create(ctx context.Context,
+		orgID int64,
+		name string,
+		description string,
+		rules []types.SegmentRule,
+		createdBy int64,
+	) (*types.OrgSegment, error)
+	GetMany(ctx context.Context, orgID int64) ([]types.OrgSegment, error)
+	GetManyByID(ctx context.Context,
+		orgID int64,
+		segmentIDs []int64,
+	) ([]types.OrgSegment, error)
+	GetOne(ctx context.Context,
+		orgID int64,
+		id *int64,
+		uuid *string,
+	) (*types.OrgSegment, error)
+	Update(ctx context.Context,
+		orgID int64,
+		id int64,
+		name string,
+		description string,
+		rules []types.SegmentRule,
+		modifiedBy int64,
+	) (*types.OrgSegment, error)
+	Delete(ctx context.Context,
+		orgID int64,
+		id int64,
+	) error
+	AccountsSet(ctx context.Context,
+		orgID int64,
+		segmentID int64,
+		accountIDs []int64,
+		createdBy int64,
+	) error
+	FlagRefsGetMany(ctx context.Context,
+		orgID int64,
+		segmentID int64,
+	) ([]types.OrgSegmentFlagRef, error)
+	FlagRefsSet(ctx context.Context,
+		orgID int64,
+		environmentID int64,
+		flagID int64,
+		segmentIDs []int64,
+	) error
 }
 
 type OrgRepo interface {
@@ -250,23 +368,74 @@ type AppRepo interface {
 	) error
 }
 
+type EnvironmentRepo interface {
+	Create(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		name string,
+		slug string,
+		createdBy int64,
+	) (*types.Environment, error)
+	GetMany(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+	) ([]types.Environment, error)
+	GetOne(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		id *int64,
+		uuid *string,
+		slug *string,
+	) (*types.Environment, error)
+	Update(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		id int64,
+		name string,
+		slug string,
+		modifiedBy int64,
+	) (*types.Environment, error)
+	Delete(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		id int64,
+	) error
+}
+
 type FeatureFlagRepo interface {
 	Create(ctx context.Context,
 		orgID int64,
 		applicationID int64,
+		environmentID int64,
 		name string,
 		label string,
 		description string,
 		isEnabled bool,
+		rolloutPercentage *int,
+		flagType types.FeatureFlagType,
+		variants []types.FeatureFlagVariant,
+		defaultVariant *string,
+		rules []types.TargetingRule,
+		prerequisites []types.FeatureFlagPrerequisite,
+		expiresAt *time.Time,
 		createdBy int64,
 	) (*types.FeatureFlag, error)
 	GetMany(ctx context.Context,
 		orgID int64,
 		applicationID int64,
+		environmentID int64,
 	) ([]types.FeatureFlag, error)
+	GetManyForAccount(ctx context.Context,
+		orgSlug string,
+		appSlug string,
+		environmentID *int64,
+		envSlug string,
+		accountID int64,
+	) (*types.FlagEvaluationScope, []types.FeatureFlag, []types.OrgGroupFeatureFlag, error)
 	GetOne(ctx context.Context,
 		orgID int64,
 		applicationID int64,
+		environmentID int64,
 		id *int64,
 		uuid *string,
 		name *string,
@@ -274,11 +443,19 @@ type FeatureFlagRepo interface {
 	Update(ctx context.Context,
 		orgID int64,
 		applicationID int64,
+		environmentID int64,
 		id int64,
 		name string,
 		label string,
 		description string,
 		isEnabled bool,
+		rolloutPercentage *int,
+		flagType types.FeatureFlagType,
+		variants []types.FeatureFlagVariant,
+		defaultVariant *string,
+		rules []types.TargetingRule,
+		prerequisites []types.FeatureFlagPrerequisite,
+		expiresAt *time.Time,
 		modifiedBy int64,
 	) (*types.FeatureFlag, error)
 	Delete(ctx context.Context,
@@ -286,37 +463,325 @@ type FeatureFlagRepo interface {
 		applicationID int64,
 		id int64,
 	) error
+	LifecycleUpdate(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		id int64,
+		lifecycle types.FeatureFlagLifecycle,
+		modifiedBy int64,
+	) error
+	StaleGetMany(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+	) ([]types.StaleFeatureFlag, error)
+	PrerequisiteRemove(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		flagID int64,
+		modifiedBy int64,
+	) error
+	VersionCreate(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
+		flagID int64,
+		name string,
+		label string,
+		description string,
+		isEnabled bool,
+		rolloutPercentage *int,
+		flagType types.FeatureFlagType,
+		variants []types.FeatureFlagVariant,
+		defaultVariant *string,
+		rules []types.TargetingRule,
+		prerequisites []types.FeatureFlagPrerequisite,
+		groupFlags []types.FeatureFlagVersionGroupFlag,
+		restoredFrom *int,
+		createdBy int64,
+	) (*types.FeatureFlagVersion, error)
+	VersionsGetMany(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
+		flagID int64,
+	) ([]types.FeatureFlagVersion, error)
+	VersionGetOne(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
+		flagID int64,
+		version int,
+	) (*types.FeatureFlagVersion, error)
 	GroupFlagCreate(ctx context.Context,
 		orgID int64,
 		groupID int64,
 		appID int64,
+		environmentID int64,
 		flagID int64,
 		isEnabled bool,
+		rolloutPercentage *int,
+		variant *string,
 		createdBy int64,
 	) (*types.OrgGroupFeatureFlag, error)
+	GroupFlagsGetMany(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
+	) ([]types.OrgGroupFeatureFlag, error)
 	GroupFlagsGetByFlagID(ctx context.Context,
 		orgID int64,
 		applicationID int64,
+		environmentID int64,
+		flagID int64,
+	) ([]types.OrgGroupFeatureFlag, error)
+	GroupFlagsGetManyByAccount(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
+		flagID *int64,
+	) (map[int64][]types.OrgGroupFeatureFlag, error)
+	GroupFlagsGetByAccountID(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
 		flagID int64,
+		accountID int64,
 	) ([]types.OrgGroupFeatureFlag, error)
 	GroupFlagGetOne(ctx context.Context,
 		orgID int64,
 		groupID int64,
 		applicationID int64,
+		environmentID int64,
 		flagID int64,
 	) (*types.OrgGroupFeatureFlag, error)
 	GroupFlagUpdate(ctx context.Context,
 		orgID int64,
 		groupID int64,
 		appID int64,
+		environmentID int64,
 		flagID int64,
 		isEnabled bool,
+		rolloutPercentage *int,
+		variant *string,
+		modifiedBy int64,
+	) (*types.OrgGroupFeatureFlag, error)
+	GroupFlagUpsert(ctx context.Context,
+		orgID int64,
+		groupID int64,
+		appID int64,
+		environmentID int64,
+		flagID int64,
+		isEnabled bool,
+		rolloutPercentage *int,
+		variant *string,
 		modifiedBy int64,
 	) (*types.OrgGroupFeatureFlag, error)
 	GroupFlagDelete(ctx context.Context,
 		orgID int64,
 		groupID int64,
 		appID int64,
+		environmentID int64,
 		flagID int64,
 	) error
 }
+
+type SDKKeyRepo interface {
+	Create(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
+		name string,
+		keyType types.SDKKeyType,
+		keyPrefix string,
+		keyHash string,
+		createdBy int64,
+	) (*types.SDKKey, error)
+	GetMany(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+	) ([]types.SDKKey, error)
+	GetOne(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		id int64,
+	) (*types.SDKKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*types.SDKKey, error)
+	Rotate(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		id int64,
+		keyPrefix string,
+		keyHash string,
+		modifiedBy int64,
+	) (*types.SDKKey, error)
+	Revoke(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		id int64,
+		revokedBy int64,
+	) (*types.SDKKey, error)
+}
+
+type FlagEventRepo interface {
+	Create(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID *int64,
+		eventType types.FlagEventType,
+		flagID int64,
+		groupID *int64,
+		payload any,
+		createdBy int64,
+	) (*types.FlagEvent, error)
+	Lock(ctx context.Context, applicationID int64) error
+	GetOne(ctx context.Context, id int64) (*types.FlagEvent, error)
+	GetManyAfter(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
+		afterID int64,
+		limit int,
+	) ([]types.FlagEvent, error)
+	Listen(ctx context.Context, handler func(payload string)) error
+}
+
+type FlagScheduleRepo interface {
+	Create(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
+		flagID int64,
+		groupID *int64,
+		isEnabled bool,
+		rolloutPercentage *int,
+		executeAt time.Time,
+		createdBy int64,
+	) (*types.FlagSchedule, error)
+	GetMany(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
+		flagID int64,
+	) ([]types.FlagSchedule, error)
+	GetOne(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
+		flagID int64,
+		id int64,
+	) (*types.FlagSchedule, error)
+	Cancel(ctx context.Context,
+		orgID int64,
+		applicationID int64,
+		environmentID int64,
+		flagID int64,
+		id int64,
+		modifiedBy int64,
+	) (*types.FlagSchedule, error)
+	Lock(ctx context.Context) (bool, error)
+	GetManyDue(ctx context.Context, limit int) ([]types.FlagSchedule, error)
+	SetStatus(ctx context.Context,
+		id int64,
+		status types.FlagScheduleStatus,
+		errMessage *string,
+	) (*types.FlagSchedule, error)
+}
+
+type AuditLogRepo interface {
+	Create(ctx context.Context,
+		orgID *int64,
+		actorID *int64,
+		traceID string,
+		action types.AuditAction,
+		entityType types.AuditEntityType,
+		entityID int64,
+		before any,
+		after any,
+	) (*types.AuditLogEntry, error)
+	GetMany(ctx context.Context,
+		orgID int64,
+		entityType *types.AuditEntityType,
+		entityID *int64,
+		actorID *int64,
+		from *time.Time,
+		to *time.Time,
+		limit int,
+	) ([]types.AuditLogEntry, error)
+}
+
+type WebhookRepo interface {
+	Create(ctx context.Context,
+		orgID int64,
+		url string,
+		secret string,
+		eventTypes []types.WebhookEventType,
+		isEnabled bool,
+		createdBy int64,
+	) (*types.Webhook, error)
+	GetMany(ctx context.Context, orgID int64) ([]types.Webhook, error)
+	GetOne(ctx context.Context,
+		orgID int64,
+		id int64,
+	) (*types.Webhook, error)
+	Update(ctx context.Context,
+		orgID int64,
+		id int64,
+		url string,
+		secret string,
+		eventTypes []types.WebhookEventType,
+		isEnabled bool,
+		modifiedBy int64,
+	) (*types.Webhook, error)
+	Delete(ctx context.Context,
+		orgID int64,
+		id int64,
+	) error
+	DeliveryEnqueue(ctx context.Context,
+		orgID int64,
+		eventType types.WebhookEventType,
+		payload any,
+	) ([]int64, error)
+	DeliveryGetMany(ctx context.Context,
+		orgID int64,
+		webhookID int64,
+		limit int,
+	) ([]types.WebhookDelivery, error)
+	DeliveryGetOne(ctx context.Context,
+		orgID int64,
+		id int64,
+	) (*types.WebhookDelivery, error)
+	DeliveryAttemptSet(ctx context.Context,
+		id int64,
+		status types.WebhookDeliveryStatus,
+		responseStatus *int,
+		errMessage *string,
+	) (*types.WebhookDelivery, error)
+	DeliveryRedeliver(ctx context.Context,
+		orgID int64,
+		webhookID int64,
+		id int64,
+	) (*types.WebhookDelivery, error)
+}
+
+type JobRepo interface {
+	Create(ctx context.Context,
+		kind types.JobKind,
+		payload any,
+		maxAttempts int,
+	) (*types.Job, error)
+	GetMany(ctx context.Context,
+		status *types.JobStatus,
+		limit int,
+	) ([]types.Job, error)
+	GetOne(ctx context.Context, id int64) (*types.Job, error)
+	Claim(ctx context.Context, lock time.Duration) (*types.Job, error)
+	ResultSet(ctx context.Context,
+		id int64,
+		attempt int,
+		status types.JobStatus,
+		runAt *time.Time,
+		errMessage *string,
+	) (*types.Job, error)
+	Retry(ctx context.Context, id int64) (*types.Job, error)
+	Purge(ctx context.Context, completedBefore time.Time) (int64, error)
+}
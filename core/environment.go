@@ -0,0 +1,345 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"switchcraft/types"
+)
+
+// Environments created along with every new application
+var appDefaultEnvironments = []struct {
+	name string
+	slug string
+}{
+	{name: "Development", slug: "development"},
+	{name: "Staging", slug: "staging"},
+	{name: "Production", slug: types.DefaultEnvironmentSlug},
+}
+
+type envCreateArgs struct {
+	orgSlug string
+	appSlug string
+	name    string
+	slug    string
+}
+
+func (a *envCreateArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("envCreateArgs.orgSlug cannot be empty")
+	}
+	if a.appSlug == "" {
+		return errors.New("envCreateArgs.appSlug cannot be empty")
+	}
+	if a.name == "" {
+		return errors.New("envCreateArgs.name cannot be empty")
+	}
+	if err := validateSlug(a.slug); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Core) NewEnvCreateArgs(
+	orgSlug string,
+	appSlug string,
+	name string,
+	slug string,
+) envCreateArgs {
+	return envCreateArgs{
+		orgSlug: orgSlug,
+		appSlug: appSlug,
+		name:    name,
+		slug:    slug,
+	}
+}
+
+// EnvCreate adds an environment to an application. Existing flags are added
+// to it disabled.
+func (c *Core) EnvCreate(ctx context.Context, args envCreateArgs) (*types.Environment, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org *types.Organization
+		app *types.Application
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+
+	var env *types.Environment
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if env, err = c.environmentRepo.Create(ctx,
+			org.ID,
+			app.ID,
+			args.name,
+			args.slug,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionCreate,
+			types.AuditEntityEnvironment,
+			env.ID,
+			nil,
+			env,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+func (c *Core) EnvGetMany(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+) ([]types.Environment, error) {
+	var (
+		org *types.Organization
+		app *types.Application
+		err error
+	)
+
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &appSlug)); err != nil {
+		return nil, err
+	}
+
+	return c.environmentRepo.GetMany(ctx, org.ID, app.ID)
+}
+
+func (c *Core) EnvGetOne(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+) (*types.Environment, error) {
+	if envSlug == "" {
+		return nil, errors.New("core.EnvGetOne envSlug cannot be empty")
+	}
+
+	var (
+		org *types.Organization
+		app *types.Application
+		err error
+	)
+
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &appSlug)); err != nil {
+		return nil, err
+	}
+
+	return c.environmentRepo.GetOne(ctx, org.ID, app.ID, nil, nil, &envSlug)
+}
+
+type envUpdateArgs struct {
+	orgSlug string
+	appSlug string
+	envSlug string
+	name    string
+	slug    string
+}
+
+func (a *envUpdateArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("envUpdateArgs.orgSlug cannot be empty")
+	}
+	if a.appSlug == "" {
+		return errors.New("envUpdateArgs.appSlug cannot be empty")
+	}
+	if a.envSlug == "" {
+		return errors.New("envUpdateArgs.envSlug cannot be empty")
+	}
+	if a.name == "" {
+		return errors.New("envUpdateArgs.name cannot be empty")
+	}
+	if err := validateSlug(a.slug); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Core) NewEnvUpdateArgs(
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	name string,
+	slug string,
+) envUpdateArgs {
+	return envUpdateArgs{
+		orgSlug: orgSlug,
+		appSlug: appSlug,
+		envSlug: envSlug,
+		name:    name,
+		slug:    slug,
+	}
+}
+
+func (c *Core) EnvUpdate(ctx context.Context, args envUpdateArgs) (*types.Environment, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org *types.Organization
+		app *types.Application
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+
+	var before, env *types.Environment
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.environmentRepo.GetOne(ctx, org.ID, app.ID, nil, nil, &args.envSlug); err != nil {
+			return err
+		}
+
+		if env, err = c.environmentRepo.Update(ctx,
+			org.ID,
+			app.ID,
+			before.ID,
+			args.name,
+			args.slug,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionUpdate,
+			types.AuditEntityEnvironment,
+			env.ID,
+			before,
+			env,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// EnvDelete removes an environment along with its flag state, overrides,
+// versions, and SDK keys. An application always keeps at least one.
+func (c *Core) EnvDelete(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+) error {
+	if envSlug == "" {
+		return errors.New("core.EnvDelete envSlug cannot be empty")
+	}
+
+	var (
+		org *types.Organization
+		app *types.Application
+		env *types.Environment
+		err error
+	)
+
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
+		return err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &appSlug)); err != nil {
+		return err
+	}
+
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if env, err = c.environmentRepo.GetOne(ctx, org.ID, app.ID, nil, nil, &envSlug); err != nil {
+			return err
+		}
+
+		envs, err := c.environmentRepo.GetMany(ctx, org.ID, app.ID)
+		if err != nil {
+			return err
+		}
+		if len(envs) < 2 {
+			return fmt.Errorf("%w: environment '%s' is the only environment of application '%s'",
+				types.ErrItemInUse,
+				env.Slug,
+				app.Slug,
+			)
+		}
+
+		if err = c.environmentRepo.Delete(ctx, org.ID, app.ID, env.ID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionDelete,
+			types.AuditEntityEnvironment,
+			env.ID,
+			env,
+			nil,
+		)
+	})
+}
+
+// appEnvGet resolves the environment an operation applies to. An SDK key is
+// bound to a single environment, used when none is named, and cannot reach
+// any other. Everyone else gets the default environment when none is named.
+func (c *Core) appEnvGet(ctx context.Context,
+	orgID int64,
+	appID int64,
+	envSlug string,
+) (*types.Environment, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var env *types.Environment
+	if envSlug == "" && tracer.SDKKey != nil {
+		env, err = c.environmentRepo.GetOne(ctx, orgID, appID, &tracer.SDKKey.EnvironmentID, nil, nil)
+	} else {
+		if envSlug == "" {
+			envSlug = types.DefaultEnvironmentSlug
+		}
+		env, err = c.environmentRepo.GetOne(ctx, orgID, appID, nil, nil, &envSlug)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sdkKeyAuthorizeEnv(tracer, env.ID); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
@@ -3,16 +3,28 @@ package core
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"switchcraft/types"
+	"time"
 )
 
 type featFlagCreateArgs struct {
-	orgSlug     string
-	appSlug     string
-	name        string
-	label       string
-	description string
-	isEnabled   bool
+	orgSlug           string
+	appSlug           string
+	envSlug           string
+	name              string
+	label             string
+	description       string
+	isEnabled         bool
+	rolloutPercentage *int
+	flagType          types.FeatureFlagType
+	variants          []types.FeatureFlagVariant
+	defaultVariant    *string
+	rules             []types.TargetingRule
+	prerequisites     []types.FeatureFlagPrerequisite
+	expiresAt         *time.Time
 }
 
 func (a *featFlagCreateArgs) Validate() error {
@@ -25,24 +37,65 @@ func (a *featFlagCreateArgs) Validate() error {
 	if a.name == "" {
 		return errors.New("featFlagCreateArgs.name cannot be empty")
 	}
+	if !rolloutPercentageIsValid(a.rolloutPercentage) {
+		return errors.New("featFlagCreateArgs.rolloutPercentage must be between 0 and 100")
+	}
+	if err := featFlagVariantsValidate(a.flagType, a.variants, a.defaultVariant); err != nil {
+		return fmt.Errorf("featFlagCreateArgs: %w", err)
+	}
+	if err := featFlagRulesValidate(a.flagType, a.variants, a.rules); err != nil {
+		return fmt.Errorf("featFlagCreateArgs: %w", err)
+	}
+	if err := featFlagPrerequisitesValidate(0, a.prerequisites); err != nil {
+		return fmt.Errorf("featFlagCreateArgs: %w", err)
+	}
 	return nil
 }
 
 func (c *Core) NewFeatFlagCreateArgs(
 	orgSlug string,
 	appSlug string,
+	envSlug string,
 	name string,
 	label string,
 	description string,
 	isEnabled bool,
+	rolloutPercentage *int,
+	flagType types.FeatureFlagType,
+	variants []types.FeatureFlagVariant,
+	defaultVariant *string,
+	rules []types.TargetingRule,
+	prerequisites []types.FeatureFlagPrerequisite,
+	expiresAt *time.Time,
 ) featFlagCreateArgs {
+	if flagType == "" {
+		flagType = types.FeatureFlagTypeBoolean
+	}
+	if variants == nil {
+		variants = []types.FeatureFlagVariant{}
+	}
+	if rules == nil {
+		rules = []types.TargetingRule{}
+	}
+	if prerequisites == nil {
+		prerequisites = []types.FeatureFlagPrerequisite{}
+	}
+
 	return featFlagCreateArgs{
-		orgSlug:     orgSlug,
-		appSlug:     appSlug,
-		name:        name,
-		label:       label,
-		description: description,
-		isEnabled:   isEnabled,
+		orgSlug:           orgSlug,
+		appSlug:           appSlug,
+		envSlug:           envSlug,
+		name:              name,
+		label:             label,
+		description:       description,
+		isEnabled:         isEnabled,
+		rolloutPercentage: rolloutPercentage,
+		flagType:          flagType,
+		variants:          variants,
+		defaultVariant:    defaultVariant,
+		rules:             rules,
+		prerequisites:     prerequisites,
+		expiresAt:         expiresAt,
 	}
 }
 
@@ -59,36 +112,111 @@ func (c *Core) FeatFlagCreate(ctx context.Context, args featFlagCreateArgs) (*ty
 	var (
 		org *types.Organization
 		app *types.Application
+		env *types.Environment
 	)
 
 	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
 		return nil, err
 	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
 	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
 		return nil, err
 	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
 
-	return c.featureFlagRepo.Create(ctx,
-		org.ID,
-		app.ID,
-		args.name,
-		args.label,
-		args.description,
-		args.isEnabled,
-		tracer.AuthAccount.ID,
-	)
+	var flag *types.FeatureFlag
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if err = c.featFlagPrerequisitesCheck(ctx, org.ID, app.ID, env.ID, 0, args.prerequisites); err != nil {
+			return err
+		}
+
+		if flag, err = c.featureFlagRepo.Create(ctx,
+			org.ID,
+			app.ID,
+			env.ID,
+			args.name,
+			args.label,
+			args.description,
+			args.isEnabled,
+			args.rolloutPercentage,
+			args.flagType,
+			args.variants,
+			args.defaultVariant,
+			args.rules,
+			args.prerequisites,
+			args.expiresAt,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		if err = c.orgSegmentRepo.FlagRefsSet(ctx,
+			org.ID,
+			env.ID,
+			flag.ID,
+			types.TargetingRuleSegmentIDs(flag.Rules),
+		); err != nil {
+			return err
+		}
+
+		if err = c.flagEventPublish(ctx,
+			org.ID,
+			app.ID,
+			nil,
+			types.FlagEventFlagCreated,
+			flag.ID,
+			nil,
+			flag,
+		); err != nil {
+			return err
+		}
+
+		// The flag exists in every environment, each gets its own first version
+		var appEnvs []types.Environment
+		if appEnvs, err = c.environmentRepo.GetMany(ctx, org.ID, app.ID); err != nil {
+			return err
+		}
+		for _, appEnv := range appEnvs {
+			if err = c.featFlagVersionSnapshot(ctx, org.ID, app.ID, appEnv.ID, flag.ID); err != nil {
+				return err
+			}
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionCreate,
+			types.AuditEntityFeatureFlag,
+			flag.ID,
+			nil,
+			flag,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return flag, nil
 }
 
 func (c *Core) FeatFlagGetMany(ctx context.Context,
 	orgSlug string,
 	appSlug string,
+	envSlug string,
 ) ([]types.FeatureFlag, error) {
 	var (
 		org *types.Organization
 		app *types.Application
+		env *types.Environment
 		err error
 	)
 
+	if ctx, err = c.authorizeSDKKey(ctx, types.SDKKeyTypeServer); err != nil {
+		return nil, err
+	}
+
 	if org, err = c.OrgGetOne(ctx,
 		c.NewOrgGetOneArgs(nil, nil, &orgSlug),
 	); err != nil {
@@ -99,13 +227,17 @@ func (c *Core) FeatFlagGetMany(ctx context.Context,
 	); err != nil {
 		return nil, err
 	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, envSlug); err != nil {
+		return nil, err
+	}
 
-	return c.featureFlagRepo.GetMany(ctx, org.ID, app.ID)
+	return c.featureFlagRepo.GetMany(ctx, org.ID, app.ID, env.ID)
 }
 
 type featFlagGetOneArgs struct {
 	orgSlug string
 	appSlug string
+	envSlug string
 	id      *int64
 	uuid    *string
 	name    *string
@@ -130,6 +262,7 @@ func (a *featFlagGetOneArgs) Validate() error {
 func (c *Core) NewFeatFlagGetOneArgs(
 	orgSlug string,
 	appSlug string,
+	envSlug string,
 	id *int64,
 	uuid *string,
 	name *string,
@@ -137,6 +270,7 @@ func (c *Core) NewFeatFlagGetOneArgs(
 	return featFlagGetOneArgs{
 		orgSlug: orgSlug,
 		appSlug: appSlug,
+		envSlug: envSlug,
 		id:      id,
 		uuid:    uuid,
 		name:    name,
@@ -151,9 +285,14 @@ func (c *Core) FeatFlagGetOne(ctx context.Context, args featFlagGetOneArgs) (*ty
 	var (
 		org *types.Organization
 		app *types.Application
+		env *types.Environment
 		err error
 	)
 
+	if ctx, err = c.authorizeSDKKey(ctx, types.SDKKeyTypeServer); err != nil {
+		return nil, err
+	}
+
 	if org, err = c.OrgGetOne(ctx,
 		c.NewOrgGetOneArgs(nil, nil, &args.orgSlug),
 	); err != nil {
@@ -164,10 +303,14 @@ func (c *Core) FeatFlagGetOne(ctx context.Context, args featFlagGetOneArgs) (*ty
 	); err != nil {
 		return nil, err
 	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
 
 	return c.featureFlagRepo.GetOne(ctx,
 		org.ID,
 		app.ID,
+		env.ID,
 		args.id,
 		args.uuid,
 		args.name,
@@ -175,13 +318,21 @@ func (c *Core) FeatFlagGetOne(ctx context.Context, args featFlagGetOneArgs) (*ty
 }
 
 type featFlagUpdateArgs struct {
-	orgSlug     string
-	appSlug     string
-	id          int64
-	name        string
-	label       string
-	description string
-	isEnabled   bool
+	orgSlug           string
+	appSlug           string
+	envSlug           string
+	id                int64
+	name              string
+	label             string
+	description       string
+	isEnabled         bool
+	rolloutPercentage *int
+	flagType          types.FeatureFlagType
+	variants          []types.FeatureFlagVariant
+	defaultVariant    *string
+	rules             []types.TargetingRule
+	prerequisites     []types.FeatureFlagPrerequisite
+	expiresAt         *time.Time
 }
 
 func (a *featFlagUpdateArgs) Validate() error {
@@ -197,26 +348,67 @@ func (a *featFlagUpdateArgs) Validate() error {
 	if a.name == "" {
 		return errors.New("featFlagUpdateArgs.name cannot be empty")
 	}
+	if !rolloutPercentageIsValid(a.rolloutPercentage) {
+		return errors.New("featFlagUpdateArgs.rolloutPercentage must be between 0 and 100")
+	}
+	if err := featFlagVariantsValidate(a.flagType, a.variants, a.defaultVariant); err != nil {
+		return fmt.Errorf("featFlagUpdateArgs: %w", err)
+	}
+	if err := featFlagRulesValidate(a.flagType, a.variants, a.rules); err != nil {
+		return fmt.Errorf("featFlagUpdateArgs: %w", err)
+	}
+	if err := featFlagPrerequisitesValidate(a.id, a.prerequisites); err != nil {
+		return fmt.Errorf("featFlagUpdateArgs: %w", err)
+	}
 	return nil
 }
 
 func (c *Core) NewFeatFlagUpdateArgs(
 	orgSlug string,
 	appSlug string,
+	envSlug string,
 	id int64,
 	name string,
 	label string,
 	description string,
 	isEnabled bool,
+	rolloutPercentage *int,
+	flagType types.FeatureFlagType,
+	variants []types.FeatureFlagVariant,
+	defaultVariant *string,
+	rules []types.TargetingRule,
+	prerequisites []types.FeatureFlagPrerequisite,
+	expiresAt *time.Time,
 ) featFlagUpdateArgs {
+	if flagType == "" {
+		flagType = types.FeatureFlagTypeBoolean
+	}
+	if variants == nil {
+		variants = []types.FeatureFlagVariant{}
+	}
+	if rules == nil {
+		rules = []types.TargetingRule{}
+	}
+	if prerequisites == nil {
+		prerequisites = []types.FeatureFlagPrerequisite{}
+	}
+
 	return featFlagUpdateArgs{
-		orgSlug:     orgSlug,
-		appSlug:     appSlug,
-		id:          id,
-		name:        name,
-		label:       label,
-		description: description,
-		isEnabled:   isEnabled,
+		orgSlug:           orgSlug,
+		appSlug:           appSlug,
+		envSlug:           envSlug,
+		id:                id,
+		name:              name,
+		label:             label,
+		description:       description,
+		isEnabled:         isEnabled,
+		rolloutPercentage: rolloutPercentage,
+		flagType:          flagType,
+		variants:          variants,
+		defaultVariant:    defaultVariant,
+		rules:             rules,
+		prerequisites:     prerequisites,
+		expiresAt:         expiresAt,
 	}
 }
 
@@ -233,57 +425,293 @@ func (c *Core) FeatFlagUpdate(ctx context.Context, args featFlagUpdateArgs) (*ty
 	var (
 		org *types.Organization
 		app *types.Application
+		env *types.Environment
 	)
 	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
 		return nil, err
 	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
 	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
 		return nil, err
 	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
 
-	return c.featureFlagRepo.Update(ctx,
-		org.ID,
-		app.ID,
-		args.id,
-		args.name,
-		args.label,
-		args.description,
-		args.isEnabled,
-		tracer.AuthAccount.ID,
-	)
+	var before, flag *types.FeatureFlag
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.featureFlagRepo.GetOne(ctx, org.ID, app.ID, env.ID, &args.id, nil, nil); err != nil {
+			return err
+		}
+		if before.Lifecycle == types.FeatureFlagLifecycleArchived {
+			return fmt.Errorf("%w: flag '%s' is archived, restore it first", types.ErrInvalidState, before.Name)
+		}
+
+		if err = c.featFlagPrerequisitesCheck(ctx, org.ID, app.ID, env.ID, args.id, args.prerequisites); err != nil {
+			return err
+		}
+
+		if flag, err = c.featureFlagRepo.Update(ctx,
+			org.ID,
+			app.ID,
+			env.ID,
+			args.id,
+			args.name,
+			args.label,
+			args.description,
+			args.isEnabled,
+			args.rolloutPercentage,
+			args.flagType,
+			args.variants,
+			args.defaultVariant,
+			args.rules,
+			args.prerequisites,
+			args.expiresAt,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		if err = c.orgSegmentRepo.FlagRefsSet(ctx,
+			org.ID,
+			env.ID,
+			flag.ID,
+			types.TargetingRuleSegmentIDs(flag.Rules),
+		); err != nil {
+			return err
+		}
+
+		if err = c.flagEventPublish(ctx,
+			org.ID,
+			app.ID,
+			nil,
+			types.FlagEventFlagUpdated,
+			flag.ID,
+			nil,
+			flag,
+		); err != nil {
+			return err
+		}
+
+		if err = c.featFlagVersionSnapshot(ctx, org.ID, app.ID, env.ID, flag.ID); err != nil {
+			return err
+		}
+
+		// Shared fields changed in every environment, so each other
+		// environment's history needs a version matching its new state
+		if featFlagSharedFieldsChanged(before, flag) {
+			var appEnvs []types.Environment
+			if appEnvs, err = c.environmentRepo.GetMany(ctx, org.ID, app.ID); err != nil {
+				return err
+			}
+			for _, appEnv := range appEnvs {
+				if appEnv.ID == env.ID {
+					continue
+				}
+				if _, err = c.featFlagVersionCreate(ctx, org.ID, app.ID, appEnv.ID, flag.ID, nil); err != nil {
+					return err
+				}
+			}
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionUpdate,
+			types.AuditEntityFeatureFlag,
+			flag.ID,
+			before,
+			flag,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return flag, nil
 }
 
+// FeatFlagDelete permanently deletes an archived flag along with its group
+// overrides and history, flags must be archived first. It fails with
+// ErrItemInUse while other flags have the flag as a prerequisite. With force
+// it is removed from their prerequisites instead.
 func (c *Core) FeatFlagDelete(ctx context.Context,
 	orgSlug string,
 	appSlug string,
+	envSlug string,
 	id int64,
+	force bool,
 ) error {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return err
+	}
+
 	var (
-		org *types.Organization
-		app *types.Application
-		err error
+		org  *types.Organization
+		app  *types.Application
+		env  *types.Environment
+		flag *types.FeatureFlag
 	)
 
 	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
 		return err
 	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return err
+	}
 	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &appSlug)); err != nil {
 		return err
 	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, envSlug); err != nil {
+		return err
+	}
 
-	return c.featureFlagRepo.Delete(ctx,
-		org.ID,
-		app.ID,
-		id,
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if flag, err = c.featureFlagRepo.GetOne(ctx, org.ID, app.ID, env.ID, &id, nil, nil); err != nil {
+			return err
+		}
+		if flag.Lifecycle != types.FeatureFlagLifecycleArchived {
+			return fmt.Errorf("%w: flag '%s' must be archived before it is deleted", types.ErrInvalidState, flag.Name)
+		}
+
+		var dependents []types.FeatureFlag
+		if dependents, err = c.featFlagDependentsGet(ctx, org.ID, app.ID, env.ID, flag.ID); err != nil {
+			return err
+		}
+
+		if len(dependents) > 0 && !force {
+			flagNames := make([]string, len(dependents))
+			for i, dependent := range dependents {
+				flagNames[i] = dependent.Name
+			}
+			return fmt.Errorf("%w: flag '%s' is a prerequisite of flags %s",
+				types.ErrItemInUse,
+				flag.Name,
+				strings.Join(flagNames, ", "),
+			)
+		}
+
+		if len(dependents) > 0 {
+			if err = c.featureFlagRepo.PrerequisiteRemove(ctx,
+				org.ID,
+				app.ID,
+				flag.ID,
+				tracer.AuthAccount.ID,
+			); err != nil {
+				return err
+			}
+
+			for i := range dependents {
+				if err = c.featFlagPrerequisiteRemoved(ctx, org.ID, app.ID, env.ID, &dependents[i]); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err = c.featureFlagRepo.Delete(ctx,
+			org.ID,
+			app.ID,
+			flag.ID,
+		); err != nil {
+			return err
+		}
+
+		if err = c.flagEventPublish(ctx,
+			org.ID,
+			app.ID,
+			nil,
+			types.FlagEventFlagDeleted,
+			flag.ID,
+			nil,
+			flag,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionDelete,
+			types.AuditEntityFeatureFlag,
+			flag.ID,
+			flag,
+			nil,
+		)
+	})
+}
+
+// featFlagDependentsGet returns the flags that have flagID as a prerequisite
+func (c *Core) featFlagDependentsGet(ctx context.Context,
+	orgID int64,
+	appID int64,
+	envID int64,
+	flagID int64,
+) ([]types.FeatureFlag, error) {
+	flags, err := c.featureFlagRepo.GetMany(ctx, orgID, appID, envID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []types.FeatureFlag
+	for _, flag := range flags {
+		if slices.ContainsFunc(flag.Prerequisites, func(p types.FeatureFlagPrerequisite) bool {
+			return p.FlagID == flagID
+		}) {
+			dependents = append(dependents, flag)
+		}
+	}
+
+	return dependents, nil
+}
+
+// featFlagPrerequisiteRemoved records a forced delete dropping a prerequisite
+// of a flag the same way as an update of the flag
+func (c *Core) featFlagPrerequisiteRemoved(ctx context.Context,
+	orgID int64,
+	appID int64,
+	envID int64,
+	before *types.FeatureFlag,
+) error {
+	flag, err := c.featureFlagRepo.GetOne(ctx, orgID, appID, envID, &before.ID, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = c.flagEventPublish(ctx,
+		orgID,
+		appID,
+		nil,
+		types.FlagEventFlagUpdated,
+		flag.ID,
+		nil,
+		flag,
+	); err != nil {
+		return err
+	}
+
+	if err = c.featFlagVersionSnapshot(ctx, orgID, appID, envID, flag.ID); err != nil {
+		return err
+	}
+
+	return c.auditLog(ctx,
+		&orgID,
+		types.AuditActionUpdate,
+		types.AuditEntityFeatureFlag,
+		flag.ID,
+		before,
+		flag,
 	)
 }
 
 type groupFlagCreateArgs struct {
-	orgSlug   string
-	groupID   int64
-	appSlug   string
-	flagID    int64
-	isEnabled bool
+	orgSlug           string
+	groupID           int64
+	appSlug           string
+	envSlug           string
+	flagID            int64
+	isEnabled         bool
+	rolloutPercentage *int
+	variant           *string
 }
 
 func (a *groupFlagCreateArgs) Validate() error {
@@ -299,6 +727,9 @@ func (a *groupFlagCreateArgs) Validate() error {
 	if a.flagID < 1 {
 		return errors.New("groupFlagCreateArgs.flagID must be positive integer")
 	}
+	if !rolloutPercentageIsValid(a.rolloutPercentage) {
+		return errors.New("groupFlagCreateArgs.rolloutPercentage must be between 0 and 100")
+	}
 	return nil
 }
 
@@ -306,15 +737,21 @@ func (c *Core) NewGroupFlagCreateArgs(
 	orgSlug string,
 	groupID int64,
 	appSlug string,
+	envSlug string,
 	flagID int64,
 	isEnabled bool,
+	rolloutPercentage *int,
+	variant *string,
 ) groupFlagCreateArgs {
 	return groupFlagCreateArgs{
-		orgSlug:   orgSlug,
-		groupID:   groupID,
-		appSlug:   appSlug,
-		flagID:    flagID,
-		isEnabled: isEnabled,
+		orgSlug:           orgSlug,
+		groupID:           groupID,
+		appSlug:           appSlug,
+		envSlug:           envSlug,
+		flagID:            flagID,
+		isEnabled:         isEnabled,
+		rolloutPercentage: rolloutPercentage,
+		variant:           variant,
 	}
 }
 
@@ -331,27 +768,76 @@ func (c *Core) GroupFlagCreate(ctx context.Context, args groupFlagCreateArgs) (*
 	var (
 		org *types.Organization
 		app *types.Application
+		env *types.Environment
 	)
 	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
 		return nil, err
 	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
 	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
 		return nil, err
 	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
 
-	return c.featureFlagRepo.GroupFlagCreate(ctx,
-		org.ID,
-		args.groupID,
-		app.ID,
-		args.flagID,
-		args.isEnabled,
-		tracer.AuthAccount.ID,
-	)
+	var groupFlag *types.OrgGroupFeatureFlag
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if err = c.groupFlagVariantValidate(ctx, org.ID, app.ID, env.ID, args.flagID, args.variant); err != nil {
+			return err
+		}
+
+		if groupFlag, err = c.featureFlagRepo.GroupFlagCreate(ctx,
+			org.ID,
+			args.groupID,
+			app.ID,
+			env.ID,
+			args.flagID,
+			args.isEnabled,
+			args.rolloutPercentage,
+			args.variant,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		if err = c.flagEventPublish(ctx,
+			org.ID,
+			app.ID,
+			&env.ID,
+			types.FlagEventGroupFlagCreated,
+			groupFlag.FlagID,
+			&groupFlag.GroupID,
+			groupFlag,
+		); err != nil {
+			return err
+		}
+
+		if err = c.featFlagVersionSnapshot(ctx, org.ID, app.ID, env.ID, groupFlag.FlagID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionCreate,
+			types.AuditEntityGroupFlag,
+			groupFlag.GroupID,
+			nil,
+			groupFlag,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return groupFlag, nil
 }
 
 type groupFlagsGetByFlagIDArgs struct {
 	orgSlug string
 	appSlug string
+	envSlug string
 	flagID  int64
 }
 
@@ -371,11 +857,13 @@ func (a *groupFlagsGetByFlagIDArgs) Validate() error {
 func (c *Core) NewGroupFlagsGetByFlagIDArgs(
 	orgSlug string,
 	appSlug string,
+	envSlug string,
 	flagID int64,
 ) groupFlagsGetByFlagIDArgs {
 	return groupFlagsGetByFlagIDArgs{
 		orgSlug: orgSlug,
 		appSlug: appSlug,
+		envSlug: envSlug,
 		flagID:  flagID,
 	}
 }
@@ -388,22 +876,31 @@ func (c *Core) GroupFlagsGetByFlagID(ctx context.Context, args groupFlagsGetByFl
 	var (
 		org *types.Organization
 		app *types.Application
+		env *types.Environment
 		err error
 	)
+
+	if ctx, err = c.authorizeSDKKey(ctx, types.SDKKeyTypeServer); err != nil {
+		return nil, err
+	}
 	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
 		return nil, err
 	}
 	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
 		return nil, err
 	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
 
-	return c.featureFlagRepo.GroupFlagsGetByFlagID(ctx, org.ID, app.ID, args.flagID)
+	return c.featureFlagRepo.GroupFlagsGetByFlagID(ctx, org.ID, app.ID, env.ID, args.flagID)
 }
 
 type groupFlagGetOneArgs struct {
 	orgSlug string
 	groupID int64
 	appSlug string
+	envSlug string
 	flagID  int64
 }
 
@@ -427,12 +924,14 @@ func (c *Core) NewGroupFlagGetOneArgs(
 	orgSlug string,
 	groupID int64,
 	appSlug string,
+	envSlug string,
 	flagID int64,
 ) groupFlagGetOneArgs {
 	return groupFlagGetOneArgs{
 		orgSlug: orgSlug,
 		groupID: groupID,
 		appSlug: appSlug,
+		envSlug: envSlug,
 		flagID:  flagID,
 	}
 }
@@ -445,6 +944,7 @@ func (c *Core) GroupFlagGetOne(ctx context.Context, args groupFlagGetOneArgs) (*
 	var (
 		org *types.Organization
 		app *types.Application
+		env *types.Environment
 		err error
 	)
 	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
@@ -453,16 +953,22 @@ func (c *Core) GroupFlagGetOne(ctx context.Context, args groupFlagGetOneArgs) (*
 	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
 		return nil, err
 	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
 
-	return c.featureFlagRepo.GroupFlagGetOne(ctx, org.ID, args.groupID, app.ID, args.flagID)
+	return c.featureFlagRepo.GroupFlagGetOne(ctx, org.ID, args.groupID, app.ID, env.ID, args.flagID)
 }
 
 type groupFlagUpdateArgs struct {
-	orgSlug   string
-	groupID   int64
-	appSlug   string
-	flagID    int64
-	isEnabled bool
+	orgSlug           string
+	groupID           int64
+	appSlug           string
+	envSlug           string
+	flagID            int64
+	isEnabled         bool
+	rolloutPercentage *int
+	variant           *string
 }
 
 func (a *groupFlagUpdateArgs) Validate() error {
@@ -478,6 +984,9 @@ func (a *groupFlagUpdateArgs) Validate() error {
 	if a.flagID < 1 {
 		return errors.New("groupFlagUpdateArgs.flagID must be positive integer")
 	}
+	if !rolloutPercentageIsValid(a.rolloutPercentage) {
+		return errors.New("groupFlagUpdateArgs.rolloutPercentage must be between 0 and 100")
+	}
 	return nil
 }
 
@@ -485,15 +994,21 @@ func (c *Core) NewGroupFlagUpdateArgs(
 	orgSlug string,
 	groupID int64,
 	appSlug string,
+	envSlug string,
 	flagID int64,
 	isEnabled bool,
+	rolloutPercentage *int,
+	variant *string,
 ) groupFlagUpdateArgs {
 	return groupFlagUpdateArgs{
-		orgSlug:   orgSlug,
-		groupID:   groupID,
-		appSlug:   appSlug,
-		flagID:    flagID,
-		isEnabled: isEnabled,
+		orgSlug:           orgSlug,
+		groupID:           groupID,
+		appSlug:           appSlug,
+		envSlug:           envSlug,
+		flagID:            flagID,
+		isEnabled:         isEnabled,
+		rolloutPercentage: rolloutPercentage,
+		variant:           variant,
 	}
 }
 
@@ -510,28 +1025,399 @@ func (c *Core) GroupFlagUpdate(ctx context.Context, args groupFlagUpdateArgs) (*
 	var (
 		org *types.Organization
 		app *types.Application
+		env *types.Environment
 	)
 	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
 		return nil, err
 	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
 	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
 		return nil, err
 	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
 
-	return c.featureFlagRepo.GroupFlagUpdate(ctx,
-		org.ID,
-		args.groupID,
-		app.ID,
-		args.flagID,
-		args.isEnabled,
-		tracer.AuthAccount.ID,
+	var before, groupFlag *types.OrgGroupFeatureFlag
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.featureFlagRepo.GroupFlagGetOne(ctx,
+			org.ID,
+			args.groupID,
+			app.ID,
+			env.ID,
+			args.flagID,
+		); err != nil {
+			return err
+		}
+
+		if err = c.groupFlagVariantValidate(ctx, org.ID, app.ID, env.ID, args.flagID, args.variant); err != nil {
+			return err
+		}
+
+		if groupFlag, err = c.featureFlagRepo.GroupFlagUpdate(ctx,
+			org.ID,
+			args.groupID,
+			app.ID,
+			env.ID,
+			args.flagID,
+			args.isEnabled,
+			args.rolloutPercentage,
+			args.variant,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		if err = c.flagEventPublish(ctx,
+			org.ID,
+			app.ID,
+			&env.ID,
+			types.FlagEventGroupFlagUpdated,
+			groupFlag.FlagID,
+			&groupFlag.GroupID,
+			groupFlag,
+		); err != nil {
+			return err
+		}
+
+		if err = c.featFlagVersionSnapshot(ctx, org.ID, app.ID, env.ID, groupFlag.FlagID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionUpdate,
+			types.AuditEntityGroupFlag,
+			groupFlag.GroupID,
+			before,
+			groupFlag,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return groupFlag, nil
+}
+
+type groupFlagUpsertArgs struct {
+	orgSlug           string
+	groupID           int64
+	appSlug           string
+	envSlug           string
+	flagID            int64
+	isEnabled         bool
+	rolloutPercentage *int
+	variant           *string
+}
+
+func (a *groupFlagUpsertArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("groupFlagUpsertArgs.orgSlug cannot be empty")
+	}
+	if a.groupID < 1 {
+		return errors.New("groupFlagUpsertArgs.groupID must be positive integer")
+	}
+	if a.appSlug == "" {
+		return errors.New("groupFlagUpsertArgs.appSlug cannot be empty")
+	}
+	if a.flagID < 1 {
+		return errors.New("groupFlagUpsertArgs.flagID must be positive integer")
+	}
+	if !rolloutPercentageIsValid(a.rolloutPercentage) {
+		return errors.New("groupFlagUpsertArgs.rolloutPercentage must be between 0 and 100")
+	}
+	return nil
+}
+
+func (c *Core) NewGroupFlagUpsertArgs(
+	orgSlug string,
+	groupID int64,
+	appSlug string,
+	envSlug string,
+	flagID int64,
+	isEnabled bool,
+	rolloutPercentage *int,
+	variant *string,
+) groupFlagUpsertArgs {
+	return groupFlagUpsertArgs{
+		orgSlug:           orgSlug,
+		groupID:           groupID,
+		appSlug:           appSlug,
+		envSlug:           envSlug,
+		flagID:            flagID,
+		isEnabled:         isEnabled,
+		rolloutPercentage: rolloutPercentage,
+		variant:           variant,
+	}
+}
+
+// GroupFlagUpsert creates a group's override of a flag, or updates the one the
+// group already has. The write is a single upsert, so concurrent calls for
+// the same group cannot fail on the override already existing.
+func (c *Core) GroupFlagUpsert(ctx context.Context, args groupFlagUpsertArgs) (*types.OrgGroupFeatureFlag, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org *types.Organization
+		app *types.Application
+		env *types.Environment
 	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
+
+	var before, groupFlag *types.OrgGroupFeatureFlag
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if _, err = c.featureFlagRepo.GetOne(ctx, org.ID, app.ID, env.ID, &args.flagID, nil, nil); err != nil {
+			return err
+		}
+		if _, err = c.orgGroupRepo.GetOne(ctx, org.ID, &args.groupID, nil); err != nil {
+			return err
+		}
+
+		if before, err = c.featureFlagRepo.GroupFlagGetOne(ctx,
+			org.ID,
+			args.groupID,
+			app.ID,
+			env.ID,
+			args.flagID,
+		); err != nil && !errors.Is(err, types.ErrNotFound) {
+			return err
+		}
+
+		if err = c.groupFlagVariantValidate(ctx, org.ID, app.ID, env.ID, args.flagID, args.variant); err != nil {
+			return err
+		}
+
+		if groupFlag, err = c.featureFlagRepo.GroupFlagUpsert(ctx,
+			org.ID,
+			args.groupID,
+			app.ID,
+			env.ID,
+			args.flagID,
+			args.isEnabled,
+			args.rolloutPercentage,
+			args.variant,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		eventType, action := types.FlagEventGroupFlagUpdated, types.AuditActionUpdate
+		if before == nil {
+			eventType, action = types.FlagEventGroupFlagCreated, types.AuditActionCreate
+		}
+
+		if err = c.flagEventPublish(ctx,
+			org.ID,
+			app.ID,
+			&env.ID,
+			eventType,
+			groupFlag.FlagID,
+			&groupFlag.GroupID,
+			groupFlag,
+		); err != nil {
+			return err
+		}
+
+		if err = c.featFlagVersionSnapshot(ctx, org.ID, app.ID, env.ID, groupFlag.FlagID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			action,
+			types.AuditEntityGroupFlag,
+			groupFlag.GroupID,
+			before,
+			groupFlag,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return groupFlag, nil
+}
+
+type groupFlagsSetArgs struct {
+	orgSlug    string
+	appSlug    string
+	envSlug    string
+	flagID     int64
+	groupFlags []types.GroupFlagOverride
+}
+
+func (a *groupFlagsSetArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("groupFlagsSetArgs.orgSlug cannot be empty")
+	}
+	if a.appSlug == "" {
+		return errors.New("groupFlagsSetArgs.appSlug cannot be empty")
+	}
+	if a.flagID < 1 {
+		return errors.New("groupFlagsSetArgs.flagID must be positive integer")
+	}
+
+	seen := make(map[int64]bool, len(a.groupFlags))
+	for _, groupFlag := range a.groupFlags {
+		if groupFlag.GroupID < 1 {
+			return errors.New("groupFlagsSetArgs.groupFlags groupId must be positive integer")
+		}
+		if seen[groupFlag.GroupID] {
+			return fmt.Errorf("groupFlagsSetArgs.groupFlags group %d is listed more than once", groupFlag.GroupID)
+		}
+		seen[groupFlag.GroupID] = true
+
+		if !rolloutPercentageIsValid(groupFlag.RolloutPercentage) {
+			return errors.New("groupFlagsSetArgs.groupFlags rolloutPercentage must be between 0 and 100")
+		}
+	}
+	return nil
+}
+
+func (c *Core) NewGroupFlagsSetArgs(
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	flagID int64,
+	groupFlags []types.GroupFlagOverride,
+) groupFlagsSetArgs {
+	return groupFlagsSetArgs{
+		orgSlug:    orgSlug,
+		appSlug:    appSlug,
+		envSlug:    envSlug,
+		flagID:     flagID,
+		groupFlags: groupFlags,
+	}
+}
+
+// GroupFlagsSet replaces every group override of a flag in an environment
+// with groupFlags, atomically. Overrides that already match are left alone,
+// the rest are upserted or deleted through the regular operations so each
+// change is audited, and the result is recorded as a single version.
+func (c *Core) GroupFlagsSet(ctx context.Context, args groupFlagsSetArgs) ([]types.OrgGroupFeatureFlag, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org        *types.Organization
+		app        *types.Application
+		env        *types.Environment
+		groupFlags []types.OrgGroupFeatureFlag
+		err        error
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
+
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		deferredCtx := context.WithValue(ctx, ctxFeatFlagVersionDeferred, true)
+
+		if _, err = c.featureFlagRepo.GetOne(ctx, org.ID, app.ID, env.ID, &args.flagID, nil, nil); err != nil {
+			return err
+		}
+
+		current, err := c.featureFlagRepo.GroupFlagsGetByFlagID(ctx, org.ID, app.ID, env.ID, args.flagID)
+		if err != nil {
+			return err
+		}
+
+		currentByGroupID := make(map[int64]types.OrgGroupFeatureFlag, len(current))
+		for _, groupFlag := range current {
+			currentByGroupID[groupFlag.GroupID] = groupFlag
+		}
+
+		changed := false
+		keep := make(map[int64]bool, len(args.groupFlags))
+		for _, groupFlag := range args.groupFlags {
+			keep[groupFlag.GroupID] = true
+
+			if existing, ok := currentByGroupID[groupFlag.GroupID]; ok &&
+				existing.IsEnabled == groupFlag.IsEnabled &&
+				ptrEqual(existing.RolloutPercentage, groupFlag.RolloutPercentage) &&
+				ptrEqual(existing.Variant, groupFlag.Variant) {
+				continue
+			}
+
+			if _, err = c.GroupFlagUpsert(deferredCtx, c.NewGroupFlagUpsertArgs(
+				org.Slug,
+				groupFlag.GroupID,
+				app.Slug,
+				env.Slug,
+				args.flagID,
+				groupFlag.IsEnabled,
+				groupFlag.RolloutPercentage,
+				groupFlag.Variant,
+			)); err != nil {
+				return err
+			}
+			changed = true
+		}
+
+		for _, groupFlag := range current {
+			if keep[groupFlag.GroupID] {
+				continue
+			}
+			if err = c.GroupFlagDelete(deferredCtx, c.NewGroupFlagDeleteArgs(
+				org.Slug,
+				groupFlag.GroupID,
+				app.Slug,
+				env.Slug,
+				args.flagID,
+			)); err != nil {
+				return err
+			}
+			changed = true
+		}
+
+		if changed {
+			if _, err = c.featFlagVersionCreate(ctx, org.ID, app.ID, env.ID, args.flagID, nil); err != nil {
+				return err
+			}
+		}
+
+		groupFlags, err = c.featureFlagRepo.GroupFlagsGetByFlagID(ctx, org.ID, app.ID, env.ID, args.flagID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return groupFlags, nil
 }
 
 type groupFlagDeleteArgs struct {
 	orgSlug string
 	groupID int64
 	appSlug string
+	envSlug string
 	flagID  int64
 }
 
@@ -555,12 +1441,14 @@ func (c *Core) NewGroupFlagDeleteArgs(
 	orgSlug string,
 	groupID int64,
 	appSlug string,
+	envSlug string,
 	flagID int64,
 ) groupFlagDeleteArgs {
 	return groupFlagDeleteArgs{
 		orgSlug: orgSlug,
 		groupID: groupID,
 		appSlug: appSlug,
+		envSlug: envSlug,
 		flagID:  flagID,
 	}
 }
@@ -571,21 +1459,317 @@ func (c *Core) GroupFlagDelete(ctx context.Context, args groupFlagDeleteArgs) er
 	}
 
 	var (
-		org *types.Organization
-		app *types.Application
-		err error
+		org       *types.Organization
+		app       *types.Application
+		env       *types.Environment
+		groupFlag *types.OrgGroupFeatureFlag
+		err       error
 	)
 	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
 		return err
 	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return err
+	}
 	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
 		return err
 	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return err
+	}
 
-	return c.featureFlagRepo.GroupFlagDelete(ctx,
-		org.ID,
-		args.groupID,
-		app.ID,
-		args.flagID,
-	)
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if groupFlag, err = c.featureFlagRepo.GroupFlagGetOne(ctx,
+			org.ID,
+			args.groupID,
+			app.ID,
+			env.ID,
+			args.flagID,
+		); err != nil {
+			return err
+		}
+
+		if err = c.featureFlagRepo.GroupFlagDelete(ctx,
+			org.ID,
+			args.groupID,
+			app.ID,
+			env.ID,
+			args.flagID,
+		); err != nil {
+			return err
+		}
+
+		if err = c.flagEventPublish(ctx,
+			org.ID,
+			app.ID,
+			&env.ID,
+			types.FlagEventGroupFlagDeleted,
+			groupFlag.FlagID,
+			&groupFlag.GroupID,
+			groupFlag,
+		); err != nil {
+			return err
+		}
+
+		if err = c.featFlagVersionSnapshot(ctx, org.ID, app.ID, env.ID, groupFlag.FlagID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionDelete,
+			types.AuditEntityGroupFlag,
+			groupFlag.GroupID,
+			groupFlag,
+			nil,
+		)
+	})
+}
+
+// A nil rollout percentage means the flag applies to every account
+func rolloutPercentageIsValid(rolloutPercentage *int) bool {
+	return rolloutPercentage == nil || (*rolloutPercentage >= 0 && *rolloutPercentage <= 100)
+}
+
+// featFlagSharedFieldsChanged reports whether an update changed any of the
+// fields shared by every environment of the flag
+func featFlagSharedFieldsChanged(before *types.FeatureFlag, after *types.FeatureFlag) bool {
+	return before.Name != after.Name ||
+		before.Label != after.Label ||
+		before.Description != after.Description ||
+		before.FlagType != after.FlagType ||
+		!jsonEqual(before.Variants, after.Variants) ||
+		!ptrEqual(before.DefaultVariant, after.DefaultVariant) ||
+		!slices.EqualFunc(before.Prerequisites, after.Prerequisites, func(a, b types.FeatureFlagPrerequisite) bool {
+			return a.FlagID == b.FlagID && ptrEqual(a.Variant, b.Variant)
+		})
+}
+
+func ptrEqual[T comparable](a *T, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Boolean flags have no variants. Every other type needs at least one, each
+// with a unique name and a value matching the flag type, and a default.
+func featFlagVariantsValidate(
+	flagType types.FeatureFlagType,
+	variants []types.FeatureFlagVariant,
+	defaultVariant *string,
+) error {
+	if !flagType.IsValid() {
+		return fmt.Errorf("invalid flagType '%s'", flagType)
+	}
+
+	if flagType == types.FeatureFlagTypeBoolean {
+		if len(variants) > 0 || defaultVariant != nil {
+			return errors.New("boolean flags cannot have variants")
+		}
+		return nil
+	}
+
+	if len(variants) == 0 {
+		return fmt.Errorf("%s flags must have at least one variant", flagType)
+	}
+
+	names := make(map[string]bool, len(variants))
+	for _, variant := range variants {
+		if variant.Name == "" {
+			return errors.New("variant name cannot be empty")
+		}
+		if names[variant.Name] {
+			return fmt.Errorf("duplicate variant '%s'", variant.Name)
+		}
+		if !flagType.ValueIsValid(variant.Value) {
+			return fmt.Errorf("variant '%s' value must be a valid %s", variant.Name, flagType)
+		}
+		names[variant.Name] = true
+	}
+
+	if defaultVariant == nil {
+		return errors.New("defaultVariant cannot be empty")
+	}
+	if !names[*defaultVariant] {
+		return fmt.Errorf("defaultVariant '%s' is not a variant", *defaultVariant)
+	}
+
+	return nil
+}
+
+// Rules are matched in order. A rule's variant must be one of the flag's
+// variants, and boolean flags cannot pin variants at all.
+func featFlagRulesValidate(
+	flagType types.FeatureFlagType,
+	variants []types.FeatureFlagVariant,
+	rules []types.TargetingRule,
+) error {
+	flag := types.FeatureFlag{FlagType: flagType, Variants: variants}
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		variant := rules[i].Variant
+		if variant == nil {
+			continue
+		}
+		if flagType == types.FeatureFlagTypeBoolean {
+			return fmt.Errorf("rule %d: boolean flags cannot have variants", i)
+		}
+		if flag.Variant(*variant) == nil {
+			return fmt.Errorf("rule %d: variant '%s' is not a variant", i, *variant)
+		}
+	}
+
+	return nil
+}
+
+// A flag cannot be its own prerequisite or list the same prerequisite twice
+func featFlagPrerequisitesValidate(flagID int64, prerequisites []types.FeatureFlagPrerequisite) error {
+	flagIDs := make(map[int64]bool, len(prerequisites))
+	for i, prerequisite := range prerequisites {
+		if prerequisite.FlagID < 1 {
+			return fmt.Errorf("prerequisite %d: flagId must be positive integer", i)
+		}
+		if prerequisite.FlagID == flagID {
+			return fmt.Errorf("prerequisite %d: flag cannot be its own prerequisite", i)
+		}
+		if flagIDs[prerequisite.FlagID] {
+			return fmt.Errorf("prerequisite %d: duplicate flag %d", i, prerequisite.FlagID)
+		}
+		if prerequisite.Variant != nil && *prerequisite.Variant == "" {
+			return fmt.Errorf("prerequisite %d: variant cannot be empty", i)
+		}
+		flagIDs[prerequisite.FlagID] = true
+	}
+
+	return nil
+}
+
+// featFlagPrerequisitesCheck ensures every prerequisite is a flag of the same
+// application that is not archived, that a required variant is one of that flag's variants and
+// that giving flagID these prerequisites does not form a cycle. flagID is 0
+// for a flag that does not exist yet, which nothing can depend on. It must be
+// called within WithTx.
+func (c *Core) featFlagPrerequisitesCheck(ctx context.Context,
+	orgID int64,
+	appID int64,
+	envID int64,
+	flagID int64,
+	prerequisites []types.FeatureFlagPrerequisite,
+) error {
+	if len(prerequisites) == 0 {
+		return nil
+	}
+
+	// Held until commit, so concurrent updates of the application's flags
+	// are checked one after the other and cannot add the two halves of a
+	// cycle. It is the lock every flag change takes for its flag event.
+	if err := c.flagEventRepo.Lock(ctx, appID); err != nil {
+		return err
+	}
+
+	flags, err := c.featureFlagRepo.GetMany(ctx, orgID, appID, envID)
+	if err != nil {
+		return err
+	}
+
+	flagsByID := make(map[int64]*types.FeatureFlag, len(flags))
+	for i := range flags {
+		flagsByID[flags[i].ID] = &flags[i]
+	}
+
+	for _, prerequisite := range prerequisites {
+		flag, ok := flagsByID[prerequisite.FlagID]
+		if !ok {
+			return fmt.Errorf("%w: prerequisite flag %d is not in the application",
+				types.ErrLinkedItemNotFound,
+				prerequisite.FlagID,
+			)
+		}
+		if flag.Lifecycle == types.FeatureFlagLifecycleArchived {
+			return fmt.Errorf("%w: prerequisite flag '%s' is archived", types.ErrInvalidState, flag.Name)
+		}
+		if prerequisite.Variant != nil && flag.Variant(*prerequisite.Variant) == nil {
+			return fmt.Errorf("%w: prerequisite flag '%s' has no variant '%s'",
+				types.ErrInvalidState,
+				flag.Name,
+				*prerequisite.Variant,
+			)
+		}
+	}
+
+	if flagID == 0 {
+		return nil
+	}
+
+	// Walk the prerequisites depth first, any path leading back to the flag
+	// is a cycle
+	visited := make(map[int64]bool)
+	var path []string
+	var leadsToFlag func(prerequisites []types.FeatureFlagPrerequisite) bool
+	leadsToFlag = func(prerequisites []types.FeatureFlagPrerequisite) bool {
+		for _, prerequisite := range prerequisites {
+			flag, ok := flagsByID[prerequisite.FlagID]
+			if !ok {
+				continue
+			}
+			if flag.ID == flagID {
+				path = append(path, flag.Name)
+				return true
+			}
+			if visited[flag.ID] {
+				continue
+			}
+			visited[flag.ID] = true
+
+			path = append(path, flag.Name)
+			if leadsToFlag(flag.Prerequisites) {
+				return true
+			}
+			path = path[:len(path)-1]
+		}
+		return false
+	}
+
+	if leadsToFlag(prerequisites) {
+		name := fmt.Sprint(flagID)
+		if flag, ok := flagsByID[flagID]; ok {
+			name = flag.Name
+		}
+		return fmt.Errorf("%w: prerequisites form a cycle: %s -> %s",
+			types.ErrInvalidState,
+			name,
+			strings.Join(path, " -> "),
+		)
+	}
+
+	return nil
+}
+
+// groupFlagVariantValidate ensures a variant pinned by a group override is one
+// of the flag's variants
+func (c *Core) groupFlagVariantValidate(ctx context.Context,
+	orgID int64,
+	appID int64,
+	envID int64,
+	flagID int64,
+	variant *string,
+) error {
+	if variant == nil {
+		return nil
+	}
+
+	flag, err := c.featureFlagRepo.GetOne(ctx, orgID, appID, envID, &flagID, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if flag.Variant(*variant) == nil {
+		return fmt.Errorf("%w: flag '%s' has no variant '%s'", types.ErrInvalidState, flag.Name, *variant)
+	}
+
+	return nil
 }
@@ -0,0 +1,658 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"switchcraft/types"
+	"time"
+)
+
+// featFlagEnv is one side of a diff or promotion
+type featFlagEnv struct {
+	app *types.Application
+	env *types.Environment
+}
+
+type featFlagDiffArgs struct {
+	orgSlug       string
+	sourceAppSlug string
+	sourceEnvSlug string
+	targetAppSlug string
+	targetEnvSlug string
+}
+
+func (a *featFlagDiffArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("featFlagDiffArgs.orgSlug cannot be empty")
+	}
+	if a.sourceAppSlug == "" {
+		return errors.New("featFlagDiffArgs.sourceAppSlug cannot be empty")
+	}
+	if a.targetAppSlug == "" {
+		return errors.New("featFlagDiffArgs.targetAppSlug cannot be empty")
+	}
+	return nil
+}
+
+func (c *Core) NewFeatFlagDiffArgs(
+	orgSlug string,
+	sourceAppSlug string,
+	sourceEnvSlug string,
+	targetAppSlug string,
+	targetEnvSlug string,
+) featFlagDiffArgs {
+	return featFlagDiffArgs{
+		orgSlug:       orgSlug,
+		sourceAppSlug: sourceAppSlug,
+		sourceEnvSlug: sourceEnvSlug,
+		targetAppSlug: targetAppSlug,
+		targetEnvSlug: targetEnvSlug,
+	}
+}
+
+// FeatFlagDiff compares every flag and group override of two environments,
+// in the same or different applications of an org
+func (c *Core) FeatFlagDiff(ctx context.Context, args featFlagDiffArgs) (*types.FeatureFlagSetDiff, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	source, target, err := c.featFlagEnvPairGet(ctx, org,
+		args.sourceAppSlug,
+		args.sourceEnvSlug,
+		args.targetAppSlug,
+		args.targetEnvSlug,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	flags, err := c.featFlagSetDiff(ctx, org.ID, source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.FeatureFlagSetDiff{
+		SourceAppSlug: source.app.Slug,
+		SourceEnvSlug: source.env.Slug,
+		TargetAppSlug: target.app.Slug,
+		TargetEnvSlug: target.env.Slug,
+		Flags:         flags,
+	}, nil
+}
+
+type featFlagPromoteArgs struct {
+	featFlagDiffArgs
+	flagNames []string
+	dryRun    bool
+}
+
+func (a *featFlagPromoteArgs) Validate() error {
+	if err := a.featFlagDiffArgs.Validate(); err != nil {
+		return err
+	}
+	if len(a.flagNames) == 0 {
+		return errors.New("featFlagPromoteArgs.flagNames cannot be empty")
+	}
+	return nil
+}
+
+func (c *Core) NewFeatFlagPromoteArgs(
+	orgSlug string,
+	sourceAppSlug string,
+	sourceEnvSlug string,
+	targetAppSlug string,
+	targetEnvSlug string,
+	flagNames []string,
+	dryRun bool,
+) featFlagPromoteArgs {
+	return featFlagPromoteArgs{
+		featFlagDiffArgs: c.NewFeatFlagDiffArgs(
+			orgSlug,
+			sourceAppSlug,
+			sourceEnvSlug,
+			targetAppSlug,
+			targetEnvSlug,
+		),
+		flagNames: flagNames,
+		dryRun:    dryRun,
+	}
+}
+
+// FeatFlagPromote copies the selected flags and their group overrides from the
+// source environment to the target in a single transaction. Flags missing from
+// the target application are created, target overrides for groups without one
+// in the source are removed. Flags only in the target are left alone.
+// Prerequisites are matched by name and must already be in the target or be
+// promoted along with the flag.
+//
+// Changes are applied through the regular flag and group flag operations so
+// each is audited, and every promoted flag gets one new version. A dry run
+// returns the same result without changing anything.
+func (c *Core) FeatFlagPromote(ctx context.Context, args featFlagPromoteArgs) (*types.FeatureFlagPromotion, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug))
+	if err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
+
+	source, target, err := c.featFlagEnvPairGet(ctx, org,
+		args.sourceAppSlug,
+		args.sourceEnvSlug,
+		args.targetAppSlug,
+		args.targetEnvSlug,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	promotion := &types.FeatureFlagPromotion{
+		FeatureFlagSetDiff: types.FeatureFlagSetDiff{
+			SourceAppSlug: source.app.Slug,
+			SourceEnvSlug: source.env.Slug,
+			TargetAppSlug: target.app.Slug,
+			TargetEnvSlug: target.env.Slug,
+		},
+		DryRun: args.dryRun,
+	}
+
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		ctx = context.WithValue(ctx, ctxFeatFlagVersionDeferred, true)
+
+		diffs, err := c.featFlagSetDiff(ctx, org.ID, source, target)
+		if err != nil {
+			return err
+		}
+
+		diffsByName := make(map[string]types.FeatureFlagDiff, len(diffs))
+		for _, diff := range diffs {
+			diffsByName[diff.Name] = diff
+		}
+
+		promotion.Flags = make([]types.FeatureFlagDiff, 0, len(args.flagNames))
+		for _, name := range args.flagNames {
+			diff, ok := diffsByName[name]
+			if !ok || diff.Source == nil {
+				return fmt.Errorf("core.FeatFlagPromote flag '%s' not in source: %w", name, types.ErrNotFound)
+			}
+			if slices.ContainsFunc(promotion.Flags, func(f types.FeatureFlagDiff) bool { return f.Name == name }) {
+				continue
+			}
+			promotion.Flags = append(promotion.Flags, diff)
+		}
+
+		if args.dryRun {
+			return nil
+		}
+
+		sourceFlags, err := c.featureFlagRepo.GetMany(ctx, org.ID, source.app.ID, source.env.ID)
+		if err != nil {
+			return err
+		}
+		sourceNames := featFlagNamesByID(sourceFlags)
+
+		for _, diff := range featFlagPromoteOrder(promotion.Flags, sourceNames) {
+			if diff.Status == types.FlagDiffStatusUnchanged {
+				continue
+			}
+			if err = c.featFlagPromoteOne(ctx, org, target, diff, sourceNames); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// featFlagPromoteOne applies a single flag diff to the target. It must run
+// within a WithTx with version snapshots deferred.
+func (c *Core) featFlagPromoteOne(ctx context.Context,
+	org *types.Organization,
+	target featFlagEnv,
+	diff types.FeatureFlagDiff,
+	sourceNames map[int64]string,
+) error {
+	var (
+		source = diff.Source
+		flag   *types.FeatureFlag
+		err    error
+	)
+
+	// Prerequisites point at source flags, find the target flags by name
+	targetFlags, err := c.featureFlagRepo.GetMany(ctx, org.ID, target.app.ID, target.env.ID)
+	if err != nil {
+		return err
+	}
+	targetIDs := make(map[string]int64, len(targetFlags))
+	for _, targetFlag := range targetFlags {
+		targetIDs[targetFlag.Name] = targetFlag.ID
+	}
+	prerequisites := make([]types.FeatureFlagPrerequisite, len(source.Prerequisites))
+	for i, prerequisite := range source.Prerequisites {
+		name := sourceNames[prerequisite.FlagID]
+		targetID, ok := targetIDs[name]
+		if !ok {
+			return fmt.Errorf("core.featFlagPromoteOne prerequisite '%s' of flag '%s' not in target: %w",
+				name,
+				source.Name,
+				types.ErrLinkedItemNotFound,
+			)
+		}
+		prerequisites[i] = types.FeatureFlagPrerequisite{FlagID: targetID, Variant: prerequisite.Variant}
+	}
+
+	if diff.Target == nil {
+		if flag, err = c.FeatFlagCreate(ctx, c.NewFeatFlagCreateArgs(
+			org.Slug,
+			target.app.Slug,
+			target.env.Slug,
+			source.Name,
+			source.Label,
+			source.Description,
+			source.IsEnabled,
+			source.RolloutPercentage,
+			source.FlagType,
+			source.Variants,
+			source.DefaultVariant,
+			source.Rules,
+			prerequisites,
+			source.ExpiresAt,
+		)); err != nil {
+			return err
+		}
+	} else {
+		flag = diff.Target
+		if len(diff.Fields) > 0 {
+			if flag, err = c.FeatFlagUpdate(ctx, c.NewFeatFlagUpdateArgs(
+				org.Slug,
+				target.app.Slug,
+				target.env.Slug,
+				diff.Target.ID,
+				source.Name,
+				source.Label,
+				source.Description,
+				source.IsEnabled,
+				source.RolloutPercentage,
+				source.FlagType,
+				source.Variants,
+				source.DefaultVariant,
+				source.Rules,
+				prerequisites,
+				source.ExpiresAt,
+			)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, groupDiff := range diff.GroupFlags {
+		switch groupDiff.Status {
+		case types.FlagDiffStatusAdded:
+			_, err = c.GroupFlagCreate(ctx, c.NewGroupFlagCreateArgs(
+				org.Slug,
+				groupDiff.GroupID,
+				target.app.Slug,
+				target.env.Slug,
+				flag.ID,
+				groupDiff.Source.IsEnabled,
+				groupDiff.Source.RolloutPercentage,
+				groupDiff.Source.Variant,
+			))
+		case types.FlagDiffStatusChanged:
+			_, err = c.GroupFlagUpdate(ctx, c.NewGroupFlagUpdateArgs(
+				org.Slug,
+				groupDiff.GroupID,
+				target.app.Slug,
+				target.env.Slug,
+				flag.ID,
+				groupDiff.Source.IsEnabled,
+				groupDiff.Source.RolloutPercentage,
+				groupDiff.Source.Variant,
+			))
+		case types.FlagDiffStatusRemoved:
+			err = c.GroupFlagDelete(ctx, c.NewGroupFlagDeleteArgs(
+				org.Slug,
+				groupDiff.GroupID,
+				target.app.Slug,
+				target.env.Slug,
+				flag.ID,
+			))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if diff.Target != nil {
+		_, err = c.featFlagVersionCreate(ctx, org.ID, target.app.ID, target.env.ID, flag.ID, nil)
+		return err
+	}
+
+	// A new flag exists in every environment of the target application
+	appEnvs, err := c.environmentRepo.GetMany(ctx, org.ID, target.app.ID)
+	if err != nil {
+		return err
+	}
+	for _, appEnv := range appEnvs {
+		if _, err = c.featFlagVersionCreate(ctx, org.ID, target.app.ID, appEnv.ID, flag.ID, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// featFlagPromoteOrder moves flags after the promoted flags they have as
+// prerequisites so those exist in the target first
+func featFlagPromoteOrder(diffs []types.FeatureFlagDiff, sourceNames map[int64]string) []types.FeatureFlagDiff {
+	diffsByName := make(map[string]types.FeatureFlagDiff, len(diffs))
+	for _, diff := range diffs {
+		diffsByName[diff.Name] = diff
+	}
+
+	ordered := make([]types.FeatureFlagDiff, 0, len(diffs))
+	added := make(map[string]bool, len(diffs))
+	var add func(diff types.FeatureFlagDiff)
+	add = func(diff types.FeatureFlagDiff) {
+		if added[diff.Name] {
+			return
+		}
+		added[diff.Name] = true
+		for _, prerequisite := range diff.Source.Prerequisites {
+			if prerequisiteDiff, ok := diffsByName[sourceNames[prerequisite.FlagID]]; ok {
+				add(prerequisiteDiff)
+			}
+		}
+		ordered = append(ordered, diff)
+	}
+	for _, diff := range diffs {
+		add(diff)
+	}
+
+	return ordered
+}
+
+func timePtrEqual(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func featFlagNamesByID(flags []types.FeatureFlag) map[int64]string {
+	names := make(map[int64]string, len(flags))
+	for _, flag := range flags {
+		names[flag.ID] = flag.Name
+	}
+	return names
+}
+
+func (c *Core) featFlagEnvPairGet(ctx context.Context,
+	org *types.Organization,
+	sourceAppSlug string,
+	sourceEnvSlug string,
+	targetAppSlug string,
+	targetEnvSlug string,
+) (featFlagEnv, featFlagEnv, error) {
+	var (
+		source featFlagEnv
+		target featFlagEnv
+		err    error
+	)
+
+	if source.app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &sourceAppSlug)); err != nil {
+		return source, target, err
+	}
+	if source.env, err = c.appEnvGet(ctx, org.ID, source.app.ID, sourceEnvSlug); err != nil {
+		return source, target, err
+	}
+	if target.app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &targetAppSlug)); err != nil {
+		return source, target, err
+	}
+	if target.env, err = c.appEnvGet(ctx, org.ID, target.app.ID, targetEnvSlug); err != nil {
+		return source, target, err
+	}
+
+	if source.env.ID == target.env.ID {
+		return source, target, errors.New("core.featFlagEnvPairGet source and target cannot be the same environment")
+	}
+
+	return source, target, nil
+}
+
+// featFlagSetDiff loads both sides and diffs them, sorted by flag name
+func (c *Core) featFlagSetDiff(ctx context.Context,
+	orgID int64,
+	source featFlagEnv,
+	target featFlagEnv,
+) ([]types.FeatureFlagDiff, error) {
+	sourceFlags, err := c.featureFlagRepo.GetMany(ctx, orgID, source.app.ID, source.env.ID)
+	if err != nil {
+		return nil, err
+	}
+	sourceGroupFlags, err := c.featureFlagRepo.GroupFlagsGetMany(ctx, orgID, source.app.ID, source.env.ID)
+	if err != nil {
+		return nil, err
+	}
+	targetFlags, err := c.featureFlagRepo.GetMany(ctx, orgID, target.app.ID, target.env.ID)
+	if err != nil {
+		return nil, err
+	}
+	targetGroupFlags, err := c.featureFlagRepo.GroupFlagsGetMany(ctx, orgID, target.app.ID, target.env.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupFlagsByFlagID := func(groupFlags []types.OrgGroupFeatureFlag) map[int64][]types.OrgGroupFeatureFlag {
+		byFlagID := make(map[int64][]types.OrgGroupFeatureFlag)
+		for _, groupFlag := range groupFlags {
+			byFlagID[groupFlag.FlagID] = append(byFlagID[groupFlag.FlagID], groupFlag)
+		}
+		return byFlagID
+	}
+	sourceGroupFlagsByFlagID := groupFlagsByFlagID(sourceGroupFlags)
+	targetGroupFlagsByFlagID := groupFlagsByFlagID(targetGroupFlags)
+
+	targetFlagsByName := make(map[string]*types.FeatureFlag, len(targetFlags))
+	for i := range targetFlags {
+		targetFlagsByName[targetFlags[i].Name] = &targetFlags[i]
+	}
+	sourceNames := featFlagNamesByID(sourceFlags)
+	targetNames := featFlagNamesByID(targetFlags)
+
+	diffs := make([]types.FeatureFlagDiff, 0, len(sourceFlags))
+	for i := range sourceFlags {
+		sourceFlag := &sourceFlags[i]
+		targetFlag := targetFlagsByName[sourceFlag.Name]
+		delete(targetFlagsByName, sourceFlag.Name)
+
+		var targetFlagGroupFlags []types.OrgGroupFeatureFlag
+		if targetFlag != nil {
+			targetFlagGroupFlags = targetGroupFlagsByFlagID[targetFlag.ID]
+		}
+
+		diffs = append(diffs, featFlagDiff(
+			sourceFlag,
+			targetFlag,
+			sourceGroupFlagsByFlagID[sourceFlag.ID],
+			targetFlagGroupFlags,
+			sourceNames,
+			targetNames,
+		))
+	}
+	for _, targetFlag := range targetFlagsByName {
+		diffs = append(diffs, featFlagDiff(
+			nil,
+			targetFlag,
+			nil,
+			targetGroupFlagsByFlagID[targetFlag.ID],
+			sourceNames,
+			targetNames,
+		))
+	}
+
+	slices.SortFunc(diffs, func(a, b types.FeatureFlagDiff) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return diffs, nil
+}
+
+func featFlagDiff(
+	source *types.FeatureFlag,
+	target *types.FeatureFlag,
+	sourceGroupFlags []types.OrgGroupFeatureFlag,
+	targetGroupFlags []types.OrgGroupFeatureFlag,
+	sourceNames map[int64]string,
+	targetNames map[int64]string,
+) types.FeatureFlagDiff {
+	diff := types.FeatureFlagDiff{
+		Source:     source,
+		Target:     target,
+		Fields:     []string{},
+		GroupFlags: groupFlagsDiff(sourceGroupFlags, targetGroupFlags),
+	}
+
+	switch {
+	case target == nil:
+		diff.Name = source.Name
+		diff.Status = types.FlagDiffStatusAdded
+		return diff
+	case source == nil:
+		diff.Name = target.Name
+		diff.Status = types.FlagDiffStatusRemoved
+		return diff
+	}
+
+	diff.Name = source.Name
+	if source.Label != target.Label {
+		diff.Fields = append(diff.Fields, "label")
+	}
+	if source.Description != target.Description {
+		diff.Fields = append(diff.Fields, "description")
+	}
+	if source.FlagType != target.FlagType {
+		diff.Fields = append(diff.Fields, "flagType")
+	}
+	if !jsonEqual(source.Variants, target.Variants) {
+		diff.Fields = append(diff.Fields, "variants")
+	}
+	if !ptrEqual(source.DefaultVariant, target.DefaultVariant) {
+		diff.Fields = append(diff.Fields, "defaultVariant")
+	}
+	if source.IsEnabled != target.IsEnabled {
+		diff.Fields = append(diff.Fields, "isEnabled")
+	}
+	if !ptrEqual(source.RolloutPercentage, target.RolloutPercentage) {
+		diff.Fields = append(diff.Fields, "rolloutPercentage")
+	}
+	if !jsonEqual(source.Rules, target.Rules) {
+		diff.Fields = append(diff.Fields, "rules")
+	}
+	// Flag IDs differ between applications, compare prerequisites by name
+	if !slices.EqualFunc(source.Prerequisites, target.Prerequisites, func(a, b types.FeatureFlagPrerequisite) bool {
+		return sourceNames[a.FlagID] == targetNames[b.FlagID] && ptrEqual(a.Variant, b.Variant)
+	}) {
+		diff.Fields = append(diff.Fields, "prerequisites")
+	}
+	if !timePtrEqual(source.ExpiresAt, target.ExpiresAt) {
+		diff.Fields = append(diff.Fields, "expiresAt")
+	}
+
+	diff.Status = types.FlagDiffStatusUnchanged
+	if len(diff.Fields) > 0 || len(diff.GroupFlags) > 0 {
+		diff.Status = types.FlagDiffStatusChanged
+	}
+
+	return diff
+}
+
+// groupFlagsDiff matches overrides by group and only returns those that differ
+func groupFlagsDiff(source []types.OrgGroupFeatureFlag, target []types.OrgGroupFeatureFlag) []types.GroupFlagDiff {
+	targetByGroupID := make(map[int64]*types.OrgGroupFeatureFlag, len(target))
+	for i := range target {
+		targetByGroupID[target[i].GroupID] = &target[i]
+	}
+
+	diffs := []types.GroupFlagDiff{}
+	for i := range source {
+		sourceGroupFlag := &source[i]
+		targetGroupFlag, ok := targetByGroupID[sourceGroupFlag.GroupID]
+		delete(targetByGroupID, sourceGroupFlag.GroupID)
+
+		if !ok {
+			diffs = append(diffs, types.GroupFlagDiff{
+				GroupID: sourceGroupFlag.GroupID,
+				Status:  types.FlagDiffStatusAdded,
+				Source:  sourceGroupFlag,
+			})
+			continue
+		}
+
+		if sourceGroupFlag.IsEnabled == targetGroupFlag.IsEnabled &&
+			ptrEqual(sourceGroupFlag.RolloutPercentage, targetGroupFlag.RolloutPercentage) &&
+			ptrEqual(sourceGroupFlag.Variant, targetGroupFlag.Variant) {
+			continue
+		}
+		diffs = append(diffs, types.GroupFlagDiff{
+			GroupID: sourceGroupFlag.GroupID,
+			Status:  types.FlagDiffStatusChanged,
+			Source:  sourceGroupFlag,
+			Target:  targetGroupFlag,
+		})
+	}
+	for _, targetGroupFlag := range targetByGroupID {
+		diffs = append(diffs, types.GroupFlagDiff{
+			GroupID: targetGroupFlag.GroupID,
+			Status:  types.FlagDiffStatusRemoved,
+			Target:  targetGroupFlag,
+		})
+	}
+
+	slices.SortFunc(diffs, func(a, b types.GroupFlagDiff) int {
+		return cmp.Compare(a.GroupID, b.GroupID)
+	})
+
+	return diffs
+}
+
+// jsonEqual compares variants and rules by their JSON value, ignoring
+// formatting and key order, an empty list equals a nil one
+func jsonEqual[T any](a []T, b []T) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	aValue, aErr := jsonValue(a)
+	bValue, bErr := jsonValue(b)
+	return aErr == nil && bErr == nil && reflect.DeepEqual(aValue, bValue)
+}
+
+func jsonValue(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	err = json.Unmarshal(raw, &value)
+	return value, err
+}
@@ -0,0 +1,280 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"switchcraft/types"
+)
+
+type featFlagEvaluateArgs struct {
+	orgSlug    string
+	appSlug    string
+	envSlug    string
+	flagID     *int64
+	flagName   *string
+	accountID  int64
+	attributes map[string]any
+}
+
+func (a *featFlagEvaluateArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("featFlagEvaluateArgs.orgSlug cannot be empty")
+	}
+	if a.appSlug == "" {
+		return errors.New("featFlagEvaluateArgs.appSlug cannot be empty")
+	}
+	if a.flagID == nil && a.flagName == nil {
+		return errors.New("featFlagEvaluateArgs: must provide flagID or flagName")
+	}
+	if a.flagID != nil && *a.flagID < 1 {
+		return errors.New("featFlagEvaluateArgs.flagID must be positive integer")
+	}
+	if a.accountID < 1 {
+		return errors.New("featFlagEvaluateArgs.accountID must be positive integer")
+	}
+	return nil
+}
+
+func (c *Core) NewFeatFlagEvaluateArgs(
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	flagID *int64,
+	flagName *string,
+	accountID int64,
+	attributes map[string]any,
+) featFlagEvaluateArgs {
+	return featFlagEvaluateArgs{
+		orgSlug:    orgSlug,
+		appSlug:    appSlug,
+		envSlug:    envSlug,
+		flagID:     flagID,
+		flagName:   flagName,
+		accountID:  accountID,
+		attributes: attributes,
+	}
+}
+
+// FeatFlagEvaluate resolves a single feature flag for an org account,
+// applying any group overrides and targeting rules on top of the flag's
+// default state once its prerequisites are met. Attributes are only used by
+// targeting rules.
+func (c *Core) FeatFlagEvaluate(ctx context.Context, args featFlagEvaluateArgs) (*types.FeatureFlagEvaluation, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org        *types.Organization
+		app        *types.Application
+		env        *types.Environment
+		account    *types.Account
+		flag       *types.FeatureFlag
+		groupFlags []types.OrgGroupFeatureFlag
+		segments   map[int64]*types.OrgSegment
+		err        error
+	)
+
+	if ctx, err = c.authorizeSDKKey(ctx, types.SDKKeyTypeServer, types.SDKKeyTypeClient); err != nil {
+		return nil, err
+	}
+
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
+	if account, err = c.orgAccountRepo.GetOne(ctx, org.ID, &args.accountID, nil, nil); err != nil {
+		return nil, err
+	}
+	if flag, err = c.featureFlagRepo.GetOne(ctx,
+		org.ID,
+		app.ID,
+		env.ID,
+		args.flagID,
+		nil,
+		args.flagName,
+	); err != nil {
+		return nil, err
+	}
+
+	flags := []types.FeatureFlag{*flag}
+	if len(flag.Prerequisites) == 0 {
+		if groupFlags, err = c.featureFlagRepo.GroupFlagsGetByAccountID(ctx,
+			org.ID,
+			app.ID,
+			env.ID,
+			flag.ID,
+			account.ID,
+		); err != nil {
+			return nil, err
+		}
+	} else {
+		// Prerequisites can chain through any flag of the application
+		if _, flags, groupFlags, err = c.featureFlagRepo.GetManyForAccount(ctx,
+			org.Slug,
+			app.Slug,
+			&env.ID,
+			env.Slug,
+			account.ID,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if segments, err = c.orgSegmentsGetForFlags(ctx, org.ID, flags); err != nil {
+		return nil, err
+	}
+
+	evaluator := featFlagEvaluator(flags, groupFlags, types.EvaluationContext{
+		AccountID:   account.ID,
+		AccountUUID: account.UUID,
+		Attributes:  args.attributes,
+		Segments:    segments,
+	})
+	evaluation := evaluator.Evaluate(flag)
+	evaluation.AccountID = account.ID
+
+	return &evaluation, nil
+}
+
+type featFlagEvaluateManyArgs struct {
+	orgSlug    string
+	appSlug    string
+	envSlug    string
+	accountID  int64
+	attributes map[string]any
+}
+
+func (a *featFlagEvaluateManyArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("featFlagEvaluateManyArgs.orgSlug cannot be empty")
+	}
+	if a.appSlug == "" {
+		return errors.New("featFlagEvaluateManyArgs.appSlug cannot be empty")
+	}
+	if a.accountID < 1 {
+		return errors.New("featFlagEvaluateManyArgs.accountID must be positive integer")
+	}
+	return nil
+}
+
+func (c *Core) NewFeatFlagEvaluateManyArgs(
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	accountID int64,
+	attributes map[string]any,
+) featFlagEvaluateManyArgs {
+	return featFlagEvaluateManyArgs{
+		orgSlug:    orgSlug,
+		appSlug:    appSlug,
+		envSlug:    envSlug,
+		accountID:  accountID,
+		attributes: attributes,
+	}
+}
+
+// FeatFlagEvaluateMany resolves every flag in an application environment for
+// an org account, returning the variant and value of each alongside whether it
+// is enabled. This is the hot path for client applications, so the org, app,
+// environment, and account are resolved in the same query that loads every
+// flag and the account's group overrides, and authorized from its result.
+func (c *Core) FeatFlagEvaluateMany(ctx context.Context, args featFlagEvaluateManyArgs) ([]types.FeatureFlagEvaluation, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		scope      *types.FlagEvaluationScope
+		flags      []types.FeatureFlag
+		groupFlags []types.OrgGroupFeatureFlag
+	)
+
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx, err = c.authorizeSDKKey(ctx, types.SDKKeyTypeServer, types.SDKKeyTypeClient); err != nil {
+		return nil, err
+	}
+
+	// SDK keys default to their own environment, everyone else to the
+	// default environment
+	var (
+		envID   *int64
+		envSlug = args.envSlug
+	)
+	if envSlug == "" {
+		if tracer.SDKKey != nil {
+			envID = &tracer.SDKKey.EnvironmentID
+		} else {
+			envSlug = types.DefaultEnvironmentSlug
+		}
+	}
+
+	if scope, flags, groupFlags, err = c.featureFlagRepo.GetManyForAccount(ctx,
+		args.orgSlug,
+		args.appSlug,
+		envID,
+		envSlug,
+		args.accountID,
+	); err != nil {
+		return nil, err
+	}
+
+	if err = c.authorizeOrg(ctx, scope.OrgID); err != nil {
+		return nil, err
+	}
+	if err = sdkKeyAuthorizeScope(ctx, tracer, scope.OrgID, &scope.ApplicationID); err != nil {
+		return nil, err
+	}
+	if err = sdkKeyAuthorizeEnv(tracer, scope.EnvironmentID); err != nil {
+		return nil, err
+	}
+
+	evalCtx := types.EvaluationContext{
+		AccountID:   scope.AccountID,
+		AccountUUID: scope.AccountUUID,
+		Attributes:  args.attributes,
+	}
+	if evalCtx.Segments, err = c.orgSegmentsGetForFlags(ctx, scope.OrgID, flags); err != nil {
+		return nil, err
+	}
+
+	evaluator := featFlagEvaluator(flags, groupFlags, evalCtx)
+	evaluations := make([]types.FeatureFlagEvaluation, len(flags))
+	for i := range flags {
+		evaluations[i] = evaluator.Evaluate(&flags[i])
+		evaluations[i].AccountID = scope.AccountID
+	}
+
+	return evaluations, nil
+}
+
+// featFlagEvaluator evaluates the flags of an application environment for an
+// account, groupFlags must already be limited to the account's groups
+func featFlagEvaluator(
+	flags []types.FeatureFlag,
+	groupFlags []types.OrgGroupFeatureFlag,
+	evalCtx types.EvaluationContext,
+) *types.FeatureFlagEvaluator {
+	flagsByID := make(map[int64]*types.FeatureFlag, len(flags))
+	for i := range flags {
+		flagsByID[flags[i].ID] = &flags[i]
+	}
+
+	groupFlagsByFlagID := make(map[int64][]types.OrgGroupFeatureFlag)
+	for _, groupFlag := range groupFlags {
+		groupFlagsByFlagID[groupFlag.FlagID] = append(groupFlagsByFlagID[groupFlag.FlagID], groupFlag)
+	}
+
+	return types.NewFeatureFlagEvaluator(flagsByID, func(flagID int64) []types.OrgGroupFeatureFlag {
+		return groupFlagsByFlagID[flagID]
+	}, evalCtx)
+}
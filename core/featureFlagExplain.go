@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"switchcraft/types"
+)
+
+// FeatFlagExplain evaluates a flag for an org account like FeatFlagEvaluate
+// and returns the decision trace behind the result: the flag's own state, its
+// prerequisites, every group the account is a member of and any override
+// those groups have, and which targeting rules matched. It exposes other
+// groups' overrides so it is limited to org members, SDK keys are refused.
+func (c *Core) FeatFlagExplain(ctx context.Context, args featFlagEvaluateArgs) (*types.FeatureFlagExplanation, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org        *types.Organization
+		app        *types.Application
+		env        *types.Environment
+		account    *types.Account
+		flag       *types.FeatureFlag
+		flags      []types.FeatureFlag
+		groups     []types.OrgGroup
+		groupFlags []types.OrgGroupFeatureFlag
+		segments   map[int64]*types.OrgSegment
+		err        error
+	)
+
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleViewer); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
+	if account, err = c.orgAccountRepo.GetOne(ctx, org.ID, &args.accountID, nil, nil); err != nil {
+		return nil, err
+	}
+	if flag, err = c.featureFlagRepo.GetOne(ctx,
+		org.ID,
+		app.ID,
+		env.ID,
+		args.flagID,
+		nil,
+		args.flagName,
+	); err != nil {
+		return nil, err
+	}
+
+	// Prerequisites can chain through any flag of the application
+	if _, flags, groupFlags, err = c.featureFlagRepo.GetManyForAccount(ctx,
+		org.Slug,
+		app.Slug,
+		&env.ID,
+		env.Slug,
+		account.ID,
+	); err != nil {
+		return nil, err
+	}
+	if groups, err = c.orgGroupRepo.GetManyByAccountID(ctx, org.ID, account.ID); err != nil {
+		return nil, err
+	}
+	if segments, err = c.orgSegmentsGetForFlags(ctx, org.ID, flags); err != nil {
+		return nil, err
+	}
+
+	evaluator := featFlagEvaluator(flags, groupFlags, types.EvaluationContext{
+		AccountID:   account.ID,
+		AccountUUID: account.UUID,
+		Attributes:  args.attributes,
+		Segments:    segments,
+	})
+	explanation := evaluator.Explain(flag, groups)
+	explanation.Evaluation.AccountID = account.ID
+
+	return &explanation, nil
+}
@@ -0,0 +1,257 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"switchcraft/types"
+	"time"
+)
+
+// Flags unchanged for longer than this are reported as stale by default
+const featFlagStaleDefaultDays = 30
+
+// FeatFlagArchive retires a flag without deleting it. Archived flags keep
+// their group overrides and history but are disabled for every account and
+// cannot be changed until restored. Fails with ErrItemInUse while flags that
+// are not archived have it as a prerequisite.
+func (c *Core) FeatFlagArchive(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	id int64,
+) (*types.FeatureFlag, error) {
+	return c.featFlagLifecycleSet(ctx, orgSlug, appSlug, envSlug, id, types.FeatureFlagLifecycleArchived)
+}
+
+// FeatFlagRestore makes an archived or deprecated flag active again, it
+// evaluates exactly as it did before being archived
+func (c *Core) FeatFlagRestore(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	id int64,
+) (*types.FeatureFlag, error) {
+	return c.featFlagLifecycleSet(ctx, orgSlug, appSlug, envSlug, id, types.FeatureFlagLifecycleActive)
+}
+
+// FeatFlagDeprecate marks a flag for removal from code. Deprecated flags
+// evaluate as usual and are always listed by the stale flag report.
+func (c *Core) FeatFlagDeprecate(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	id int64,
+) (*types.FeatureFlag, error) {
+	return c.featFlagLifecycleSet(ctx, orgSlug, appSlug, envSlug, id, types.FeatureFlagLifecycleDeprecated)
+}
+
+func (c *Core) featFlagLifecycleSet(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	id int64,
+	lifecycle types.FeatureFlagLifecycle,
+) (*types.FeatureFlag, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgSlug == "" {
+		return nil, errors.New("core.featFlagLifecycleSet orgSlug cannot be empty")
+	}
+	if appSlug == "" {
+		return nil, errors.New("core.featFlagLifecycleSet appSlug cannot be empty")
+	}
+	if id < 1 {
+		return nil, errors.New("core.featFlagLifecycleSet id must be positive integer")
+	}
+
+	var (
+		org *types.Organization
+		app *types.Application
+		env *types.Environment
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, envSlug); err != nil {
+		return nil, err
+	}
+
+	var before, flag *types.FeatureFlag
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.featureFlagRepo.GetOne(ctx, org.ID, app.ID, env.ID, &id, nil, nil); err != nil {
+			return err
+		}
+		if before.Lifecycle == lifecycle {
+			flag = before
+			return nil
+		}
+
+		if lifecycle == types.FeatureFlagLifecycleArchived {
+			dependents, err := c.featFlagDependentsGet(ctx, org.ID, app.ID, env.ID, before.ID)
+			if err != nil {
+				return err
+			}
+
+			var flagNames []string
+			for _, dependent := range dependents {
+				if dependent.Lifecycle != types.FeatureFlagLifecycleArchived {
+					flagNames = append(flagNames, dependent.Name)
+				}
+			}
+			if len(flagNames) > 0 {
+				return fmt.Errorf("%w: flag '%s' is a prerequisite of flags %s",
+					types.ErrItemInUse,
+					before.Name,
+					strings.Join(flagNames, ", "),
+				)
+			}
+		}
+
+		if err = c.featureFlagRepo.LifecycleUpdate(ctx,
+			org.ID,
+			app.ID,
+			before.ID,
+			lifecycle,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		if flag, err = c.featureFlagRepo.GetOne(ctx, org.ID, app.ID, env.ID, &id, nil, nil); err != nil {
+			return err
+		}
+
+		if err = c.flagEventPublish(ctx,
+			org.ID,
+			app.ID,
+			nil,
+			types.FlagEventFlagUpdated,
+			flag.ID,
+			nil,
+			flag,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionUpdate,
+			types.AuditEntityFeatureFlag,
+			flag.ID,
+			before,
+			flag,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return flag, nil
+}
+
+type featFlagStaleReportArgs struct {
+	orgSlug   string
+	appSlug   string
+	staleDays int
+}
+
+func (a *featFlagStaleReportArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("featFlagStaleReportArgs.orgSlug cannot be empty")
+	}
+	if a.appSlug == "" {
+		return errors.New("featFlagStaleReportArgs.appSlug cannot be empty")
+	}
+	if a.staleDays < 1 {
+		return errors.New("featFlagStaleReportArgs.staleDays must be positive integer")
+	}
+	return nil
+}
+
+// NewFeatFlagStaleReportArgs defaults staleDays to 30 when 0
+func (c *Core) NewFeatFlagStaleReportArgs(
+	orgSlug string,
+	appSlug string,
+	staleDays int,
+) featFlagStaleReportArgs {
+	if staleDays == 0 {
+		staleDays = featFlagStaleDefaultDays
+	}
+
+	return featFlagStaleReportArgs{
+		orgSlug:   orgSlug,
+		appSlug:   appSlug,
+		staleDays: staleDays,
+	}
+}
+
+// FeatFlagStaleReport lists the flags of an application, across all of its
+// environments, that are candidates for removal: flags unchanged for
+// staleDays, flags serving the same state to everyone everywhere, flags past
+// their expiry and deprecated flags. Archived flags are left out.
+func (c *Core) FeatFlagStaleReport(ctx context.Context, args featFlagStaleReportArgs) (*types.FeatureFlagStaleReport, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org *types.Organization
+		app *types.Application
+		err error
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+
+	flags, err := c.featureFlagRepo.StaleGetMany(ctx, org.ID, app.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		now        = time.Now()
+		staleSince = now.AddDate(0, 0, -args.staleDays)
+		report     = &types.FeatureFlagStaleReport{
+			AppSlug:   app.Slug,
+			StaleDays: args.staleDays,
+			Flags:     []types.StaleFeatureFlag{},
+		}
+	)
+	for _, flag := range flags {
+		flag.Reasons = []types.StaleFlagReason{}
+		if flag.LastModified.Before(staleSince) {
+			flag.Reasons = append(flag.Reasons, types.StaleFlagReasonUnchanged)
+		}
+		if flag.FullyEnabled {
+			flag.Reasons = append(flag.Reasons, types.StaleFlagReasonFullyEnabled)
+		}
+		if flag.FullyDisabled {
+			flag.Reasons = append(flag.Reasons, types.StaleFlagReasonFullyDisabled)
+		}
+		if flag.ExpiresAt != nil && !flag.ExpiresAt.After(now) {
+			flag.Reasons = append(flag.Reasons, types.StaleFlagReasonExpired)
+		}
+		if flag.Lifecycle == types.FeatureFlagLifecycleDeprecated {
+			flag.Reasons = append(flag.Reasons, types.StaleFlagReasonDeprecated)
+		}
+
+		if len(flag.Reasons) > 0 {
+			report.Flags = append(report.Flags, flag)
+		}
+	}
+
+	return report, nil
+}
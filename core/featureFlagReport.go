@@ -0,0 +1,228 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"switchcraft/types"
+)
+
+type featFlagAccountsReportArgs struct {
+	orgSlug  string
+	appSlug  string
+	envSlug  string
+	flagID   *int64
+	flagName *string
+}
+
+func (a *featFlagAccountsReportArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("featFlagAccountsReportArgs.orgSlug cannot be empty")
+	}
+	if a.appSlug == "" {
+		return errors.New("featFlagAccountsReportArgs.appSlug cannot be empty")
+	}
+	if a.flagID == nil && a.flagName == nil {
+		return errors.New("featFlagAccountsReportArgs: must provide flagID or flagName")
+	}
+	if a.flagID != nil && *a.flagID < 1 {
+		return errors.New("featFlagAccountsReportArgs.flagID must be positive integer")
+	}
+	return nil
+}
+
+func (c *Core) NewFeatFlagAccountsReportArgs(
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	flagID *int64,
+	flagName *string,
+) featFlagAccountsReportArgs {
+	return featFlagAccountsReportArgs{
+		orgSlug:  orgSlug,
+		appSlug:  appSlug,
+		envSlug:  envSlug,
+		flagID:   flagID,
+		flagName: flagName,
+	}
+}
+
+// FeatFlagAccountsReport resolves a flag for every account in the org,
+// answering who currently has it enabled. Overrides are loaded for the whole
+// org at once rather than per account. There is no caller supplied context,
+// so targeting rules on attributes never match.
+func (c *Core) FeatFlagAccountsReport(ctx context.Context, args featFlagAccountsReportArgs) ([]types.FeatureFlagEvaluation, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org        *types.Organization
+		app        *types.Application
+		env        *types.Environment
+		flag       *types.FeatureFlag
+		flags      []types.FeatureFlag
+		accounts   []types.Account
+		groupFlags map[int64][]types.OrgGroupFeatureFlag
+		segments   map[int64]*types.OrgSegment
+		err        error
+	)
+
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleViewer); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
+	if flag, err = c.featureFlagRepo.GetOne(ctx,
+		org.ID,
+		app.ID,
+		env.ID,
+		args.flagID,
+		nil,
+		args.flagName,
+	); err != nil {
+		return nil, err
+	}
+
+	// Prerequisites can chain through any flag of the application, so
+	// overrides are needed for every flag when the flag has any
+	var groupFlagsFlagID *int64
+	if len(flag.Prerequisites) == 0 {
+		flags = []types.FeatureFlag{*flag}
+		groupFlagsFlagID = &flag.ID
+	} else if flags, err = c.featureFlagRepo.GetMany(ctx, org.ID, app.ID, env.ID); err != nil {
+		return nil, err
+	}
+
+	if accounts, err = c.orgAccountRepo.GetMany(ctx, org.ID); err != nil {
+		return nil, err
+	}
+	if groupFlags, err = c.featureFlagRepo.GroupFlagsGetManyByAccount(ctx,
+		org.ID,
+		app.ID,
+		env.ID,
+		groupFlagsFlagID,
+	); err != nil {
+		return nil, err
+	}
+	if segments, err = c.orgSegmentsGetForFlags(ctx, org.ID, flags); err != nil {
+		return nil, err
+	}
+
+	evaluations := make([]types.FeatureFlagEvaluation, 0, len(accounts))
+	for _, account := range accounts {
+		evaluator := featFlagEvaluator(flags, groupFlags[account.ID], types.EvaluationContext{
+			AccountID:   account.ID,
+			AccountUUID: account.UUID,
+			Segments:    segments,
+		})
+		evaluation := evaluator.Evaluate(flag)
+		evaluation.AccountID = account.ID
+		evaluations = append(evaluations, evaluation)
+	}
+
+	return evaluations, nil
+}
+
+type featFlagAccountFlagsReportArgs struct {
+	orgSlug   string
+	appSlug   string
+	envSlug   string
+	accountID int64
+}
+
+func (a *featFlagAccountFlagsReportArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("featFlagAccountFlagsReportArgs.orgSlug cannot be empty")
+	}
+	if a.appSlug == "" {
+		return errors.New("featFlagAccountFlagsReportArgs.appSlug cannot be empty")
+	}
+	if a.accountID < 1 {
+		return errors.New("featFlagAccountFlagsReportArgs.accountID must be positive integer")
+	}
+	return nil
+}
+
+func (c *Core) NewFeatFlagAccountFlagsReportArgs(
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	accountID int64,
+) featFlagAccountFlagsReportArgs {
+	return featFlagAccountFlagsReportArgs{
+		orgSlug:   orgSlug,
+		appSlug:   appSlug,
+		envSlug:   envSlug,
+		accountID: accountID,
+	}
+}
+
+// FeatFlagAccountFlagsReport resolves every flag of an application
+// environment for a single account, answering which flags are on for it. Like
+// FeatFlagAccountsReport, targeting rules on attributes never match.
+func (c *Core) FeatFlagAccountFlagsReport(ctx context.Context, args featFlagAccountFlagsReportArgs) ([]types.FeatureFlagEvaluation, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org        *types.Organization
+		app        *types.Application
+		env        *types.Environment
+		account    *types.Account
+		flags      []types.FeatureFlag
+		groupFlags []types.OrgGroupFeatureFlag
+		segments   map[int64]*types.OrgSegment
+		err        error
+	)
+
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleViewer); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
+	if account, err = c.orgAccountRepo.GetOne(ctx, org.ID, &args.accountID, nil, nil); err != nil {
+		return nil, err
+	}
+
+	if _, flags, groupFlags, err = c.featureFlagRepo.GetManyForAccount(ctx,
+		org.Slug,
+		app.Slug,
+		&env.ID,
+		env.Slug,
+		account.ID,
+	); err != nil {
+		return nil, err
+	}
+	if segments, err = c.orgSegmentsGetForFlags(ctx, org.ID, flags); err != nil {
+		return nil, err
+	}
+
+	evaluator := featFlagEvaluator(flags, groupFlags, types.EvaluationContext{
+		AccountID:   account.ID,
+		AccountUUID: account.UUID,
+		Segments:    segments,
+	})
+	evaluations := make([]types.FeatureFlagEvaluation, 0, len(flags))
+	for i := range flags {
+		evaluation := evaluator.Evaluate(&flags[i])
+		evaluation.AccountID = account.ID
+		evaluations = append(evaluations, evaluation)
+	}
+
+	return evaluations, nil
+}
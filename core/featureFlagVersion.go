@@ -0,0 +1,306 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"switchcraft/types"
+)
+
+type featFlagVersionCtxType int
+
+const (
+	_ featFlagVersionCtxType = iota
+	ctxFeatFlagVersionDeferred
+)
+
+// featFlagVersionSnapshot records the state of a flag after a change. It must
+// run within the same WithTx as the change. Operations made up of several
+// flag changes, like rollback, defer snapshots and record a single version
+// once they are done.
+func (c *Core) featFlagVersionSnapshot(ctx context.Context, orgID int64, appID int64, envID int64, flagID int64) error {
+	if deferred, _ := ctx.Value(ctxFeatFlagVersionDeferred).(bool); deferred {
+		return nil
+	}
+
+	_, err := c.featFlagVersionCreate(ctx, orgID, appID, envID, flagID, nil)
+	return err
+}
+
+func (c *Core) featFlagVersionCreate(ctx context.Context,
+	orgID int64,
+	appID int64,
+	envID int64,
+	flagID int64,
+	restoredFrom *int,
+) (*types.FeatureFlagVersion, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		flag       *types.FeatureFlag
+		groupFlags []types.OrgGroupFeatureFlag
+	)
+	if flag, err = c.featureFlagRepo.GetOne(ctx, orgID, appID, envID, &flagID, nil, nil); err != nil {
+		return nil, err
+	}
+	if groupFlags, err = c.featureFlagRepo.GroupFlagsGetByFlagID(ctx, orgID, appID, envID, flagID); err != nil {
+		return nil, err
+	}
+
+	versionGroupFlags := make([]types.FeatureFlagVersionGroupFlag, len(groupFlags))
+	for i, groupFlag := range groupFlags {
+		versionGroupFlags[i] = types.FeatureFlagVersionGroupFlag{
+			GroupID:           groupFlag.GroupID,
+			IsEnabled:         groupFlag.IsEnabled,
+			RolloutPercentage: groupFlag.RolloutPercentage,
+			Variant:           groupFlag.Variant,
+		}
+	}
+	slices.SortFunc(versionGroupFlags, func(a, b types.FeatureFlagVersionGroupFlag) int {
+		return cmp.Compare(a.GroupID, b.GroupID)
+	})
+
+	return c.featureFlagRepo.VersionCreate(ctx,
+		orgID,
+		appID,
+		envID,
+		flag.ID,
+		flag.Name,
+		flag.Label,
+		flag.Description,
+		flag.IsEnabled,
+		flag.RolloutPercentage,
+		flag.FlagType,
+		flag.Variants,
+		flag.DefaultVariant,
+		flag.Rules,
+		flag.Prerequisites,
+		versionGroupFlags,
+		restoredFrom,
+		tracer.AuthAccount.ID,
+	)
+}
+
+// FeatFlagVersionGetMany returns the version history of a flag in an
+// environment, newest first
+func (c *Core) FeatFlagVersionGetMany(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	flagID int64,
+) ([]types.FeatureFlagVersion, error) {
+	if flagID < 1 {
+		return nil, errors.New("core.FeatFlagVersionGetMany flagID must be positive integer")
+	}
+
+	var (
+		org  *types.Organization
+		app  *types.Application
+		env  *types.Environment
+		flag *types.FeatureFlag
+		err  error
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, envSlug); err != nil {
+		return nil, err
+	}
+	if flag, err = c.featureFlagRepo.GetOne(ctx, org.ID, app.ID, env.ID, &flagID, nil, nil); err != nil {
+		return nil, err
+	}
+
+	return c.featureFlagRepo.VersionsGetMany(ctx, org.ID, app.ID, env.ID, flag.ID)
+}
+
+type featFlagRollbackArgs struct {
+	orgSlug string
+	appSlug string
+	envSlug string
+	flagID  int64
+	version int
+}
+
+func (a *featFlagRollbackArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("featFlagRollbackArgs.orgSlug cannot be empty")
+	}
+	if a.appSlug == "" {
+		return errors.New("featFlagRollbackArgs.appSlug cannot be empty")
+	}
+	if a.flagID < 1 {
+		return errors.New("featFlagRollbackArgs.flagID must be positive integer")
+	}
+	if a.version < 1 {
+		return errors.New("featFlagRollbackArgs.version must be positive integer")
+	}
+	return nil
+}
+
+func (c *Core) NewFeatFlagRollbackArgs(
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	flagID int64,
+	version int,
+) featFlagRollbackArgs {
+	return featFlagRollbackArgs{
+		orgSlug: orgSlug,
+		appSlug: appSlug,
+		envSlug: envSlug,
+		flagID:  flagID,
+		version: version,
+	}
+}
+
+// FeatFlagRollback restores a flag and its group overrides in an environment
+// to a previous version. Changes are applied through the regular update operations so each
+// is audited, and the result is recorded as a new version. Restoring fields
+// shared by every environment also records a version in the other
+// environments. Overrides for groups that have since been deleted are skipped.
+func (c *Core) FeatFlagRollback(ctx context.Context, args featFlagRollbackArgs) (*types.FeatureFlagVersion, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org      *types.Organization
+		app      *types.Application
+		env      *types.Environment
+		restored *types.FeatureFlagVersion
+		err      error
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
+
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		ctx = context.WithValue(ctx, ctxFeatFlagVersionDeferred, true)
+
+		target, err := c.featureFlagRepo.VersionGetOne(ctx, org.ID, app.ID, env.ID, args.flagID, args.version)
+		if err != nil {
+			return err
+		}
+
+		// Expiry is not versioned, keep the flag's current one
+		flag, err := c.featureFlagRepo.GetOne(ctx, org.ID, app.ID, env.ID, &args.flagID, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		if _, err = c.FeatFlagUpdate(ctx, c.NewFeatFlagUpdateArgs(
+			args.orgSlug,
+			args.appSlug,
+			env.Slug,
+			args.flagID,
+			target.Name,
+			target.Label,
+			target.Description,
+			target.IsEnabled,
+			target.RolloutPercentage,
+			target.FlagType,
+			target.Variants,
+			target.DefaultVariant,
+			target.Rules,
+			target.Prerequisites,
+			flag.ExpiresAt,
+		)); err != nil {
+			return err
+		}
+
+		current, err := c.featureFlagRepo.GroupFlagsGetByFlagID(ctx, org.ID, app.ID, env.ID, args.flagID)
+		if err != nil {
+			return err
+		}
+
+		currentByGroupID := make(map[int64]types.OrgGroupFeatureFlag, len(current))
+		for _, groupFlag := range current {
+			currentByGroupID[groupFlag.GroupID] = groupFlag
+		}
+		targetByGroupID := make(map[int64]types.FeatureFlagVersionGroupFlag, len(target.GroupFlags))
+		for _, groupFlag := range target.GroupFlags {
+			targetByGroupID[groupFlag.GroupID] = groupFlag
+		}
+
+		for _, groupFlag := range target.GroupFlags {
+			existing, ok := currentByGroupID[groupFlag.GroupID]
+			if ok {
+				if existing.IsEnabled == groupFlag.IsEnabled &&
+					ptrEqual(existing.RolloutPercentage, groupFlag.RolloutPercentage) &&
+					ptrEqual(existing.Variant, groupFlag.Variant) {
+					continue
+				}
+				if _, err = c.GroupFlagUpdate(ctx, c.NewGroupFlagUpdateArgs(
+					args.orgSlug,
+					groupFlag.GroupID,
+					args.appSlug,
+					env.Slug,
+					args.flagID,
+					groupFlag.IsEnabled,
+					groupFlag.RolloutPercentage,
+					groupFlag.Variant,
+				)); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if _, err = c.orgGroupRepo.GetOne(ctx, org.ID, &groupFlag.GroupID, nil); errors.Is(err, types.ErrNotFound) {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			if _, err = c.GroupFlagCreate(ctx, c.NewGroupFlagCreateArgs(
+				args.orgSlug,
+				groupFlag.GroupID,
+				args.appSlug,
+				env.Slug,
+				args.flagID,
+				groupFlag.IsEnabled,
+				groupFlag.RolloutPercentage,
+				groupFlag.Variant,
+			)); err != nil {
+				return err
+			}
+		}
+
+		for _, groupFlag := range current {
+			if _, ok := targetByGroupID[groupFlag.GroupID]; ok {
+				continue
+			}
+			if err = c.GroupFlagDelete(ctx, c.NewGroupFlagDeleteArgs(
+				args.orgSlug,
+				groupFlag.GroupID,
+				args.appSlug,
+				env.Slug,
+				args.flagID,
+			)); err != nil {
+				return err
+			}
+		}
+
+		restored, err = c.featFlagVersionCreate(ctx, org.ID, app.ID, env.ID, args.flagID, &target.Version)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
@@ -0,0 +1,301 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"switchcraft/types"
+	"sync"
+	"time"
+)
+
+// Max number of missed events replayed to a reconnecting subscriber
+const flagEventReplayLimit = 1000
+
+// Buffered events per subscriber before it is considered too slow and dropped
+const flagEventSubscriberBuffer = 64
+
+const flagEventListenRetryInterval = 5 * time.Second
+
+// flagEventBroker fans flag events received from Postgres out to the
+// subscribers of each application on this instance.
+type flagEventBroker struct {
+	mu          sync.Mutex
+	subscribers map[int64]map[chan types.FlagEvent]struct{}
+	listenOnce  sync.Once
+}
+
+func newFlagEventBroker() *flagEventBroker {
+	return &flagEventBroker{
+		subscribers: make(map[int64]map[chan types.FlagEvent]struct{}),
+	}
+}
+
+func (b *flagEventBroker) subscribe(appID int64) chan types.FlagEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan types.FlagEvent, flagEventSubscriberBuffer)
+	if b.subscribers[appID] == nil {
+		b.subscribers[appID] = make(map[chan types.FlagEvent]struct{})
+	}
+	b.subscribers[appID][ch] = struct{}{}
+
+	return ch
+}
+
+func (b *flagEventBroker) unsubscribe(appID int64, ch chan types.FlagEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[appID][ch]; !ok {
+		return
+	}
+	delete(b.subscribers[appID], ch)
+	if len(b.subscribers[appID]) == 0 {
+		delete(b.subscribers, appID)
+	}
+	close(ch)
+}
+
+func (b *flagEventBroker) hasSubscribers(appID int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subscribers[appID]) > 0
+}
+
+// publish never blocks. Subscribers that cannot keep up are dropped and are
+// expected to reconnect and catch up using their last event ID.
+func (b *flagEventBroker) publish(event types.FlagEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[event.ApplicationID] {
+		select {
+		case ch <- event:
+		default:
+			delete(b.subscribers[event.ApplicationID], ch)
+			close(ch)
+		}
+	}
+}
+
+// closeAll drops every subscriber, used when events may have been missed
+func (b *flagEventBroker) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for appID, subscribers := range b.subscribers {
+		for ch := range subscribers {
+			close(ch)
+		}
+		delete(b.subscribers, appID)
+	}
+}
+
+// flagEventPublish records a flag event for streaming subscribers and queues
+// it for the org's webhooks. It must be called within the same WithTx as the
+// change, subscribers are only notified once the transaction commits. A nil
+// envID publishes to every environment.
+func (c *Core) flagEventPublish(ctx context.Context,
+	orgID int64,
+	appID int64,
+	envID *int64,
+	eventType types.FlagEventType,
+	flagID int64,
+	groupID *int64,
+	payload any,
+) error {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return err
+	}
+
+	event, err := c.flagEventRepo.Create(ctx,
+		orgID,
+		appID,
+		envID,
+		eventType,
+		flagID,
+		groupID,
+		payload,
+		tracer.AuthAccount.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.webhookEnqueue(ctx, orgID, types.WebhookEventType(eventType), event)
+}
+
+// flagEventListen runs for the lifetime of the process once the first
+// subscriber connects, relaying notifications from every instance
+func (c *Core) flagEventListen() {
+	ctx := types.NewOperationCtx(context.Background(), "", time.Now(), types.Account{})
+	tracer, _ := c.getOperationTracer(ctx)
+
+	for {
+		err := c.flagEventRepo.Listen(ctx, func(payload string) {
+			var notification types.FlagEventNotification
+			if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+				c.logger.Error(tracer, "core.flagEventListen invalid notification payload", payload)
+				return
+			}
+
+			if !c.flagEvents.hasSubscribers(notification.ApplicationID) {
+				return
+			}
+
+			event, err := c.flagEventRepo.GetOne(ctx, notification.ID)
+			if err != nil {
+				c.logger.Error(tracer, "core.flagEventListen error loading flag event", map[string]any{
+					"id":    notification.ID,
+					"error": err.Error(),
+				})
+				return
+			}
+
+			c.flagEvents.publish(*event)
+		})
+
+		c.logger.Error(tracer, "core.flagEventListen listener stopped, retrying", map[string]any{
+			"error": err.Error(),
+		})
+
+		// Anything published while disconnected was missed, force subscribers
+		// to reconnect and replay from their last event ID
+		c.flagEvents.closeAll()
+		time.Sleep(flagEventListenRetryInterval)
+	}
+}
+
+type flagEventSubscribeArgs struct {
+	orgSlug     string
+	appSlug     string
+	envSlug     string
+	lastEventID int64
+}
+
+func (a *flagEventSubscribeArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("flagEventSubscribeArgs.orgSlug cannot be empty")
+	}
+	if a.appSlug == "" {
+		return errors.New("flagEventSubscribeArgs.appSlug cannot be empty")
+	}
+	if a.lastEventID < 0 {
+		return errors.New("flagEventSubscribeArgs.lastEventID cannot be negative")
+	}
+	return nil
+}
+
+func (c *Core) NewFlagEventSubscribeArgs(
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	lastEventID int64,
+) flagEventSubscribeArgs {
+	return flagEventSubscribeArgs{
+		orgSlug:     orgSlug,
+		appSlug:     appSlug,
+		envSlug:     envSlug,
+		lastEventID: lastEventID,
+	}
+}
+
+// FlagEventSubscribe streams flag events for an application environment until
+// ctx is cancelled. When lastEventID is provided, events after it are replayed
+// first. The returned channel is closed if the subscriber falls behind or
+// the listener reconnects, callers should resubscribe with the last ID seen.
+func (c *Core) FlagEventSubscribe(ctx context.Context, args flagEventSubscribeArgs) (<-chan types.FlagEvent, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org *types.Organization
+		app *types.Application
+		env *types.Environment
+		err error
+	)
+
+	if ctx, err = c.authorizeSDKKey(ctx, types.SDKKeyTypeServer); err != nil {
+		return nil, err
+	}
+
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
+
+	c.flagEvents.listenOnce.Do(func() {
+		go c.flagEventListen()
+	})
+
+	// Subscribe before loading the replay so nothing falls in between,
+	// duplicates are skipped by event ID below. IDs within an application
+	// are in commit order, see flagEventRepo.Create.
+	live := c.flagEvents.subscribe(app.ID)
+
+	var replay []types.FlagEvent
+	if args.lastEventID > 0 {
+		if replay, err = c.flagEventRepo.GetManyAfter(ctx,
+			org.ID,
+			app.ID,
+			env.ID,
+			args.lastEventID,
+			flagEventReplayLimit,
+		); err != nil {
+			c.flagEvents.unsubscribe(app.ID, live)
+			return nil, err
+		}
+	}
+
+	events := make(chan types.FlagEvent)
+	go func() {
+		defer close(events)
+		defer c.flagEvents.unsubscribe(app.ID, live)
+
+		lastID := args.lastEventID
+		send := func(event types.FlagEvent) bool {
+			if event.ID <= lastID {
+				return true
+			}
+			if event.EnvironmentID != nil && *event.EnvironmentID != env.ID {
+				return true
+			}
+			select {
+			case events <- event:
+				lastID = event.ID
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, event := range replay {
+			if !send(event) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case event, ok := <-live:
+				if !ok || !send(event) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,368 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"switchcraft/types"
+	"time"
+)
+
+// How often each instance checks for due schedules
+const flagScheduleInterval = 15 * time.Second
+
+// Max schedules executed per check, the rest wait for the next one
+const flagScheduleBatchSize = 100
+
+type flagScheduleCreateArgs struct {
+	orgSlug           string
+	appSlug           string
+	envSlug           string
+	flagID            int64
+	groupID           *int64
+	isEnabled         bool
+	rolloutPercentage *int
+	executeAt         time.Time
+}
+
+func (a *flagScheduleCreateArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("flagScheduleCreateArgs.orgSlug cannot be empty")
+	}
+	if a.appSlug == "" {
+		return errors.New("flagScheduleCreateArgs.appSlug cannot be empty")
+	}
+	if a.flagID < 1 {
+		return errors.New("flagScheduleCreateArgs.flagID must be positive integer")
+	}
+	if a.groupID != nil && *a.groupID < 1 {
+		return errors.New("flagScheduleCreateArgs.groupID must be positive integer")
+	}
+	if !rolloutPercentageIsValid(a.rolloutPercentage) {
+		return errors.New("flagScheduleCreateArgs.rolloutPercentage must be between 0 and 100")
+	}
+	if !a.executeAt.After(time.Now()) {
+		return errors.New("flagScheduleCreateArgs.executeAt must be in the future")
+	}
+	return nil
+}
+
+func (c *Core) NewFlagScheduleCreateArgs(
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	flagID int64,
+	groupID *int64,
+	isEnabled bool,
+	rolloutPercentage *int,
+	executeAt time.Time,
+) flagScheduleCreateArgs {
+	return flagScheduleCreateArgs{
+		orgSlug:           orgSlug,
+		appSlug:           appSlug,
+		envSlug:           envSlug,
+		flagID:            flagID,
+		groupID:           groupID,
+		isEnabled:         isEnabled,
+		rolloutPercentage: rolloutPercentage,
+		executeAt:         executeAt,
+	}
+}
+
+// FlagScheduleCreate schedules a flag, or a group's override of it, to be
+// enabled or disabled with a rollout percentage at a later time
+func (c *Core) FlagScheduleCreate(ctx context.Context, args flagScheduleCreateArgs) (*types.FlagSchedule, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org *types.Organization
+		app *types.Application
+		env *types.Environment
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
+
+	var schedule *types.FlagSchedule
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if _, err = c.featureFlagRepo.GetOne(ctx, org.ID, app.ID, env.ID, &args.flagID, nil, nil); err != nil {
+			return err
+		}
+		if args.groupID != nil {
+			if _, err = c.orgGroupRepo.GetOne(ctx, org.ID, args.groupID, nil); err != nil {
+				return err
+			}
+		}
+
+		if schedule, err = c.flagScheduleRepo.Create(ctx,
+			org.ID,
+			app.ID,
+			env.ID,
+			args.flagID,
+			args.groupID,
+			args.isEnabled,
+			args.rolloutPercentage,
+			args.executeAt,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionCreate,
+			types.AuditEntityFlagSchedule,
+			schedule.ID,
+			nil,
+			schedule,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// FlagScheduleGetMany returns every schedule of a flag in an environment,
+// including executed, failed and cancelled ones, in execution order
+func (c *Core) FlagScheduleGetMany(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	flagID int64,
+) ([]types.FlagSchedule, error) {
+	var (
+		org *types.Organization
+		app *types.Application
+		env *types.Environment
+		err error
+	)
+
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, envSlug); err != nil {
+		return nil, err
+	}
+
+	return c.flagScheduleRepo.GetMany(ctx, org.ID, app.ID, env.ID, flagID)
+}
+
+// FlagScheduleCancel cancels a pending schedule. Schedules that already ran
+// or were cancelled are not found.
+func (c *Core) FlagScheduleCancel(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	flagID int64,
+	id int64,
+) (*types.FlagSchedule, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		org *types.Organization
+		app *types.Application
+		env *types.Environment
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, envSlug); err != nil {
+		return nil, err
+	}
+
+	var before, schedule *types.FlagSchedule
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.flagScheduleRepo.GetOne(ctx, org.ID, app.ID, env.ID, flagID, id); err != nil {
+			return err
+		}
+
+		if schedule, err = c.flagScheduleRepo.Cancel(ctx,
+			org.ID,
+			app.ID,
+			env.ID,
+			flagID,
+			id,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionUpdate,
+			types.AuditEntityFlagSchedule,
+			schedule.ID,
+			before,
+			schedule,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// FlagScheduleRun executes due schedules until ctx is cancelled. Every
+// instance may run it, an advisory lock ensures only one executes schedules
+// at a time.
+func (c *Core) FlagScheduleRun(ctx context.Context) {
+	tracer := types.OperationTracer{TraceID: "flag-schedule", StartTime: time.Now()}
+
+	ticker := time.NewTicker(flagScheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.flagScheduleExecuteDue(ctx); err != nil {
+			c.logger.Error(tracer, "core.FlagScheduleRun error executing schedules", map[string]any{
+				"error": err.Error(),
+			})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// flagScheduleExecuteDue runs one batch of due schedules in a single
+// transaction holding the scheduler lock. Each schedule runs in its own
+// savepoint so a failing change is recorded as failed without affecting the
+// others.
+func (c *Core) flagScheduleExecuteDue(ctx context.Context) error {
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		locked, err := c.flagScheduleRepo.Lock(ctx)
+		if err != nil || !locked {
+			return err
+		}
+
+		due, err := c.flagScheduleRepo.GetManyDue(ctx, flagScheduleBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, schedule := range due {
+			var (
+				status     = types.FlagScheduleStatusExecuted
+				errMessage *string
+			)
+
+			if execErr := c.repository.WithSavepoint(ctx, func(ctx context.Context) error {
+				return c.flagScheduleExecute(ctx, schedule)
+			}); execErr != nil {
+				message := execErr.Error()
+				status = types.FlagScheduleStatusFailed
+				errMessage = &message
+			}
+
+			if _, err = c.flagScheduleRepo.SetStatus(ctx, schedule.ID, status, errMessage); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// flagScheduleExecute applies a schedule through the regular update
+// operations as the account that created it, so the change is authorized,
+// versioned and audited as theirs. A group override that no longer exists is
+// created.
+func (c *Core) flagScheduleExecute(ctx context.Context, schedule types.FlagSchedule) error {
+	account, err := c.globalAccountRepo.GetOne(ctx, &schedule.CreatedBy, nil, nil)
+	if err != nil {
+		return fmt.Errorf("core.flagScheduleExecute error loading account: %w", err)
+	}
+	ctx = types.NewOperationCtx(ctx, "", time.Now(), *account)
+
+	var (
+		org  *types.Organization
+		app  *types.Application
+		env  *types.Environment
+		flag *types.FeatureFlag
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(&schedule.OrgID, nil, nil)); err != nil {
+		return err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, &schedule.ApplicationID, nil, nil)); err != nil {
+		return err
+	}
+	if env, err = c.environmentRepo.GetOne(ctx, org.ID, app.ID, &schedule.EnvironmentID, nil, nil); err != nil {
+		return err
+	}
+	if flag, err = c.featureFlagRepo.GetOne(ctx, org.ID, app.ID, env.ID, &schedule.FlagID, nil, nil); err != nil {
+		return err
+	}
+
+	if schedule.GroupID == nil {
+		_, err = c.FeatFlagUpdate(ctx, c.NewFeatFlagUpdateArgs(
+			org.Slug,
+			app.Slug,
+			env.Slug,
+			flag.ID,
+			flag.Name,
+			flag.Label,
+			flag.Description,
+			schedule.IsEnabled,
+			schedule.RolloutPercentage,
+			flag.FlagType,
+			flag.Variants,
+			flag.DefaultVariant,
+			flag.Rules,
+			flag.Prerequisites,
+			flag.ExpiresAt,
+		))
+		return err
+	}
+
+	// Schedules do not change the variant of an existing override
+	var variant *string
+	groupFlag, err := c.featureFlagRepo.GroupFlagGetOne(ctx, org.ID, *schedule.GroupID, app.ID, env.ID, flag.ID)
+	if err == nil {
+		variant = groupFlag.Variant
+	} else if !errors.Is(err, types.ErrNotFound) {
+		return err
+	}
+
+	_, err = c.GroupFlagUpsert(ctx, c.NewGroupFlagUpsertArgs(
+		org.Slug,
+		*schedule.GroupID,
+		app.Slug,
+		env.Slug,
+		flag.ID,
+		schedule.IsEnabled,
+		schedule.RolloutPercentage,
+		variant,
+	))
+	return err
+}
@@ -55,6 +55,10 @@ func (c *Core) GlobalAccountCreate(ctx context.Context, args globalAccountCreate
 		return nil, err
 	}
 
+	if err = c.authorizeInstanceAdmin(ctx); err != nil {
+		return nil, err
+	}
+
 	if err := args.Validate(); err != nil {
 		return nil, err
 	}
@@ -64,18 +68,40 @@ func (c *Core) GlobalAccountCreate(ctx context.Context, args globalAccountCreate
 		return nil, err
 	}
 
-	return c.globalAccountRepo.Create(ctx,
-		args.isInstanceAdmin,
-		args.firstName,
-		args.lastName,
-		args.email,
-		args.username,
-		password,
-		tracer.AuthAccount.ID,
-	)
+	var account *types.Account
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if account, err = c.globalAccountRepo.Create(ctx,
+			args.isInstanceAdmin,
+			args.firstName,
+			args.lastName,
+			args.email,
+			args.username,
+			password,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			account.OrgID,
+			types.AuditActionCreate,
+			types.AuditEntityAccount,
+			account.ID,
+			nil,
+			account,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return account, nil
 }
 
 func (c *Core) GlobalAccountGetMany(ctx context.Context) ([]types.Account, error) {
+	if err := c.authorizeInstanceAdmin(ctx); err != nil {
+		return nil, err
+	}
+
 	return c.globalAccountRepo.GetMany(ctx)
 }
 
@@ -105,8 +131,25 @@ func (c *Core) GlobalAccountGetOne(ctx context.Context, args globalAccountGetOne
 		return nil, err
 	}
 
-	return c.globalAccountRepo.GetOne(ctx, args.id, args.uuid, args.username)
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
 
+	account, err := c.globalAccountRepo.GetOne(ctx, args.id, args.uuid, args.username)
+	if err != nil {
+		return nil, err
+	}
+
+	// Accounts may always look themselves up
+	if tracer.SDKKey == nil && account.ID == tracer.AuthAccount.ID {
+		return account, nil
+	}
+	if err = c.authorizeInstanceAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	return account, nil
 }
 
 type globalAccountUpdateArgs struct {
@@ -146,22 +189,69 @@ func (c *Core) GlobalAccountUpdate(ctx context.Context, args globalAccountUpdate
 		return nil, err
 	}
 
+	if err = c.authorizeInstanceAdmin(ctx); err != nil {
+		return nil, err
+	}
+
 	if err := args.Validate(); err != nil {
 		return nil, err
 	}
 
-	return c.globalAccountRepo.Update(ctx,
-		args.id,
-		args.isInstanceAdmin,
-		args.firstName,
-		args.lastName,
-		args.email,
-		args.username,
-		tracer.AuthAccount.ID,
-	)
+	var before, account *types.Account
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.globalAccountRepo.GetOne(ctx, &args.id, nil, nil); err != nil {
+			return err
+		}
 
+		if account, err = c.globalAccountRepo.Update(ctx,
+			args.id,
+			args.isInstanceAdmin,
+			args.firstName,
+			args.lastName,
+			args.email,
+			args.username,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			account.OrgID,
+			types.AuditActionUpdate,
+			types.AuditEntityAccount,
+			account.ID,
+			before,
+			account,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return account, nil
 }
 
 func (c *Core) GlobalAccountDelete(ctx context.Context, id int64) error {
-	return c.globalAccountRepo.Delete(ctx, id)
+	if err := c.authorizeInstanceAdmin(ctx); err != nil {
+		return err
+	}
+
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		account, err := c.globalAccountRepo.GetOne(ctx, &id, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		if err = c.globalAccountRepo.Delete(ctx, account.ID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			account.OrgID,
+			types.AuditActionDelete,
+			types.AuditEntityAccount,
+			account.ID,
+			account,
+			nil,
+		)
+	})
 }
@@ -0,0 +1,221 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"switchcraft/types"
+	"sync"
+	"time"
+)
+
+// How long a claimed job is locked to its worker. A job still running after
+// this is assumed lost with its worker and claimed again.
+const jobLock = 5 * time.Minute
+
+// How long an idle worker waits before checking for due jobs again
+const jobPollInterval = 2 * time.Second
+
+// Failed runs are retried after 30s, 1m, 2m and so on, never waiting more
+// than an hour between attempts
+const (
+	jobRetryBaseDelay = 30 * time.Second
+	jobRetryMaxDelay  = time.Hour
+)
+
+// Succeeded jobs are kept around this long for inspection, dead jobs are kept
+// until retried
+const (
+	jobRetention     = 7 * 24 * time.Hour
+	jobPurgeInterval = time.Hour
+)
+
+const jobGetManyMaxLimit = 1000
+
+// jobEnqueue queues a job, giving up after maxAttempts failed runs. Called
+// within WithTx, the job only runs once the change it belongs to commits.
+func (c *Core) jobEnqueue(ctx context.Context,
+	kind types.JobKind,
+	payload any,
+	maxAttempts int,
+) error {
+	_, err := c.jobRepo.Create(ctx, kind, payload, maxAttempts)
+	return err
+}
+
+// jobHandle runs a job by its kind. An error fails the run, the job is
+// retried as long as it has attempts left.
+func (c *Core) jobHandle(ctx context.Context, job *types.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	switch job.Kind {
+	case types.JobKindWebhookDeliver:
+		return c.webhookDeliver(ctx, job)
+	default:
+		return fmt.Errorf("unknown job kind '%s'", job.Kind)
+	}
+}
+
+// JobWorkerRun runs a pool of workers executing due jobs until ctx is
+// cancelled, and blocks until they have all stopped. Any number of instances
+// may run workers, each job is claimed by a single worker at a time.
+func (c *Core) JobWorkerRun(ctx context.Context, workers int) {
+	tracer := types.OperationTracer{TraceID: "job-worker", StartTime: time.Now()}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.jobWork(ctx, tracer)
+		}()
+	}
+
+	c.jobPurgeRun(ctx, tracer)
+	wg.Wait()
+}
+
+// jobWork runs due jobs back to back, polling while there are none
+func (c *Core) jobWork(ctx context.Context, tracer types.OperationTracer) {
+	for {
+		job, err := c.jobRepo.Claim(ctx, jobLock)
+		if err != nil && ctx.Err() == nil {
+			c.logger.Error(tracer, "core.jobWork error claiming job", map[string]any{
+				"error": err.Error(),
+			})
+		}
+
+		if job != nil {
+			c.jobRun(ctx, tracer, job)
+			continue
+		}
+
+		select {
+		case <-time.After(jobPollInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Core) jobRun(ctx context.Context, tracer types.OperationTracer, job *types.Job) {
+	var (
+		status     = types.JobStatusSucceeded
+		runAt      *time.Time
+		errMessage *string
+	)
+
+	jobCtx := types.NewOperationCtx(ctx, job.UUID, time.Now(), types.Account{})
+	if err := c.jobHandle(jobCtx, job); err != nil {
+		message := err.Error()
+		errMessage = &message
+
+		if job.Attempts < job.MaxAttempts {
+			next := time.Now().Add(jobRetryDelay(job.Attempts))
+			status = types.JobStatusPending
+			runAt = &next
+		} else {
+			status = types.JobStatusDead
+			c.logger.Warn(tracer, "core.jobRun job is dead after its last attempt", map[string]any{
+				"jobID": job.ID,
+				"kind":  job.Kind,
+				"error": message,
+			})
+		}
+	}
+
+	// Recorded even when the worker is shutting down, otherwise the job stays
+	// running until its lock expires. Not found means the lock ran out and the
+	// job was claimed again, the newer attempt owns the result.
+	_, err := c.jobRepo.ResultSet(context.WithoutCancel(ctx), job.ID, job.Attempts, status, runAt, errMessage)
+	if errors.Is(err, types.ErrNotFound) {
+		c.logger.Warn(tracer, "core.jobRun lost the job before recording its result", map[string]any{
+			"jobID":   job.ID,
+			"attempt": job.Attempts,
+		})
+	} else if err != nil {
+		c.logger.Error(tracer, "core.jobRun error recording result", map[string]any{
+			"jobID": job.ID,
+			"error": err.Error(),
+		})
+	}
+}
+
+// jobRetryDelay is the wait after the given failed attempt
+func jobRetryDelay(attempts int) time.Duration {
+	delay := jobRetryBaseDelay
+	for i := 1; i < attempts && delay < jobRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, jobRetryMaxDelay)
+}
+
+func (c *Core) jobPurgeRun(ctx context.Context, tracer types.OperationTracer) {
+	ticker := time.NewTicker(jobPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.jobRepo.Purge(ctx, time.Now().Add(-jobRetention)); err != nil && ctx.Err() == nil {
+			c.logger.Error(tracer, "core.jobPurgeRun error purging jobs", map[string]any{
+				"error": err.Error(),
+			})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// JobGetMany returns the latest jobs, newest first, optionally only those
+// with the given status
+func (c *Core) JobGetMany(ctx context.Context, status *types.JobStatus, limit int) ([]types.Job, error) {
+	if status != nil && !status.IsValid() {
+		return nil, fmt.Errorf("core.JobGetMany invalid status '%s'", *status)
+	}
+	if limit < 1 || limit > jobGetManyMaxLimit {
+		return nil, fmt.Errorf("core.JobGetMany limit must be between 1 and %d", jobGetManyMaxLimit)
+	}
+
+	if err := c.authorizeInstanceAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.jobRepo.GetMany(ctx, status, limit)
+}
+
+func (c *Core) JobGetOne(ctx context.Context, id int64) (*types.Job, error) {
+	if id < 1 {
+		return nil, errors.New("core.JobGetOne id must be positive integer")
+	}
+
+	if err := c.authorizeInstanceAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.jobRepo.GetOne(ctx, id)
+}
+
+// JobRetry queues a dead job to run again with a fresh set of attempts
+func (c *Core) JobRetry(ctx context.Context, id int64) (*types.Job, error) {
+	job, err := c.JobGetOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status != types.JobStatusDead {
+		return nil, fmt.Errorf("%w: only dead jobs can be retried, job %d is %s",
+			types.ErrInvalidState,
+			job.ID,
+			job.Status,
+		)
+	}
+
+	return c.jobRepo.Retry(ctx, job.ID)
+}
@@ -41,7 +41,35 @@ func (c *Core) OrgCreate(ctx context.Context, args orgCreateArgs) (*types.Organi
 		return nil, err
 	}
 
-	return c.orgRepo.Create(ctx, args.name, args.slug, args.owner, tracer.AuthAccount.ID)
+	if tracer.SDKKey != nil || tracer.AuthAccount.ID == 0 {
+		return nil, types.ErrOperationNotPermitted
+	}
+
+	// Accounts without an org may create their first org during signup, as
+	// long as they will own it
+	if tracer.AuthAccount.OrgID != nil || tracer.AuthAccount.ID != args.owner {
+		if err = c.authorizeInstanceAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var org *types.Organization
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if org, err = c.orgRepo.Create(ctx,
+			args.name,
+			args.slug,
+			args.owner,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx, &org.ID, types.AuditActionCreate, types.AuditEntityOrg, org.ID, nil, org)
+	}); err != nil {
+		return nil, err
+	}
+
+	return org, nil
 }
 
 func (c *Core) OrgGetMany(ctx context.Context) ([]types.Organization, error) {
@@ -50,14 +78,23 @@ func (c *Core) OrgGetMany(ctx context.Context) ([]types.Organization, error) {
 		return nil, err
 	}
 
-	if tracer.AuthAccount.ID == 0 {
-		return nil, errors.New("error: invalid operation context authAccount")
+	if tracer.SDKKey != nil || tracer.AuthAccount.ID == 0 {
+		return nil, types.ErrOperationNotPermitted
 	}
+	if tracer.AuthAccount.IsInstanceAdmin {
+		return c.orgRepo.GetMany(ctx)
+	}
+
+	// Org accounts only see their own org
 	if tracer.AuthAccount.OrgID == nil {
-		c.logger.Warn(tracer, "core.OrgGetMany caught nil authAccount.OrgID", nil)
+		return []types.Organization{}, nil
+	}
+	org, err := c.orgRepo.GetOne(ctx, tracer.AuthAccount.OrgID, nil, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return c.orgRepo.GetMany(ctx)
+	return []types.Organization{*org}, nil
 }
 
 type orgGetOneArgs struct {
@@ -91,16 +128,16 @@ func (c *Core) OrgGetOne(ctx context.Context, args orgGetOneArgs) (*types.Organi
 		return nil, err
 	}
 
-	tracer, err := c.getOperationTracer(ctx)
+	org, err := c.orgRepo.GetOne(ctx, args.id, args.uuid, args.slug)
 	if err != nil {
 		return nil, err
 	}
 
-	if tracer.AuthAccount.ID == 0 {
-		return nil, errors.New("error: invalid operation context authAccount")
+	if err = c.authorizeOrg(ctx, org.ID); err != nil {
+		return nil, err
 	}
 
-	return c.orgRepo.GetOne(ctx, args.id, args.uuid, args.slug)
+	return org, nil
 }
 
 type orgUpdateArgs struct {
@@ -141,9 +178,56 @@ func (c *Core) OrgUpdate(ctx context.Context, args orgUpdateArgs) (*types.Organi
 		return nil, err
 	}
 
-	return c.orgRepo.Update(ctx, args.id, args.name, args.slug, args.owner, tracer.AuthAccount.ID)
+	if err = c.authorizeOrgRole(ctx, args.id, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	var before, org *types.Organization
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.orgRepo.GetOne(ctx, &args.id, nil, nil); err != nil {
+			return err
+		}
+
+		// Only owners may transfer ownership
+		if before.Owner != args.owner {
+			if err = c.authorizeOrgRole(ctx, before.ID, types.OrgRoleOwner); err != nil {
+				return err
+			}
+		}
+
+		if org, err = c.orgRepo.Update(ctx,
+			args.id,
+			args.name,
+			args.slug,
+			args.owner,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx, &org.ID, types.AuditActionUpdate, types.AuditEntityOrg, org.ID, before, org)
+	}); err != nil {
+		return nil, err
+	}
+
+	return org, nil
 }
 
 func (c *Core) OrgDelete(ctx context.Context, id int64) error {
-	return c.orgRepo.Delete(ctx, id)
+	if err := c.authorizeOrgRole(ctx, id, types.OrgRoleOwner); err != nil {
+		return err
+	}
+
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		org, err := c.orgRepo.GetOne(ctx, &id, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		if err = c.orgRepo.Delete(ctx, org.ID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx, &org.ID, types.AuditActionDelete, types.AuditEntityOrg, org.ID, org, nil)
+	})
 }
@@ -59,13 +59,102 @@ func (c *Core) Signup(ctx context.Context, args orgAccountSignupArgs) (*types.Ac
 		return nil, err
 	}
 
-	return c.orgAccountRepo.Signup(ctx,
-		args.firstName,
-		args.lastName,
-		args.email,
-		args.username,
-		password,
+	var account *types.Account
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if account, err = c.orgAccountRepo.Signup(ctx,
+			args.firstName,
+			args.lastName,
+			args.email,
+			args.username,
+			password,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			account.OrgID,
+			types.AuditActionCreate,
+			types.AuditEntityAccount,
+			account.ID,
+			nil,
+			account,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+type orgSignupArgs struct {
+	account orgAccountSignupArgs
+	orgName string
+	orgSlug string
+}
+
+func (a *orgSignupArgs) Validate() error {
+	if err := a.account.Validate(); err != nil {
+		return err
+	}
+	if a.orgName == "" {
+		return errors.New("orgSignupArgs.orgName cannot be empty")
+	}
+	if err := validateSlug(a.orgSlug); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Core) NewOrgSignupArgs(
+	firstName string,
+	lastName string,
+	email string,
+	username string,
+	password string,
+	orgName string,
+	orgSlug string,
+) orgSignupArgs {
+	return orgSignupArgs{
+		account: c.NewOrgAccountSignupArgs(firstName, lastName, email, username, password),
+		orgName: orgName,
+		orgSlug: orgSlug,
+	}
+}
+
+// OrgSignup signs up an account, creates the org it owns and moves the
+// account into it in one transaction, so a failure at any step leaves
+// neither behind. The org is created as the new account.
+func (c *Core) OrgSignup(ctx context.Context, args orgSignupArgs) (*types.Account, *types.Organization, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		account *types.Account
+		org     *types.Organization
 	)
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if account, err = c.Signup(ctx, args.account); err != nil {
+			return err
+		}
+
+		ownerCtx := types.NewOperationCtx(ctx, tracer.TraceID, tracer.StartTime, *account)
+		if org, err = c.OrgCreate(ownerCtx, c.NewOrgCreateArgs(args.orgName, args.orgSlug, account.ID)); err != nil {
+			return err
+		}
+
+		account, err = c.OrgAccountSetOrgID(ownerCtx, org.ID, account.ID)
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return account, org, nil
 }
 
 type orgAccountCreateArgs struct {
@@ -129,6 +218,10 @@ func (c *Core) OrgAccountCreate(ctx context.Context, args orgAccountCreateArgs)
 		return nil, err
 	}
 
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
 	var password *string
 	if args.password != nil {
 		tmpPass, err := c.AuthPasswordHash(*args.password)
@@ -142,15 +235,33 @@ func (c *Core) OrgAccountCreate(ctx context.Context, args orgAccountCreateArgs)
 		}
 	}
 
-	return c.orgAccountRepo.Create(ctx,
-		org.ID,
-		args.firstName,
-		args.lastName,
-		args.email,
-		args.username,
-		password,
-		tracer.AuthAccount.ID,
-	)
+	var account *types.Account
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if account, err = c.orgAccountRepo.Create(ctx,
+			org.ID,
+			args.firstName,
+			args.lastName,
+			args.email,
+			args.username,
+			password,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionCreate,
+			types.AuditEntityAccount,
+			account.ID,
+			nil,
+			account,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return account, nil
 }
 
 func (c *Core) OrgAccountGetMany(ctx context.Context, orgSlug string) ([]types.Account, error) {
@@ -280,15 +391,41 @@ func (c *Core) OrgAccountUpdate(ctx context.Context, args orgAccountUpdateArgs)
 		return nil, err
 	}
 
-	return c.orgAccountRepo.Update(ctx,
-		org.ID,
-		args.id,
-		args.firstName,
-		args.lastName,
-		args.email,
-		args.username,
-		tracer.AuthAccount.ID,
-	)
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	var before, account *types.Account
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.orgAccountRepo.GetOne(ctx, org.ID, &args.id, nil, nil); err != nil {
+			return err
+		}
+
+		if account, err = c.orgAccountRepo.Update(ctx,
+			org.ID,
+			args.id,
+			args.firstName,
+			args.lastName,
+			args.email,
+			args.username,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionUpdate,
+			types.AuditEntityAccount,
+			account.ID,
+			before,
+			account,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return account, nil
 }
 
 func (c *Core) OrgAccountSetOrgID(ctx context.Context, orgID int64, accountID int64) (*types.Account, error) {
@@ -298,7 +435,53 @@ func (c *Core) OrgAccountSetOrgID(ctx context.Context, orgID int64, accountID in
 	if accountID < 1 {
 		return nil, errors.New("core.OrgAccountSetOrgID accountID must be positive integer")
 	}
-	return c.orgAccountRepo.SetOrgID(ctx, orgID, accountID)
+
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		before  *types.Account
+		account *types.Account
+	)
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.globalAccountRepo.GetOne(ctx, &accountID, nil, nil); err != nil {
+			return err
+		}
+
+		// During signup an account without an org joins the org it owns,
+		// anything else is reserved for instance admins
+		if tracer.SDKKey != nil || !tracer.AuthAccount.IsInstanceAdmin {
+			if tracer.SDKKey != nil || tracer.AuthAccount.ID != accountID || before.OrgID != nil {
+				return types.ErrOperationNotPermitted
+			}
+			org, err := c.orgRepo.GetOne(ctx, &orgID, nil, nil)
+			if err != nil {
+				return err
+			}
+			if org.Owner != accountID {
+				return types.ErrOperationNotPermitted
+			}
+		}
+
+		if account, err = c.orgAccountRepo.SetOrgID(ctx, orgID, accountID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&orgID,
+			types.AuditActionUpdate,
+			types.AuditEntityAccount,
+			account.ID,
+			before,
+			account,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return account, nil
 }
 
 func (c *Core) OrgAccountDelete(ctx context.Context, orgSlug string, id int64) error {
@@ -311,5 +494,27 @@ func (c *Core) OrgAccountDelete(ctx context.Context, orgSlug string, id int64) e
 		return err
 	}
 
-	return c.orgAccountRepo.Delete(ctx, org.ID, id)
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return err
+	}
+
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		account, err := c.orgAccountRepo.GetOne(ctx, org.ID, &id, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		if err = c.orgAccountRepo.Delete(ctx, org.ID, account.ID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionDelete,
+			types.AuditEntityAccount,
+			account.ID,
+			account,
+			nil,
+		)
+	})
 }
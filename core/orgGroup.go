@@ -49,12 +49,34 @@ func (c *Core) OrgGroupCreate(ctx context.Context, args orgGroupCreateArgs) (*ty
 		return nil, err
 	}
 
-	return c.orgGroupRepo.Create(ctx,
-		org.ID,
-		args.name,
-		args.description,
-		tracer.AuthAccount.ID,
-	)
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	var group *types.OrgGroup
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if group, err = c.orgGroupRepo.Create(ctx,
+			org.ID,
+			args.name,
+			args.description,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionCreate,
+			types.AuditEntityOrgGroup,
+			group.ID,
+			nil,
+			group,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return group, nil
 }
 
 func (c *Core) OrgGroupGetMany(ctx context.Context, orgSlug string) ([]types.OrgGroup, error) {
@@ -161,13 +183,39 @@ func (c *Core) OrgGroupUpdate(ctx context.Context, args orgGroupUpdateArgs) (*ty
 		return nil, err
 	}
 
-	return c.orgGroupRepo.Update(ctx,
-		org.ID,
-		args.id,
-		args.name,
-		args.description,
-		tracer.AuthAccount.ID,
-	)
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	var before, group *types.OrgGroup
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.orgGroupRepo.GetOne(ctx, org.ID, &args.id, nil); err != nil {
+			return err
+		}
+
+		if group, err = c.orgGroupRepo.Update(ctx,
+			org.ID,
+			args.id,
+			args.name,
+			args.description,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionUpdate,
+			types.AuditEntityOrgGroup,
+			group.ID,
+			before,
+			group,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return group, nil
 }
 
 func (c *Core) OrgGroupDelete(ctx context.Context, orgSlug string, id int64) error {
@@ -183,7 +231,29 @@ func (c *Core) OrgGroupDelete(ctx context.Context, orgSlug string, id int64) err
 		return err
 	}
 
-	return c.orgGroupRepo.Delete(ctx, org.ID, id)
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return err
+	}
+
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		group, err := c.orgGroupRepo.GetOne(ctx, org.ID, &id, nil)
+		if err != nil {
+			return err
+		}
+
+		if err = c.orgGroupRepo.Delete(ctx, org.ID, group.ID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionDelete,
+			types.AuditEntityOrgGroup,
+			group.ID,
+			group,
+			nil,
+		)
+	})
 }
 
 type orgGroupAccountAddArgs struct {
@@ -235,6 +305,11 @@ func (c *Core) OrgGroupAccountAdd(ctx context.Context, args orgGroupAccountAddAr
 	); err != nil {
 		return nil, err
 	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
 	if group, err = c.OrgGroupGetOne(ctx,
 		c.NewOrgGroupGetOneArgs(args.orgSlug, &args.groupID, nil),
 	); err != nil {
@@ -243,6 +318,9 @@ func (c *Core) OrgGroupAccountAdd(ctx context.Context, args orgGroupAccountAddAr
 	if group.OrgID != org.ID {
 		return nil, types.ErrNotFound
 	}
+	if err = c.orgGroupAuthorizeMembership(ctx, org.ID, group.ID); err != nil {
+		return nil, err
+	}
 
 	if account, err = c.OrgAccountGetOne(ctx,
 		c.NewOrgAccountGetOneArgs(args.orgSlug, &args.accountID, nil, nil),
@@ -263,12 +341,38 @@ func (c *Core) OrgGroupAccountAdd(ctx context.Context, args orgGroupAccountAddAr
 		return nil, err
 	}
 
-	return c.orgGroupRepo.AddAccount(ctx,
-		org.ID,
-		args.groupID,
-		args.accountID,
-		tracer.AuthAccount.ID,
-	)
+	var groupAccount *types.OrgGroupAccount
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if groupAccount, err = c.orgGroupRepo.AddAccount(ctx,
+			org.ID,
+			args.groupID,
+			args.accountID,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		if err = c.webhookEnqueue(ctx, org.ID, types.WebhookEventGroupAccountsChanged, types.WebhookGroupAccountsChange{
+			GroupID:           groupAccount.GroupID,
+			AddedAccountIDs:   []int64{groupAccount.AccountID},
+			RemovedAccountIDs: []int64{},
+		}); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionCreate,
+			types.AuditEntityOrgGroupAccount,
+			groupAccount.GroupID,
+			nil,
+			groupAccount,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return groupAccount, nil
 }
 
 func (c *Core) OrgGroupAccountGetAll(ctx context.Context,
@@ -331,12 +435,18 @@ func (c *Core) OrgGroupAccountsSet(ctx context.Context, args orgGroupAccountsSet
 	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
 		return nil, err
 	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
 	if group, err = c.OrgGroupGetOne(ctx, c.NewOrgGroupGetOneArgs(args.orgSlug, &args.groupID, nil)); err != nil {
 		return nil, err
 	}
 	if group.OrgID != org.ID {
 		return nil, types.ErrNotFound
 	}
+	if err = c.orgGroupAuthorizeMembership(ctx, org.ID, group.ID); err != nil {
+		return nil, err
+	}
 
 	existingAccounts, err := c.OrgAccountGetManyByID(ctx, args.orgSlug, args.accountIDs)
 	if err != nil {
@@ -358,7 +468,42 @@ func (c *Core) OrgGroupAccountsSet(ctx context.Context, args orgGroupAccountsSet
 	}
 
 	tracer, _ := c.getOperationTracer(ctx)
-	return c.orgGroupRepo.UpdateAccounts(ctx, org.ID, args.groupID, args.accountIDs, tracer.AuthAccount.ID)
+
+	var before, accounts []types.Account
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.orgGroupRepo.GetAccounts(ctx, org.ID, args.groupID); err != nil {
+			return err
+		}
+
+		if accounts, err = c.orgGroupRepo.UpdateAccounts(ctx,
+			org.ID,
+			args.groupID,
+			args.accountIDs,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		if change := groupAccountsChange(args.groupID, before, accounts); len(change.AddedAccountIDs) > 0 ||
+			len(change.RemovedAccountIDs) > 0 {
+			if err = c.webhookEnqueue(ctx, org.ID, types.WebhookEventGroupAccountsChanged, change); err != nil {
+				return err
+			}
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionUpdate,
+			types.AuditEntityOrgGroupAccount,
+			args.groupID,
+			groupAccountsAuditState(args.groupID, before),
+			groupAccountsAuditState(args.groupID, accounts),
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
 }
 
 func (c *Core) OrgGroupAccountRemove(ctx context.Context,
@@ -371,5 +516,74 @@ func (c *Core) OrgGroupAccountRemove(ctx context.Context,
 		return err
 	}
 
-	return c.orgGroupRepo.RemoveAccount(ctx, org.ID, groupID, accountID)
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return err
+	}
+	if err = c.orgGroupAuthorizeMembership(ctx, org.ID, groupID); err != nil {
+		return err
+	}
+
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.orgGroupRepo.RemoveAccount(ctx, org.ID, groupID, accountID); err != nil {
+			return err
+		}
+
+		if err := c.webhookEnqueue(ctx, org.ID, types.WebhookEventGroupAccountsChanged, types.WebhookGroupAccountsChange{
+			GroupID:           groupID,
+			AddedAccountIDs:   []int64{},
+			RemovedAccountIDs: []int64{accountID},
+		}); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionDelete,
+			types.AuditEntityOrgGroupAccount,
+			groupID,
+			map[string]int64{"groupId": groupID, "accountId": accountID},
+			nil,
+		)
+	})
+}
+
+// groupAccountsAuditState records group membership by account ID only
+func groupAccountsAuditState(groupID int64, accounts []types.Account) map[string]any {
+	accountIDs := make([]int64, len(accounts))
+	for i, account := range accounts {
+		accountIDs[i] = account.ID
+	}
+
+	return map[string]any{
+		"groupId":    groupID,
+		"accountIds": accountIDs,
+	}
+}
+
+// groupAccountsChange lists the accounts added to and removed from a group
+func groupAccountsChange(groupID int64, before []types.Account, after []types.Account) types.WebhookGroupAccountsChange {
+	change := types.WebhookGroupAccountsChange{
+		GroupID:           groupID,
+		AddedAccountIDs:   []int64{},
+		RemovedAccountIDs: []int64{},
+	}
+
+	beforeIDs := make(map[int64]bool, len(before))
+	for _, account := range before {
+		beforeIDs[account.ID] = true
+	}
+	afterIDs := make(map[int64]bool, len(after))
+	for _, account := range after {
+		afterIDs[account.ID] = true
+		if !beforeIDs[account.ID] {
+			change.AddedAccountIDs = append(change.AddedAccountIDs, account.ID)
+		}
+	}
+	for _, account := range before {
+		if !afterIDs[account.ID] {
+			change.RemovedAccountIDs = append(change.RemovedAccountIDs, account.ID)
+		}
+	}
+
+	return change
 }
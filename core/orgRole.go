@@ -0,0 +1,359 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"switchcraft/types"
+)
+
+// orgAccountRolesGet resolves the effective role of an account in an org
+func (c *Core) orgAccountRolesGet(ctx context.Context, orgID int64, accountID int64) (*types.OrgAccountRoles, error) {
+	org, err := c.orgRepo.GetOne(ctx, &orgID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, err := c.orgAccountRepo.RoleGetGrants(ctx, org.ID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if grants == nil {
+		grants = []types.OrgRoleGrant{}
+	}
+
+	roles := &types.OrgAccountRoles{
+		OrgID:     org.ID,
+		AccountID: accountID,
+		Role:      types.OrgRoleViewer,
+		IsOwner:   org.Owner == accountID,
+		Grants:    grants,
+	}
+	if roles.IsOwner {
+		roles.Role = types.OrgRoleOwner
+	}
+	for _, grant := range grants {
+		if grant.Role.Includes(roles.Role) {
+			roles.Role = grant.Role
+		}
+	}
+
+	return roles, nil
+}
+
+// authorizeOrgRoleGrant prevents accounts from handing out more access than
+// they hold themselves, whether directly or through group membership
+func (c *Core) authorizeOrgRoleGrant(ctx context.Context, orgID int64, role types.OrgRole) error {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return err
+	}
+
+	if tracer.SDKKey == nil && tracer.AuthAccount.IsInstanceAdmin {
+		return nil
+	}
+
+	return c.authorizeOrgRole(ctx, orgID, role)
+}
+
+// OrgAccountRolesGet returns the effective role of an org account and the
+// grants it is derived from
+func (c *Core) OrgAccountRolesGet(ctx context.Context, orgSlug string, accountID int64) (*types.OrgAccountRoles, error) {
+	if orgSlug == "" {
+		return nil, errors.New("core.OrgAccountRolesGet orgSlug cannot be empty")
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := c.orgAccountRepo.GetOne(ctx, org.ID, &accountID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.orgAccountRolesGet(ctx, org.ID, account.ID)
+}
+
+type orgAccountRoleSetArgs struct {
+	orgSlug   string
+	accountID int64
+	role      types.OrgRole
+}
+
+func (a *orgAccountRoleSetArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("orgAccountRoleSetArgs.orgSlug cannot be empty")
+	}
+	if a.accountID < 1 {
+		return errors.New("orgAccountRoleSetArgs.accountID must be positive integer")
+	}
+	if !a.role.IsValid() {
+		return fmt.Errorf("orgAccountRoleSetArgs.role invalid role '%s'", a.role)
+	}
+	return nil
+}
+
+func (c *Core) NewOrgAccountRoleSetArgs(
+	orgSlug string,
+	accountID int64,
+	role types.OrgRole,
+) orgAccountRoleSetArgs {
+	return orgAccountRoleSetArgs{
+		orgSlug:   orgSlug,
+		accountID: accountID,
+		role:      role,
+	}
+}
+
+func (c *Core) OrgAccountRoleSet(ctx context.Context, args orgAccountRoleSetArgs) (*types.OrgAccountRole, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err = c.authorizeOrgRoleGrant(ctx, org.ID, args.role); err != nil {
+		return nil, err
+	}
+
+	account, err := c.orgAccountRepo.GetOne(ctx, org.ID, &args.accountID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var before, accountRole *types.OrgAccountRole
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.orgAccountRepo.RoleGetOne(ctx, org.ID, account.ID); err != nil {
+			if !errors.Is(err, types.ErrNotFound) {
+				return err
+			}
+		}
+
+		if before != nil {
+			if err = c.authorizeOrgRoleGrant(ctx, org.ID, before.Role); err != nil {
+				return err
+			}
+		}
+
+		if accountRole, err = c.orgAccountRepo.RoleSet(ctx,
+			org.ID,
+			account.ID,
+			args.role,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		action := types.AuditActionUpdate
+		if before == nil {
+			action = types.AuditActionCreate
+		}
+
+		return c.auditLog(ctx, &org.ID, action, types.AuditEntityAccountRole, account.ID, before, accountRole)
+	}); err != nil {
+		return nil, err
+	}
+
+	return accountRole, nil
+}
+
+func (c *Core) OrgAccountRoleDelete(ctx context.Context, orgSlug string, accountID int64) error {
+	if orgSlug == "" {
+		return errors.New("core.OrgAccountRoleDelete orgSlug cannot be empty")
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug))
+	if err != nil {
+		return err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return err
+	}
+
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		accountRole, err := c.orgAccountRepo.RoleGetOne(ctx, org.ID, accountID)
+		if err != nil {
+			return err
+		}
+
+		if err = c.authorizeOrgRoleGrant(ctx, org.ID, accountRole.Role); err != nil {
+			return err
+		}
+
+		if err = c.orgAccountRepo.RoleDelete(ctx, org.ID, accountID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionDelete,
+			types.AuditEntityAccountRole,
+			accountID,
+			accountRole,
+			nil,
+		)
+	})
+}
+
+type orgGroupRoleSetArgs struct {
+	orgSlug string
+	groupID int64
+	role    types.OrgRole
+}
+
+func (a *orgGroupRoleSetArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("orgGroupRoleSetArgs.orgSlug cannot be empty")
+	}
+	if a.groupID < 1 {
+		return errors.New("orgGroupRoleSetArgs.groupID must be positive integer")
+	}
+	if !a.role.IsValid() {
+		return fmt.Errorf("orgGroupRoleSetArgs.role invalid role '%s'", a.role)
+	}
+	return nil
+}
+
+func (c *Core) NewOrgGroupRoleSetArgs(
+	orgSlug string,
+	groupID int64,
+	role types.OrgRole,
+) orgGroupRoleSetArgs {
+	return orgGroupRoleSetArgs{
+		orgSlug: orgSlug,
+		groupID: groupID,
+		role:    role,
+	}
+}
+
+// OrgGroupRoleSet grants a role to every member of a group
+func (c *Core) OrgGroupRoleSet(ctx context.Context, args orgGroupRoleSetArgs) (*types.OrgGroupRole, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err = c.authorizeOrgRoleGrant(ctx, org.ID, args.role); err != nil {
+		return nil, err
+	}
+
+	group, err := c.orgGroupRepo.GetOne(ctx, org.ID, &args.groupID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var before, groupRole *types.OrgGroupRole
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.orgGroupRepo.RoleGetOne(ctx, org.ID, group.ID); err != nil {
+			if !errors.Is(err, types.ErrNotFound) {
+				return err
+			}
+		}
+
+		if before != nil {
+			if err = c.authorizeOrgRoleGrant(ctx, org.ID, before.Role); err != nil {
+				return err
+			}
+		}
+
+		if groupRole, err = c.orgGroupRepo.RoleSet(ctx,
+			org.ID,
+			group.ID,
+			args.role,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		action := types.AuditActionUpdate
+		if before == nil {
+			action = types.AuditActionCreate
+		}
+
+		return c.auditLog(ctx, &org.ID, action, types.AuditEntityGroupRole, group.ID, before, groupRole)
+	}); err != nil {
+		return nil, err
+	}
+
+	return groupRole, nil
+}
+
+func (c *Core) OrgGroupRoleDelete(ctx context.Context, orgSlug string, groupID int64) error {
+	if orgSlug == "" {
+		return errors.New("core.OrgGroupRoleDelete orgSlug cannot be empty")
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug))
+	if err != nil {
+		return err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return err
+	}
+
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		groupRole, err := c.orgGroupRepo.RoleGetOne(ctx, org.ID, groupID)
+		if err != nil {
+			return err
+		}
+
+		if err = c.authorizeOrgRoleGrant(ctx, org.ID, groupRole.Role); err != nil {
+			return err
+		}
+
+		if err = c.orgGroupRepo.RoleDelete(ctx, org.ID, groupID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionDelete,
+			types.AuditEntityGroupRole,
+			groupID,
+			groupRole,
+			nil,
+		)
+	})
+}
+
+// orgGroupAuthorizeMembership guards changes to the members of a group that
+// grants a role, since adding an account hands it that role
+func (c *Core) orgGroupAuthorizeMembership(ctx context.Context, orgID int64, groupID int64) error {
+	groupRole, err := c.orgGroupRepo.RoleGetOne(ctx, orgID, groupID)
+	if errors.Is(err, types.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return c.authorizeOrgRoleGrant(ctx, orgID, groupRole.Role)
+}
@@ -0,0 +1,454 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"switchcraft/types"
+)
+
+type orgSegmentCreateArgs struct {
+	orgSlug     string
+	name        string
+	description string
+	rules       []types.SegmentRule
+	accountIDs  []int64
+}
+
+func (a *orgSegmentCreateArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("orgSegmentCreateArgs.orgSlug cannot be empty")
+	}
+	if a.name == "" {
+		return errors.New("orgSegmentCreateArgs.name cannot be empty")
+	}
+	if err := orgSegmentRulesValidate(a.rules, a.accountIDs); err != nil {
+		return fmt.Errorf("orgSegmentCreateArgs.%w", err)
+	}
+	return nil
+}
+
+func (c *Core) NewOrgSegmentCreateArgs(
+	orgSlug string,
+	name string,
+	description string,
+	rules []types.SegmentRule,
+	accountIDs []int64,
+) orgSegmentCreateArgs {
+	if rules == nil {
+		rules = []types.SegmentRule{}
+	}
+	return orgSegmentCreateArgs{
+		orgSlug:     orgSlug,
+		name:        name,
+		description: description,
+		rules:       rules,
+		accountIDs:  orgSegmentAccountIDs(accountIDs),
+	}
+}
+
+func (c *Core) OrgSegmentCreate(ctx context.Context, args orgSegmentCreateArgs) (*types.OrgSegment, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
+	if err = c.orgSegmentAccountsValidate(ctx, org.ID, args.accountIDs); err != nil {
+		return nil, err
+	}
+
+	var segment *types.OrgSegment
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if segment, err = c.orgSegmentRepo.Create(ctx,
+			org.ID,
+			args.name,
+			args.description,
+			args.rules,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		if err = c.orgSegmentRepo.AccountsSet(ctx,
+			org.ID,
+			segment.ID,
+			args.accountIDs,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+		segment.AccountIDs = args.accountIDs
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionCreate,
+			types.AuditEntitySegment,
+			segment.ID,
+			nil,
+			segment,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+func (c *Core) OrgSegmentGetMany(ctx context.Context, orgSlug string) ([]types.OrgSegment, error) {
+	if orgSlug == "" {
+		return nil, errors.New("core.OrgSegmentGetMany orgSlug cannot be empty")
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.orgSegmentRepo.GetMany(ctx, org.ID)
+}
+
+type orgSegmentGetOneArgs struct {
+	orgSlug string
+	id      *int64
+	uuid    *string
+}
+
+func (a *orgSegmentGetOneArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("orgSegmentGetOneArgs orgSlug cannot be empty")
+	}
+	if a.id == nil && a.uuid == nil {
+		return errors.New("orgSegmentGetOneArgs must provide id or uuid")
+	}
+	return nil
+}
+
+func (c *Core) NewOrgSegmentGetOneArgs(orgSlug string, id *int64, uuid *string) orgSegmentGetOneArgs {
+	return orgSegmentGetOneArgs{
+		orgSlug: orgSlug,
+		id:      id,
+		uuid:    uuid,
+	}
+}
+
+func (c *Core) OrgSegmentGetOne(ctx context.Context, args orgSegmentGetOneArgs) (*types.OrgSegment, error) {
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.orgSegmentRepo.GetOne(ctx, org.ID, args.id, args.uuid)
+}
+
+// OrgSegmentFlagRefsGetMany lists the flags whose targeting rules reference a
+// segment
+func (c *Core) OrgSegmentFlagRefsGetMany(ctx context.Context,
+	orgSlug string,
+	id int64,
+) ([]types.OrgSegmentFlagRef, error) {
+	if orgSlug == "" {
+		return nil, errors.New("core.OrgSegmentFlagRefsGetMany orgSlug cannot be empty")
+	}
+	if id < 1 {
+		return nil, errors.New("core.OrgSegmentFlagRefsGetMany id must be positive integer")
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.orgSegmentRepo.GetOne(ctx, org.ID, &id, nil); err != nil {
+		return nil, err
+	}
+
+	return c.orgSegmentRepo.FlagRefsGetMany(ctx, org.ID, id)
+}
+
+type orgSegmentUpdateArgs struct {
+	orgSlug     string
+	id          int64
+	name        string
+	description string
+	rules       []types.SegmentRule
+	accountIDs  []int64
+}
+
+func (a *orgSegmentUpdateArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("orgSegmentUpdateArgs orgSlug cannot be empty")
+	}
+	if a.id < 1 {
+		return errors.New("orgSegmentUpdateArgs id must be positive integer")
+	}
+	if a.name == "" {
+		return errors.New("orgSegmentUpdateArgs name cannot be empty")
+	}
+	if err := orgSegmentRulesValidate(a.rules, a.accountIDs); err != nil {
+		return fmt.Errorf("orgSegmentUpdateArgs %w", err)
+	}
+	return nil
+}
+
+func (c *Core) NewOrgSegmentUpdateArgs(
+	orgSlug string,
+	id int64,
+	name string,
+	description string,
+	rules []types.SegmentRule,
+	accountIDs []int64,
+) orgSegmentUpdateArgs {
+	if rules == nil {
+		rules = []types.SegmentRule{}
+	}
+	return orgSegmentUpdateArgs{
+		orgSlug:     orgSlug,
+		id:          id,
+		name:        name,
+		description: description,
+		rules:       rules,
+		accountIDs:  orgSegmentAccountIDs(accountIDs),
+	}
+}
+
+// OrgSegmentUpdate replaces a segment's rules and accounts. Every flag that
+// references the segment is announced as updated so SDKs reload it.
+func (c *Core) OrgSegmentUpdate(ctx context.Context, args orgSegmentUpdateArgs) (*types.OrgSegment, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return nil, err
+	}
+	if err = c.orgSegmentAccountsValidate(ctx, org.ID, args.accountIDs); err != nil {
+		return nil, err
+	}
+
+	var before, segment *types.OrgSegment
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.orgSegmentRepo.GetOne(ctx, org.ID, &args.id, nil); err != nil {
+			return err
+		}
+
+		if segment, err = c.orgSegmentRepo.Update(ctx,
+			org.ID,
+			args.id,
+			args.name,
+			args.description,
+			args.rules,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		if err = c.orgSegmentRepo.AccountsSet(ctx,
+			org.ID,
+			segment.ID,
+			args.accountIDs,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+		segment.AccountIDs = args.accountIDs
+
+		refs, err := c.orgSegmentRepo.FlagRefsGetMany(ctx, org.ID, segment.ID)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			flag, err := c.featureFlagRepo.GetOne(ctx, org.ID, ref.ApplicationID, ref.EnvironmentID, &ref.FlagID, nil, nil)
+			if err != nil {
+				return err
+			}
+			if err = c.flagEventPublish(ctx,
+				org.ID,
+				ref.ApplicationID,
+				&ref.EnvironmentID,
+				types.FlagEventFlagUpdated,
+				flag.ID,
+				nil,
+				flag,
+			); err != nil {
+				return err
+			}
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionUpdate,
+			types.AuditEntitySegment,
+			segment.ID,
+			before,
+			segment,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+// OrgSegmentDelete fails with ErrItemInUse while any flag's targeting rules
+// still reference the segment
+func (c *Core) OrgSegmentDelete(ctx context.Context, orgSlug string, id int64) error {
+	if orgSlug == "" {
+		return errors.New("core.OrgSegmentDelete orgSlug cannot be empty")
+	}
+	if id < 1 {
+		return errors.New("core.OrgSegmentDelete id must be positive integer")
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug))
+	if err != nil {
+		return err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleEditor); err != nil {
+		return err
+	}
+
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		segment, err := c.orgSegmentRepo.GetOne(ctx, org.ID, &id, nil)
+		if err != nil {
+			return err
+		}
+
+		refs, err := c.orgSegmentRepo.FlagRefsGetMany(ctx, org.ID, segment.ID)
+		if err != nil {
+			return err
+		}
+		if len(refs) > 0 {
+			flagNames := make([]string, len(refs))
+			for i, ref := range refs {
+				flagNames[i] = ref.ApplicationSlug + "/" + ref.EnvironmentSlug + "/" + ref.FlagName
+			}
+			return fmt.Errorf("%w: segment '%s' is referenced by flags %s",
+				types.ErrItemInUse,
+				segment.Name,
+				strings.Join(flagNames, ", "),
+			)
+		}
+
+		if err = c.orgSegmentRepo.Delete(ctx, org.ID, segment.ID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionDelete,
+			types.AuditEntitySegment,
+			segment.ID,
+			segment,
+			nil,
+		)
+	})
+}
+
+// orgSegmentsGetForFlags loads the segments referenced by the targeting rules
+// of any of the flags, keyed by ID for evaluation. Returns nil when no flag
+// references a segment.
+func (c *Core) orgSegmentsGetForFlags(ctx context.Context,
+	orgID int64,
+	flags []types.FeatureFlag,
+) (map[int64]*types.OrgSegment, error) {
+	var segmentIDs []int64
+	for i := range flags {
+		for _, segmentID := range types.TargetingRuleSegmentIDs(flags[i].Rules) {
+			if !slices.Contains(segmentIDs, segmentID) {
+				segmentIDs = append(segmentIDs, segmentID)
+			}
+		}
+	}
+	if len(segmentIDs) == 0 {
+		return nil, nil
+	}
+
+	segments, err := c.orgSegmentRepo.GetManyByID(ctx, orgID, segmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	segmentsByID := make(map[int64]*types.OrgSegment, len(segments))
+	for i := range segments {
+		segmentsByID[segments[i].ID] = &segments[i]
+	}
+
+	return segmentsByID, nil
+}
+
+// orgSegmentAccountsValidate ensures every account exists and belongs to org
+func (c *Core) orgSegmentAccountsValidate(ctx context.Context, orgID int64, accountIDs []int64) error {
+	if len(accountIDs) == 0 {
+		return nil
+	}
+
+	accounts, err := c.orgAccountRepo.GetManyByID(ctx, orgID, accountIDs)
+	if err != nil {
+		return err
+	}
+	if len(accounts) != len(accountIDs) {
+		return types.ErrNotFound
+	}
+
+	for _, a := range accounts {
+		if a.OrgID == nil || *a.OrgID != orgID {
+			return errors.New("core.orgSegmentAccountsValidate account must be org member")
+		}
+	}
+
+	return nil
+}
+
+func orgSegmentRulesValidate(rules []types.SegmentRule, accountIDs []int64) error {
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			return fmt.Errorf("rules: rule %d: %w", i, err)
+		}
+	}
+	for _, id := range accountIDs {
+		if id < 1 {
+			return errors.New("accountIDs must be positive integers")
+		}
+	}
+	return nil
+}
+
+// orgSegmentAccountIDs sorts and de-duplicates account IDs so they can be
+// inserted and compared as given
+func orgSegmentAccountIDs(accountIDs []int64) []int64 {
+	accountIDs = slices.Clone(accountIDs)
+	slices.Sort(accountIDs)
+	accountIDs = slices.Compact(accountIDs)
+	if accountIDs == nil {
+		accountIDs = []int64{}
+	}
+	return accountIDs
+}
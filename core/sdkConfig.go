@@ -0,0 +1,82 @@
+package core
+
+import (
+	"context"
+	"switchcraft/types"
+)
+
+// SDKConfigGet loads the full flag set of an application environment for
+// SDKs that evaluate flags in process.
+func (c *Core) SDKConfigGet(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+) (*types.SDKConfig, error) {
+	var (
+		org    *types.Organization
+		app    *types.Application
+		env    *types.Environment
+		config types.SDKConfig
+		err    error
+	)
+
+	if ctx, err = c.authorizeSDKKey(ctx, types.SDKKeyTypeServer); err != nil {
+		return nil, err
+	}
+
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, envSlug); err != nil {
+		return nil, err
+	}
+
+	if config.Flags, err = c.featureFlagRepo.GetMany(ctx, org.ID, app.ID, env.ID); err != nil {
+		return nil, err
+	}
+	if config.GroupFlags, err = c.featureFlagRepo.GroupFlagsGetMany(ctx, org.ID, app.ID, env.ID); err != nil {
+		return nil, err
+	}
+	if config.GroupAccounts, err = c.orgGroupRepo.GetAccountsByAppID(ctx, org.ID, app.ID, env.ID); err != nil {
+		return nil, err
+	}
+
+	config.AccountUUIDs = map[int64]string{}
+	if sdkConfigHasRollout(&config) {
+		accounts, err := c.orgAccountRepo.GetMany(ctx, org.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range accounts {
+			config.AccountUUIDs[account.ID] = account.UUID
+		}
+	}
+
+	segments, err := c.orgSegmentsGetForFlags(ctx, org.ID, config.Flags)
+	if err != nil {
+		return nil, err
+	}
+	config.Segments = make([]types.OrgSegment, 0, len(segments))
+	for _, segment := range segments {
+		config.Segments = append(config.Segments, *segment)
+	}
+
+	return &config, nil
+}
+
+func sdkConfigHasRollout(config *types.SDKConfig) bool {
+	for _, flag := range config.Flags {
+		if flag.RolloutPercentage != nil {
+			return true
+		}
+	}
+	for _, groupFlag := range config.GroupFlags {
+		if groupFlag.RolloutPercentage != nil {
+			return true
+		}
+	}
+	return false
+}
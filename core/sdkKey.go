@@ -0,0 +1,362 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"switchcraft/types"
+)
+
+const sdkKeyByteLength = 32
+
+// Number of characters of the random portion of a key kept for display
+const sdkKeyDisplayLength = 6
+
+type sdkKeyCtxType int
+
+const (
+	_ sdkKeyCtxType = iota
+	ctxSDKKeyAuthorized
+)
+
+type sdkKeyCreateArgs struct {
+	orgSlug string
+	appSlug string
+	envSlug string
+	name    string
+	keyType types.SDKKeyType
+}
+
+func (a *sdkKeyCreateArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("sdkKeyCreateArgs.orgSlug cannot be empty")
+	}
+	if a.appSlug == "" {
+		return errors.New("sdkKeyCreateArgs.appSlug cannot be empty")
+	}
+	if a.name == "" {
+		return errors.New("sdkKeyCreateArgs.name cannot be empty")
+	}
+	if a.keyType != types.SDKKeyTypeServer && a.keyType != types.SDKKeyTypeClient {
+		return fmt.Errorf(
+			"sdkKeyCreateArgs.keyType must be '%s' or '%s'",
+			types.SDKKeyTypeServer,
+			types.SDKKeyTypeClient,
+		)
+	}
+	return nil
+}
+
+func (c *Core) NewSDKKeyCreateArgs(
+	orgSlug string,
+	appSlug string,
+	envSlug string,
+	name string,
+	keyType types.SDKKeyType,
+) sdkKeyCreateArgs {
+	return sdkKeyCreateArgs{
+		orgSlug: orgSlug,
+		appSlug: appSlug,
+		envSlug: envSlug,
+		name:    name,
+		keyType: keyType,
+	}
+}
+
+// SDKKeyCreate issues a key for one environment of an application
+func (c *Core) SDKKeyCreate(ctx context.Context, args sdkKeyCreateArgs) (*types.SDKKeyWithSecret, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		org *types.Organization
+		app *types.Application
+		env *types.Environment
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &args.appSlug)); err != nil {
+		return nil, err
+	}
+	if env, err = c.appEnvGet(ctx, org.ID, app.ID, args.envSlug); err != nil {
+		return nil, err
+	}
+
+	key, keyPrefix, keyHash, err := generateSDKKey(args.keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	var sdkKey *types.SDKKey
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if sdkKey, err = c.sdkKeyRepo.Create(ctx,
+			org.ID,
+			app.ID,
+			env.ID,
+			args.name,
+			args.keyType,
+			keyPrefix,
+			keyHash,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionCreate,
+			types.AuditEntitySDKKey,
+			sdkKey.ID,
+			nil,
+			sdkKey,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &types.SDKKeyWithSecret{SDKKey: *sdkKey, Key: key}, nil
+}
+
+func (c *Core) SDKKeyGetMany(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+) ([]types.SDKKey, error) {
+	var (
+		org *types.Organization
+		app *types.Application
+		err error
+	)
+
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &appSlug)); err != nil {
+		return nil, err
+	}
+
+	return c.sdkKeyRepo.GetMany(ctx, org.ID, app.ID)
+}
+
+// SDKKeyRotate replaces the secret of an existing key, immediately
+// invalidating the previous one.
+func (c *Core) SDKKeyRotate(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	id int64,
+) (*types.SDKKeyWithSecret, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id < 1 {
+		return nil, errors.New("core.SDKKeyRotate id must be positive integer")
+	}
+
+	var (
+		org    *types.Organization
+		app    *types.Application
+		sdkKey *types.SDKKey
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &appSlug)); err != nil {
+		return nil, err
+	}
+	if sdkKey, err = c.sdkKeyRepo.GetOne(ctx, org.ID, app.ID, id); err != nil {
+		return nil, err
+	}
+
+	key, keyPrefix, keyHash, err := generateSDKKey(sdkKey.KeyType)
+	if err != nil {
+		return nil, err
+	}
+
+	before := sdkKey
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if sdkKey, err = c.sdkKeyRepo.Rotate(ctx,
+			org.ID,
+			app.ID,
+			before.ID,
+			keyPrefix,
+			keyHash,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionUpdate,
+			types.AuditEntitySDKKey,
+			sdkKey.ID,
+			before,
+			sdkKey,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &types.SDKKeyWithSecret{SDKKey: *sdkKey, Key: key}, nil
+}
+
+func (c *Core) SDKKeyRevoke(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	id int64,
+) (*types.SDKKey, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id < 1 {
+		return nil, errors.New("core.SDKKeyRevoke id must be positive integer")
+	}
+
+	var (
+		org *types.Organization
+		app *types.Application
+	)
+	if org, err = c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug)); err != nil {
+		return nil, err
+	}
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+	if app, err = c.AppGetOne(ctx, c.NewAppGetOneArgs(org.Slug, nil, nil, &appSlug)); err != nil {
+		return nil, err
+	}
+
+	var before, sdkKey *types.SDKKey
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.sdkKeyRepo.GetOne(ctx, org.ID, app.ID, id); err != nil {
+			return err
+		}
+
+		if sdkKey, err = c.sdkKeyRepo.Revoke(ctx, org.ID, app.ID, id, tracer.AuthAccount.ID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionDelete,
+			types.AuditEntitySDKKey,
+			sdkKey.ID,
+			before,
+			sdkKey,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return sdkKey, nil
+}
+
+// AuthValidateSDKKey looks up an active SDK key by its plaintext value
+func (c *Core) AuthValidateSDKKey(ctx context.Context, key string) (*types.SDKKey, error) {
+	if !strings.HasPrefix(key, types.SDKKeyPrefix) {
+		return nil, errors.New("core.AuthValidateSDKKey invalid SDK key format")
+	}
+
+	sdkKey, err := c.sdkKeyRepo.GetByHash(ctx, hashSDKKey(key))
+	if err != nil {
+		return nil, fmt.Errorf("core.AuthValidateSDKKey: %w", err)
+	}
+
+	return sdkKey, nil
+}
+
+// authorizeSDKKey must be called by every operation that SDK keys are allowed
+// to perform. Operations performed with an SDK key are rejected by OrgGetOne
+// and AppGetOne unless the context was first passed through here, so anything
+// that does not opt in (account, org, or flag management) is denied.
+func (c *Core) authorizeSDKKey(ctx context.Context, keyTypes ...types.SDKKeyType) (context.Context, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	if tracer.SDKKey == nil {
+		return ctx, nil
+	}
+
+	if !slices.Contains(keyTypes, tracer.SDKKey.KeyType) {
+		return ctx, types.ErrOperationNotPermitted
+	}
+
+	return context.WithValue(ctx, ctxSDKKeyAuthorized, true), nil
+}
+
+// sdkKeyAuthorizeScope ensures an SDK key operation was opted in via
+// authorizeSDKKey and only touches the key's own org and application. A nil
+// appID only checks the org.
+func sdkKeyAuthorizeScope(ctx context.Context, tracer types.OperationTracer, orgID int64, appID *int64) error {
+	if tracer.SDKKey == nil {
+		return nil
+	}
+
+	if authorized, _ := ctx.Value(ctxSDKKeyAuthorized).(bool); !authorized {
+		return types.ErrOperationNotPermitted
+	}
+	if tracer.SDKKey.OrgID != orgID {
+		return types.ErrOperationNotPermitted
+	}
+	if appID != nil && tracer.SDKKey.ApplicationID != *appID {
+		return types.ErrOperationNotPermitted
+	}
+
+	return nil
+}
+
+// sdkKeyAuthorizeEnv ensures an SDK key only touches its own environment
+func sdkKeyAuthorizeEnv(tracer types.OperationTracer, envID int64) error {
+	if tracer.SDKKey != nil && tracer.SDKKey.EnvironmentID != envID {
+		return types.ErrOperationNotPermitted
+	}
+	return nil
+}
+
+func generateSDKKey(keyType types.SDKKeyType) (key string, keyPrefix string, keyHash string, err error) {
+	bytes, err := randomBytes(sdkKeyByteLength)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var (
+		typePrefix = fmt.Sprintf("%s%s_", types.SDKKeyPrefix, keyType)
+		secret     = base64.RawURLEncoding.EncodeToString(bytes)
+	)
+
+	key = typePrefix + secret
+	keyPrefix = typePrefix + secret[:sdkKeyDisplayLength]
+	keyHash = hashSDKKey(key)
+
+	return key, keyPrefix, keyHash, nil
+}
+
+// SDK keys are high entropy random values so a fast hash is sufficient and
+// allows the key to be looked up directly by its hash.
+func hashSDKKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,571 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"switchcraft/types"
+	"syscall"
+	"time"
+)
+
+// How long a webhook has to respond before the attempt counts as failed
+const webhookTimeout = 10 * time.Second
+
+// Deliveries are sent by the job queue, which backs off between attempts.
+// Giving up after 8 leaves roughly an hour between the first and the last.
+const (
+	webhookMaxAttempts     = 8
+	webhookDeliveryLogSize = 100
+	webhookSecretMinLength = 16
+)
+
+// Hosts reachable by name that webhooks may not be sent to, cloud metadata
+// endpoints resolve to addresses blocked by webhookAddrBlocked anyway
+var webhookBlockedHosts = []string{"localhost", "metadata.google.internal", "metadata.goog"}
+
+// Carrier-grade NAT, shared address space that is internal like RFC 1918
+var webhookCGNATPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+const (
+	webhookHeaderEvent     = "X-SwitchCraft-Event"
+	webhookHeaderDelivery  = "X-SwitchCraft-Delivery"
+	webhookHeaderSignature = "X-SwitchCraft-Signature"
+)
+
+type webhookCreateArgs struct {
+	orgSlug    string
+	url        string
+	secret     string
+	eventTypes []types.WebhookEventType
+	isEnabled  bool
+}
+
+func (a *webhookCreateArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("webhookCreateArgs.orgSlug cannot be empty")
+	}
+	if err := webhookURLValidate(a.url); err != nil {
+		return fmt.Errorf("webhookCreateArgs.%w", err)
+	}
+	if len(a.secret) < webhookSecretMinLength {
+		return fmt.Errorf("webhookCreateArgs.secret must be at least %d characters", webhookSecretMinLength)
+	}
+	if err := webhookEventTypesValidate(a.eventTypes); err != nil {
+		return fmt.Errorf("webhookCreateArgs.%w", err)
+	}
+	return nil
+}
+
+func (c *Core) NewWebhookCreateArgs(
+	orgSlug string,
+	url string,
+	secret string,
+	eventTypes []types.WebhookEventType,
+	isEnabled bool,
+) webhookCreateArgs {
+	if eventTypes == nil {
+		eventTypes = []types.WebhookEventType{}
+	}
+	return webhookCreateArgs{
+		orgSlug:    orgSlug,
+		url:        url,
+		secret:     secret,
+		eventTypes: eventTypes,
+		isEnabled:  isEnabled,
+	}
+}
+
+func (c *Core) WebhookCreate(ctx context.Context, args webhookCreateArgs) (*types.Webhook, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	var webhook *types.Webhook
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if webhook, err = c.webhookRepo.Create(ctx,
+			org.ID,
+			args.url,
+			args.secret,
+			args.eventTypes,
+			args.isEnabled,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionCreate,
+			types.AuditEntityWebhook,
+			webhook.ID,
+			nil,
+			webhook,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (c *Core) WebhookGetMany(ctx context.Context, orgSlug string) ([]types.Webhook, error) {
+	if orgSlug == "" {
+		return nil, errors.New("core.WebhookGetMany orgSlug cannot be empty")
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	return c.webhookRepo.GetMany(ctx, org.ID)
+}
+
+func (c *Core) WebhookGetOne(ctx context.Context, orgSlug string, id int64) (*types.Webhook, error) {
+	if orgSlug == "" {
+		return nil, errors.New("core.WebhookGetOne orgSlug cannot be empty")
+	}
+	if id < 1 {
+		return nil, errors.New("core.WebhookGetOne id must be positive integer")
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	return c.webhookRepo.GetOne(ctx, org.ID, id)
+}
+
+type webhookUpdateArgs struct {
+	orgSlug    string
+	id         int64
+	url        string
+	secret     string
+	eventTypes []types.WebhookEventType
+	isEnabled  bool
+}
+
+func (a *webhookUpdateArgs) Validate() error {
+	if a.orgSlug == "" {
+		return errors.New("webhookUpdateArgs.orgSlug cannot be empty")
+	}
+	if a.id < 1 {
+		return errors.New("webhookUpdateArgs.id must be positive integer")
+	}
+	if err := webhookURLValidate(a.url); err != nil {
+		return fmt.Errorf("webhookUpdateArgs.%w", err)
+	}
+	if a.secret != "" && len(a.secret) < webhookSecretMinLength {
+		return fmt.Errorf("webhookUpdateArgs.secret must be at least %d characters", webhookSecretMinLength)
+	}
+	if err := webhookEventTypesValidate(a.eventTypes); err != nil {
+		return fmt.Errorf("webhookUpdateArgs.%w", err)
+	}
+	return nil
+}
+
+// NewWebhookUpdateArgs keeps the webhook's current secret when secret is
+// empty
+func (c *Core) NewWebhookUpdateArgs(
+	orgSlug string,
+	id int64,
+	url string,
+	secret string,
+	eventTypes []types.WebhookEventType,
+	isEnabled bool,
+) webhookUpdateArgs {
+	if eventTypes == nil {
+		eventTypes = []types.WebhookEventType{}
+	}
+	return webhookUpdateArgs{
+		orgSlug:    orgSlug,
+		id:         id,
+		url:        url,
+		secret:     secret,
+		eventTypes: eventTypes,
+		isEnabled:  isEnabled,
+	}
+}
+
+// WebhookUpdate only affects events from here on, deliveries already queued
+// are still sent to the webhook's current URL
+func (c *Core) WebhookUpdate(ctx context.Context, args webhookUpdateArgs) (*types.Webhook, error) {
+	tracer, err := c.getOperationTracer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := args.Validate(); err != nil {
+		return nil, err
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &args.orgSlug))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	var before, webhook *types.Webhook
+	if err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if before, err = c.webhookRepo.GetOne(ctx, org.ID, args.id); err != nil {
+			return err
+		}
+
+		if webhook, err = c.webhookRepo.Update(ctx,
+			org.ID,
+			args.id,
+			args.url,
+			args.secret,
+			args.eventTypes,
+			args.isEnabled,
+			tracer.AuthAccount.ID,
+		); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionUpdate,
+			types.AuditEntityWebhook,
+			webhook.ID,
+			before,
+			webhook,
+		)
+	}); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// WebhookDelete also deletes the webhook's delivery log, including any
+// deliveries not yet sent
+func (c *Core) WebhookDelete(ctx context.Context, orgSlug string, id int64) error {
+	if orgSlug == "" {
+		return errors.New("core.WebhookDelete orgSlug cannot be empty")
+	}
+	if id < 1 {
+		return errors.New("core.WebhookDelete id must be positive integer")
+	}
+
+	org, err := c.OrgGetOne(ctx, c.NewOrgGetOneArgs(nil, nil, &orgSlug))
+	if err != nil {
+		return err
+	}
+
+	if err = c.authorizeOrgRole(ctx, org.ID, types.OrgRoleAdmin); err != nil {
+		return err
+	}
+
+	return c.repository.WithTx(ctx, func(ctx context.Context) error {
+		webhook, err := c.webhookRepo.GetOne(ctx, org.ID, id)
+		if err != nil {
+			return err
+		}
+
+		if err = c.webhookRepo.Delete(ctx, org.ID, webhook.ID); err != nil {
+			return err
+		}
+
+		return c.auditLog(ctx,
+			&org.ID,
+			types.AuditActionDelete,
+			types.AuditEntityWebhook,
+			webhook.ID,
+			webhook,
+			nil,
+		)
+	})
+}
+
+// WebhookDeliveryGetMany returns the latest deliveries of a webhook, newest
+// first
+func (c *Core) WebhookDeliveryGetMany(ctx context.Context,
+	orgSlug string,
+	webhookID int64,
+) ([]types.WebhookDelivery, error) {
+	webhook, err := c.WebhookGetOne(ctx, orgSlug, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.webhookRepo.DeliveryGetMany(ctx, webhook.OrgID, webhook.ID, webhookDeliveryLogSize)
+}
+
+// WebhookRedeliver queues an earlier delivery to be sent again as a new
+// delivery with a fresh set of attempts, the original is left as is
+func (c *Core) WebhookRedeliver(ctx context.Context,
+	orgSlug string,
+	webhookID int64,
+	deliveryID int64,
+) (*types.WebhookDelivery, error) {
+	if deliveryID < 1 {
+		return nil, errors.New("core.WebhookRedeliver deliveryID must be positive integer")
+	}
+
+	webhook, err := c.WebhookGetOne(ctx, orgSlug, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	var delivery *types.WebhookDelivery
+	err = c.repository.WithTx(ctx, func(ctx context.Context) error {
+		if delivery, err = c.webhookRepo.DeliveryRedeliver(ctx, webhook.OrgID, webhook.ID, deliveryID); err != nil {
+			return err
+		}
+
+		return c.jobEnqueue(ctx,
+			types.JobKindWebhookDeliver,
+			webhookDeliverJob{OrgID: delivery.OrgID, DeliveryID: delivery.ID},
+			webhookMaxAttempts,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+type webhookDeliverJob struct {
+	OrgID      int64 `json:"orgId"`
+	DeliveryID int64 `json:"deliveryId"`
+}
+
+// webhookEnqueue queues an event for the org's webhooks. It must be called
+// within the same WithTx as the change, so nothing is sent for changes that
+// are rolled back and deliveries only start once the transaction commits.
+func (c *Core) webhookEnqueue(ctx context.Context,
+	orgID int64,
+	eventType types.WebhookEventType,
+	data any,
+) error {
+	deliveryIDs, err := c.webhookRepo.DeliveryEnqueue(ctx, orgID, eventType, types.WebhookPayload{
+		EventType: eventType,
+		OrgID:     orgID,
+		Data:      data,
+		Created:   time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, deliveryID := range deliveryIDs {
+		if err = c.jobEnqueue(ctx,
+			types.JobKindWebhookDeliver,
+			webhookDeliverJob{OrgID: orgID, DeliveryID: deliveryID},
+			webhookMaxAttempts,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// webhookDeliver runs a delivery job, recording each attempt in the delivery
+// log. A failed send fails the job so the queue retries it, the delivery is
+// marked failed along with the job's last attempt.
+func (c *Core) webhookDeliver(ctx context.Context, job *types.Job) error {
+	var payload webhookDeliverJob
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return fmt.Errorf("core.webhookDeliver invalid payload: %w", err)
+	}
+
+	delivery, err := c.webhookRepo.DeliveryGetOne(ctx, payload.OrgID, payload.DeliveryID)
+	if errors.Is(err, types.ErrNotFound) {
+		// Deleted along with its webhook
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	webhook, err := c.webhookRepo.GetOne(ctx, delivery.OrgID, delivery.WebhookID)
+	if errors.Is(err, types.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var (
+		status         = types.WebhookDeliveryStatusDelivered
+		responseStatus *int
+		errMessage     *string
+		sendErr        error
+	)
+	if !webhook.IsEnabled {
+		message := "webhook is disabled"
+		status = types.WebhookDeliveryStatusFailed
+		errMessage = &message
+	} else if responseStatus, sendErr = c.webhookSend(ctx, webhook, *delivery); sendErr != nil {
+		message := sendErr.Error()
+		errMessage = &message
+
+		status = types.WebhookDeliveryStatusPending
+		if job.Attempts >= job.MaxAttempts {
+			status = types.WebhookDeliveryStatusFailed
+		}
+	}
+
+	if _, err = c.webhookRepo.DeliveryAttemptSet(ctx,
+		delivery.ID,
+		status,
+		responseStatus,
+		errMessage,
+	); err != nil {
+		return err
+	}
+
+	return sendErr
+}
+
+// webhookSend POSTs a delivery's payload to the webhook. The body is signed
+// with HMAC-SHA256 using the webhook's secret, sent hex encoded as
+// "sha256=<signature>". Any 2xx response counts as delivered.
+func (c *Core) webhookSend(ctx context.Context,
+	webhook *types.Webhook,
+	delivery types.WebhookDelivery,
+) (*int, error) {
+	mac := hmac.New(sha256.New, []byte(webhook.Secret))
+	mac.Write(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SwitchCraft-Webhook")
+	req.Header.Set(webhookHeaderEvent, string(delivery.EventType))
+	req.Header.Set(webhookHeaderDelivery, delivery.UUID)
+	req.Header.Set(webhookHeaderSignature, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	res, err := c.webhookClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Drained so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	status := res.StatusCode
+	if status < 200 || status > 299 {
+		return &status, fmt.Errorf("webhook responded with status %d", status)
+	}
+
+	return &status, nil
+}
+
+// newWebhookClient returns the client deliveries are sent with. Its dialer
+// refuses blocked addresses after DNS resolution, which also covers hosts
+// that resolve to them and redirects. Proxies are not used since they would
+// dial on its behalf.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: webhookDialControl,
+	}
+
+	return &http.Client{
+		Timeout: webhookTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: webhookTimeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+func webhookDialControl(_ string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if webhookAddrBlocked(addrPort.Addr()) {
+		return fmt.Errorf("webhook address %s is not allowed", addrPort.Addr())
+	}
+	return nil
+}
+
+// webhookAddrBlocked reports whether webhooks may not be sent to an address:
+// anything but public unicast. That covers loopback, private (RFC 1918 and
+// IPv6 ULA, which includes the AWS IPv6 metadata endpoint), carrier-grade
+// NAT, link-local (which includes the other cloud metadata endpoints),
+// unspecified and multicast addresses.
+func webhookAddrBlocked(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	return addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		webhookCGNATPrefix.Contains(addr) ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified()
+}
+
+func webhookURLValidate(webhookURL string) error {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	for _, blocked := range webhookBlockedHosts {
+		if host == blocked || strings.HasSuffix(host, "."+blocked) {
+			return fmt.Errorf("url host '%s' is not allowed", host)
+		}
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && webhookAddrBlocked(addr) {
+		return fmt.Errorf("url host '%s' is not allowed", host)
+	}
+
+	return nil
+}
+
+func webhookEventTypesValidate(eventTypes []types.WebhookEventType) error {
+	for _, eventType := range eventTypes {
+		if !eventType.IsValid() {
+			return fmt.Errorf("eventTypes '%s' is not a webhook event", eventType)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,471 @@
+package core
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"switchcraft/types"
+	"sync"
+	"testing"
+	"time"
+)
+
+const webhookTestSecret = "0123456789abcdef"
+
+// webhookTestReceiver records every request and responds with the status
+// returned by respond for the attempt, starting at 1
+type webhookTestReceiver struct {
+	mu       sync.Mutex
+	bodies   [][]byte
+	headers  []http.Header
+	respond  func(attempt int) int
+	received int
+}
+
+func (r *webhookTestReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	r.received++
+	r.bodies = append(r.bodies, body)
+	r.headers = append(r.headers, req.Header.Clone())
+	status := r.respond(r.received)
+	r.mu.Unlock()
+
+	w.WriteHeader(status)
+}
+
+type webhookTestTxRepo struct{ Repo }
+
+func (webhookTestTxRepo) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type webhookTestOrgRepo struct {
+	OrgRepo
+	org types.Organization
+}
+
+func (r *webhookTestOrgRepo) GetOne(_ context.Context, _ *int64, _ *string, _ *string) (*types.Organization, error) {
+	org := r.org
+	return &org, nil
+}
+
+// webhookTestRepo keeps a single webhook and its deliveries in memory
+type webhookTestRepo struct {
+	WebhookRepo
+	webhook    types.Webhook
+	deliveries []*types.WebhookDelivery
+}
+
+func (r *webhookTestRepo) GetOne(_ context.Context, orgID int64, id int64) (*types.Webhook, error) {
+	if orgID != r.webhook.OrgID || id != r.webhook.ID {
+		return nil, types.ErrNotFound
+	}
+	webhook := r.webhook
+	return &webhook, nil
+}
+
+func (r *webhookTestRepo) DeliveryEnqueue(_ context.Context,
+	orgID int64,
+	eventType types.WebhookEventType,
+	payload any,
+) ([]int64, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	delivery := r.deliveryAdd(orgID, eventType, raw)
+	return []int64{delivery.ID}, nil
+}
+
+func (r *webhookTestRepo) DeliveryGetOne(_ context.Context, orgID int64, id int64) (*types.WebhookDelivery, error) {
+	for _, delivery := range r.deliveries {
+		if delivery.OrgID == orgID && delivery.ID == id {
+			copied := *delivery
+			return &copied, nil
+		}
+	}
+	return nil, types.ErrNotFound
+}
+
+func (r *webhookTestRepo) DeliveryAttemptSet(_ context.Context,
+	id int64,
+	status types.WebhookDeliveryStatus,
+	responseStatus *int,
+	errMessage *string,
+) (*types.WebhookDelivery, error) {
+	delivery := r.deliveries[id-1]
+	delivery.Status = status
+	delivery.Attempts++
+	delivery.ResponseStatus = responseStatus
+	delivery.Error = errMessage
+	copied := *delivery
+	return &copied, nil
+}
+
+func (r *webhookTestRepo) DeliveryRedeliver(_ context.Context,
+	orgID int64,
+	webhookID int64,
+	id int64,
+) (*types.WebhookDelivery, error) {
+	original := r.deliveries[id-1]
+	if original.OrgID != orgID || original.WebhookID != webhookID {
+		return nil, types.ErrNotFound
+	}
+	delivery := r.deliveryAdd(orgID, original.EventType, original.Payload)
+	copied := *delivery
+	return &copied, nil
+}
+
+func (r *webhookTestRepo) deliveryAdd(orgID int64, eventType types.WebhookEventType, payload []byte) *types.WebhookDelivery {
+	delivery := &types.WebhookDelivery{
+		OrgID:     orgID,
+		WebhookID: r.webhook.ID,
+		ID:        int64(len(r.deliveries) + 1),
+		UUID:      fmt.Sprintf("00000000-0000-4000-8000-%012d", len(r.deliveries)+1),
+		EventType: eventType,
+		Payload:   payload,
+		Status:    types.WebhookDeliveryStatusPending,
+		Created:   time.Now(),
+	}
+	r.deliveries = append(r.deliveries, delivery)
+	return delivery
+}
+
+// webhookTestJobRepo keeps jobs in memory, the test claims them by hand
+type webhookTestJobRepo struct {
+	JobRepo
+	jobs []*types.Job
+}
+
+func (r *webhookTestJobRepo) Create(_ context.Context,
+	kind types.JobKind,
+	payload any,
+	maxAttempts int,
+) (*types.Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	job := &types.Job{
+		ID:          int64(len(r.jobs) + 1),
+		Kind:        kind,
+		Payload:     raw,
+		Status:      types.JobStatusPending,
+		MaxAttempts: maxAttempts,
+		RunAt:       time.Now(),
+		Created:     time.Now(),
+	}
+	r.jobs = append(r.jobs, job)
+	return job, nil
+}
+
+func (r *webhookTestJobRepo) ResultSet(_ context.Context,
+	id int64,
+	attempt int,
+	status types.JobStatus,
+	runAt *time.Time,
+	errMessage *string,
+) (*types.Job, error) {
+	job := r.jobs[id-1]
+	if job.Status != types.JobStatusRunning || job.Attempts != attempt {
+		return nil, types.ErrNotFound
+	}
+	job.Status = status
+	job.LastError = errMessage
+	if runAt != nil {
+		job.RunAt = *runAt
+	}
+	return job, nil
+}
+
+type webhookTest struct {
+	core     *Core
+	receiver *webhookTestReceiver
+	webhooks *webhookTestRepo
+	jobs     *webhookTestJobRepo
+}
+
+func newWebhookTest(t *testing.T, respond func(attempt int) int) *webhookTest {
+	t.Helper()
+
+	receiver := &webhookTestReceiver{respond: respond}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	webhooks := &webhookTestRepo{webhook: types.Webhook{
+		OrgID:     1,
+		ID:        1,
+		URL:       server.URL + "/hooks",
+		Secret:    webhookTestSecret,
+		IsEnabled: true,
+	}}
+	jobs := &webhookTestJobRepo{}
+
+	return &webhookTest{
+		core: &Core{
+			logger:      types.NewLogger(types.LogLevelError),
+			repository:  webhookTestTxRepo{},
+			orgRepo:     &webhookTestOrgRepo{org: types.Organization{ID: 1, Slug: "my-org"}},
+			webhookRepo: webhooks,
+			// The receiver listens on loopback, which the default client refuses
+			webhookClient: server.Client(),
+			jobRepo:       jobs,
+		},
+		receiver: receiver,
+		webhooks: webhooks,
+		jobs:     jobs,
+	}
+}
+
+func webhookTestCtx() context.Context {
+	return types.NewOperationCtx(context.Background(), "", time.Now(), types.Account{ID: 1, IsInstanceAdmin: true})
+}
+
+// enqueue queues an event for the webhook, returning its delivery job
+func (wt *webhookTest) enqueue(t *testing.T) *types.Job {
+	t.Helper()
+
+	if err := wt.core.webhookEnqueue(webhookTestCtx(), 1, types.WebhookEventFlagUpdated, map[string]any{"flagId": 7}); err != nil {
+		t.Fatalf("webhookEnqueue: %v", err)
+	}
+	return wt.jobs.jobs[len(wt.jobs.jobs)-1]
+}
+
+// run claims and runs a job once, the way jobWork does
+func (wt *webhookTest) run(job *types.Job) {
+	job.Attempts++
+	job.Status = types.JobStatusRunning
+	wt.core.jobRun(context.Background(), types.OperationTracer{TraceID: "test"}, job)
+}
+
+func TestWebhookSendSignsBody(t *testing.T) {
+	wt := newWebhookTest(t, func(int) int { return http.StatusNoContent })
+	job := wt.enqueue(t)
+	wt.run(job)
+
+	if wt.receiver.received != 1 {
+		t.Fatalf("expected 1 request, got %d", wt.receiver.received)
+	}
+
+	body, header := wt.receiver.bodies[0], wt.receiver.headers[0]
+	delivery := wt.webhooks.deliveries[0]
+	if string(body) != string(delivery.Payload) {
+		t.Errorf("body %s does not match the delivery payload %s", body, delivery.Payload)
+	}
+
+	mac := hmac.New(sha256.New, []byte(webhookTestSecret))
+	mac.Write(body)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); header.Get(webhookHeaderSignature) != want {
+		t.Errorf("signature %q, want %q", header.Get(webhookHeaderSignature), want)
+	}
+	if header.Get(webhookHeaderEvent) != string(types.WebhookEventFlagUpdated) {
+		t.Errorf("event header %q", header.Get(webhookHeaderEvent))
+	}
+	if header.Get(webhookHeaderDelivery) != delivery.UUID {
+		t.Errorf("delivery header %q, want %q", header.Get(webhookHeaderDelivery), delivery.UUID)
+	}
+
+	if delivery.Status != types.WebhookDeliveryStatusDelivered || *delivery.ResponseStatus != http.StatusNoContent {
+		t.Errorf("delivery %s with response %d, want delivered with 204", delivery.Status, *delivery.ResponseStatus)
+	}
+	if job.Status != types.JobStatusSucceeded {
+		t.Errorf("job %s, want succeeded", job.Status)
+	}
+}
+
+func TestWebhookDeliverRetriesNon2xx(t *testing.T) {
+	wt := newWebhookTest(t, func(attempt int) int {
+		if attempt < 3 {
+			return http.StatusInternalServerError
+		}
+		return http.StatusOK
+	})
+	job := wt.enqueue(t)
+	delivery := wt.webhooks.deliveries[0]
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		wt.run(job)
+
+		if job.Status != types.JobStatusPending || !job.RunAt.After(time.Now()) {
+			t.Fatalf("attempt %d: job %s due %v, want pending and backed off", attempt, job.Status, job.RunAt)
+		}
+		if delivery.Status != types.WebhookDeliveryStatusPending || *delivery.ResponseStatus != http.StatusInternalServerError {
+			t.Fatalf("attempt %d: delivery %s, want pending after a 500", attempt, delivery.Status)
+		}
+	}
+
+	wt.run(job)
+	if job.Status != types.JobStatusSucceeded {
+		t.Errorf("job %s, want succeeded", job.Status)
+	}
+	if delivery.Status != types.WebhookDeliveryStatusDelivered || delivery.Attempts != 3 {
+		t.Errorf("delivery %s after %d attempts, want delivered after 3", delivery.Status, delivery.Attempts)
+	}
+
+	for i := 1; i < len(wt.receiver.bodies); i++ {
+		if string(wt.receiver.bodies[i]) != string(wt.receiver.bodies[0]) {
+			t.Errorf("attempt %d sent a different body", i+1)
+		}
+	}
+}
+
+func TestJobRunLeavesReclaimedJobAlone(t *testing.T) {
+	wt := newWebhookTest(t, func(int) int { return http.StatusNoContent })
+	job := wt.enqueue(t)
+
+	// The first worker's lock ran out and a second worker claimed the job
+	// before the first one finished
+	stale := *job
+	stale.Attempts = 1
+	stale.Status = types.JobStatusRunning
+	job.Attempts = 2
+	job.Status = types.JobStatusRunning
+
+	wt.core.jobRun(context.Background(), types.OperationTracer{TraceID: "test"}, &stale)
+
+	if job.Status != types.JobStatusRunning || job.Attempts != 2 {
+		t.Errorf("job %s on attempt %d, want still running on attempt 2", job.Status, job.Attempts)
+	}
+}
+
+func TestWebhookDeliverFailsAfterLastAttempt(t *testing.T) {
+	wt := newWebhookTest(t, func(int) int { return http.StatusServiceUnavailable })
+	job := wt.enqueue(t)
+	delivery := wt.webhooks.deliveries[0]
+
+	for attempt := 1; attempt < webhookMaxAttempts; attempt++ {
+		wt.run(job)
+		if delivery.Status != types.WebhookDeliveryStatusPending {
+			t.Fatalf("attempt %d: delivery %s, want pending", attempt, delivery.Status)
+		}
+	}
+
+	wt.run(job)
+	if delivery.Status != types.WebhookDeliveryStatusFailed || delivery.Attempts != webhookMaxAttempts {
+		t.Errorf("delivery %s after %d attempts, want failed after %d", delivery.Status, delivery.Attempts, webhookMaxAttempts)
+	}
+	if job.Status != types.JobStatusDead {
+		t.Errorf("job %s, want dead", job.Status)
+	}
+	if wt.receiver.received != webhookMaxAttempts {
+		t.Errorf("receiver got %d requests, want %d", wt.receiver.received, webhookMaxAttempts)
+	}
+}
+
+func TestWebhookRedeliver(t *testing.T) {
+	failing := true
+	wt := newWebhookTest(t, func(int) int {
+		if failing {
+			return http.StatusBadGateway
+		}
+		return http.StatusOK
+	})
+	job := wt.enqueue(t)
+	for i := 0; i < webhookMaxAttempts; i++ {
+		wt.run(job)
+	}
+	original := wt.webhooks.deliveries[0]
+	if original.Status != types.WebhookDeliveryStatusFailed {
+		t.Fatalf("delivery %s, want failed", original.Status)
+	}
+
+	failing = false
+	redelivery, err := wt.core.WebhookRedeliver(webhookTestCtx(), "my-org", 1, original.ID)
+	if err != nil {
+		t.Fatalf("WebhookRedeliver: %v", err)
+	}
+	if redelivery.ID == original.ID || redelivery.Status != types.WebhookDeliveryStatusPending {
+		t.Fatalf("redelivery %d %s, want a new pending delivery", redelivery.ID, redelivery.Status)
+	}
+
+	redeliverJob := wt.jobs.jobs[len(wt.jobs.jobs)-1]
+	if redeliverJob == job || redeliverJob.MaxAttempts != webhookMaxAttempts {
+		t.Fatalf("expected a new job with a fresh set of %d attempts", webhookMaxAttempts)
+	}
+	wt.run(redeliverJob)
+
+	delivered := wt.webhooks.deliveries[redelivery.ID-1]
+	if delivered.Status != types.WebhookDeliveryStatusDelivered || delivered.Attempts != 1 {
+		t.Errorf("redelivery %s after %d attempts, want delivered after 1", delivered.Status, delivered.Attempts)
+	}
+	if original.Status != types.WebhookDeliveryStatusFailed {
+		t.Errorf("original delivery %s, want it left failed", original.Status)
+	}
+	if last := wt.receiver.bodies[len(wt.receiver.bodies)-1]; string(last) != string(original.Payload) {
+		t.Errorf("redelivered body %s, want the original payload %s", last, original.Payload)
+	}
+}
+
+func TestWebhookURLValidate(t *testing.T) {
+	tests := []struct {
+		url     string
+		allowed bool
+	}{
+		{"https://example.com/hooks", true},
+		{"http://93.184.215.14/hooks", true},
+		{"http://[2606:4700::1111]/hooks", true},
+		{"http://100.63.255.255/hooks", true},
+		{"http://100.128.0.1/hooks", true},
+		{"ftp://example.com/hooks", false},
+		{"/hooks", false},
+		{"http://localhost:8080/hooks", false},
+		{"http://api.localhost/hooks", false},
+		{"http://LOCALHOST./hooks", false},
+		{"http://127.0.0.1/hooks", false},
+		{"http://[::1]/hooks", false},
+		{"http://[::ffff:127.0.0.1]/hooks", false},
+		{"http://0.0.0.0/hooks", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://[fe80::1]/hooks", false},
+		{"http://[fd00:ec2::254]/latest/meta-data", false},
+		{"http://metadata.google.internal/computeMetadata/v1", false},
+		{"http://10.0.0.5:8080/hooks", false},
+		{"http://172.16.0.1/hooks", false},
+		{"http://172.31.255.254/hooks", false},
+		{"http://192.168.1.10/hooks", false},
+		{"http://[::ffff:192.168.1.10]/hooks", false},
+		{"http://[fc00::1]/hooks", false},
+		{"http://[fd12:3456:789a::1]/hooks", false},
+		{"http://100.64.0.1/hooks", false},
+		{"http://100.127.255.254/hooks", false},
+	}
+
+	for _, test := range tests {
+		err := webhookURLValidate(test.url)
+		if test.allowed && err != nil {
+			t.Errorf("%s: unexpected error %v", test.url, err)
+		}
+		if !test.allowed && err == nil {
+			t.Errorf("%s: expected to be rejected", test.url)
+		}
+	}
+}
+
+func TestWebhookClientRefusesLoopback(t *testing.T) {
+	receiver := &webhookTestReceiver{respond: func(int) int { return http.StatusOK }}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	// Sent straight to the client, as for a host that resolves to loopback
+	// or a redirect there, so only the dialer can refuse it
+	res, err := newWebhookClient().Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err == nil {
+		res.Body.Close()
+		t.Fatal("expected the request to a loopback address to be refused")
+	}
+	if !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("unexpected error %v", err)
+	}
+	if receiver.received != 0 {
+		t.Errorf("receiver got %d requests, want none", receiver.received)
+	}
+}
@@ -38,9 +38,17 @@ func main() {
 		globalAccountRepo = repository.NewGlobalAccountRepository(logger, db)
 		orgAccountRepo    = repository.NewOrgAccountRepository(logger, db)
 		orgGroupRepo      = repository.NewOrgGroupRepository(logger, db)
+		orgSegmentRepo    = repository.NewOrgSegmentRepository(logger, db)
 		orgRepo           = repository.NewOrgRepository(logger, db)
 		applicationRepo   = repository.NewAppRepository(logger, db)
+		environmentRepo   = repository.NewEnvironmentRepository(logger, db)
 		featureFlagRepo   = repository.NewFeatureFlagRepository(logger, db)
+		sdkKeyRepo        = repository.NewSDKKeyRepository(logger, db)
+		flagEventRepo     = repository.NewFlagEventRepository(logger, db)
+		flagScheduleRepo  = repository.NewFlagScheduleRepository(logger, db)
+		auditLogRepo      = repository.NewAuditLogRepository(logger, db)
+		webhookRepo       = repository.NewWebhookRepository(logger, db)
+		jobRepo           = repository.NewJobRepository(logger, db)
 	)
 
 	switchcraft := core.NewCore(
@@ -49,9 +57,17 @@ func main() {
 		globalAccountRepo,
 		orgAccountRepo,
 		orgGroupRepo,
+		orgSegmentRepo,
 		orgRepo,
 		applicationRepo,
+		environmentRepo,
 		featureFlagRepo,
+		sdkKeyRepo,
+		flagEventRepo,
+		flagScheduleRepo,
+		auditLogRepo,
+		webhookRepo,
+		jobRepo,
 		jwtSigningKeyBytes,
 	)
 
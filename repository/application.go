@@ -34,7 +34,7 @@ func (r *appRepo) Create(ctx context.Context,
 		err         error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.AppCreate,
 		orgID,
 		name,
@@ -63,7 +63,7 @@ func (r *appRepo) GetMany(ctx context.Context,
 		err          error
 	)
 
-	if rows, err = r.db.Query(ctx, queries.AppGetMany, orgID); err != nil {
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.AppGetMany, orgID); err != nil {
 		return nil, handleError(ctx, r.logger, err)
 	}
 
@@ -89,7 +89,7 @@ func (r *appRepo) GetOne(ctx context.Context,
 		err         error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.AppGetOne,
 		orgID,
 		id,
@@ -122,7 +122,7 @@ func (r *appRepo) Update(ctx context.Context,
 		err         error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.AppUpdate,
 		orgID,
 		id,
@@ -147,7 +147,7 @@ func (r *appRepo) Delete(ctx context.Context,
 	orgID int64,
 	id int64,
 ) error {
-	row := r.db.QueryRow(ctx,
+	row := getConn(ctx, r.db).QueryRow(ctx,
 		queries.AppDelete,
 		orgID,
 		id,
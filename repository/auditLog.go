@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"switchcraft/repository/queries"
+	"switchcraft/types"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewAuditLogRepository(logger *types.Logger, db *pgxpool.Pool) *auditLogRepo {
+	return &auditLogRepo{
+		logger: logger,
+		db:     db,
+	}
+}
+
+type auditLogRepo struct {
+	logger *types.Logger
+	db     *pgxpool.Pool
+}
+
+func (r *auditLogRepo) Create(ctx context.Context,
+	orgID *int64,
+	actorID *int64,
+	traceID string,
+	action types.AuditAction,
+	entityType types.AuditEntityType,
+	entityID int64,
+	before any,
+	after any,
+) (*types.AuditLogEntry, error) {
+	var (
+		entry types.AuditLogEntry
+		rows  pgx.Rows
+		err   error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.AuditLogCreate,
+		orgID,
+		actorID,
+		traceID,
+		action,
+		entityType,
+		entityID,
+		before,
+		after,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if entry, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.AuditLogEntry],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &entry, nil
+}
+
+func (r *auditLogRepo) GetMany(ctx context.Context,
+	orgID int64,
+	entityType *types.AuditEntityType,
+	entityID *int64,
+	actorID *int64,
+	from *time.Time,
+	to *time.Time,
+	limit int,
+) ([]types.AuditLogEntry, error) {
+	var (
+		entries []types.AuditLogEntry
+		rows    pgx.Rows
+		err     error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.AuditLogGetMany,
+		orgID,
+		entityType,
+		entityID,
+		actorID,
+		from,
+		to,
+		limit,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if entries, err = pgx.CollectRows(
+		rows,
+		pgx.RowToStructByName[types.AuditLogEntry],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"switchcraft/repository/queries"
+	"switchcraft/types"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewEnvironmentRepository(logger *types.Logger, db *pgxpool.Pool) *environmentRepo {
+	return &environmentRepo{
+		logger: logger,
+		db:     db,
+	}
+}
+
+type environmentRepo struct {
+	logger *types.Logger
+	db     *pgxpool.Pool
+}
+
+func (r *environmentRepo) Create(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	name string,
+	slug string,
+	createdBy int64,
+) (*types.Environment, error) {
+	var (
+		environment types.Environment
+		rows        pgx.Rows
+		err         error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.EnvironmentCreate,
+		orgID,
+		applicationID,
+		name,
+		slug,
+		createdBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if environment, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.Environment],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &environment, nil
+}
+
+func (r *environmentRepo) GetMany(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+) ([]types.Environment, error) {
+	var (
+		environments []types.Environment
+		rows         pgx.Rows
+		err          error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.EnvironmentGetMany,
+		orgID,
+		applicationID,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if environments, err = pgx.CollectRows(
+		rows,
+		pgx.RowToStructByName[types.Environment],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return environments, nil
+}
+
+func (r *environmentRepo) GetOne(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	id *int64,
+	uuid *string,
+	slug *string,
+) (*types.Environment, error) {
+	var (
+		environment types.Environment
+		rows        pgx.Rows
+		err         error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.EnvironmentGetOne,
+		orgID,
+		applicationID,
+		id,
+		uuid,
+		slug,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if environment, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.Environment],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &environment, nil
+}
+
+func (r *environmentRepo) Update(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	id int64,
+	name string,
+	slug string,
+	modifiedBy int64,
+) (*types.Environment, error) {
+	var (
+		environment types.Environment
+		rows        pgx.Rows
+		err         error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.EnvironmentUpdate,
+		orgID,
+		applicationID,
+		id,
+		name,
+		slug,
+		modifiedBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if environment, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.Environment],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &environment, nil
+}
+
+func (r *environmentRepo) Delete(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	id int64,
+) error {
+	row := getConn(ctx, r.db).QueryRow(ctx,
+		queries.EnvironmentDelete,
+		orgID,
+		applicationID,
+		id,
+	)
+
+	var numDeleted int64
+	if err := row.Scan(&numDeleted); err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	if numDeleted < 1 {
+		return types.ErrNotFound
+	}
+
+	if numDeleted > 1 {
+		return fmt.Errorf("expected to delete 1 row, deleted %v", numDeleted)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"switchcraft/repository/queries"
 	"switchcraft/types"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -25,10 +26,18 @@ type featureFlagRepo struct {
 func (r *featureFlagRepo) Create(ctx context.Context,
 	orgID int64,
 	applicationID int64,
+	environmentID int64,
 	name string,
 	label string,
 	description string,
 	isEnabled bool,
+	rolloutPercentage *int,
+	flagType types.FeatureFlagType,
+	variants []types.FeatureFlagVariant,
+	defaultVariant *string,
+	rules []types.TargetingRule,
+	prerequisites []types.FeatureFlagPrerequisite,
+	expiresAt *time.Time,
 	createdBy int64,
 ) (*types.FeatureFlag, error) {
 	var (
@@ -37,14 +46,22 @@ func (r *featureFlagRepo) Create(ctx context.Context,
 		err         error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.FeatureFlagCreate,
 		orgID,
 		applicationID,
+		environmentID,
 		name,
 		label,
 		description,
 		isEnabled,
+		rolloutPercentage,
+		flagType,
+		variants,
+		defaultVariant,
+		rules,
+		prerequisites,
+		expiresAt,
 		createdBy,
 	); err != nil {
 		return nil, handleError(ctx, r.logger, err)
@@ -63,6 +80,7 @@ func (r *featureFlagRepo) Create(ctx context.Context,
 func (r *featureFlagRepo) GetMany(ctx context.Context,
 	orgID int64,
 	applicationID int64,
+	environmentID int64,
 ) ([]types.FeatureFlag, error) {
 	var (
 		featureFlags []types.FeatureFlag
@@ -70,10 +88,11 @@ func (r *featureFlagRepo) GetMany(ctx context.Context,
 		err          error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.FeatureFlagGetMany,
 		orgID,
 		applicationID,
+		environmentID,
 	); err != nil {
 		return nil, handleError(ctx, r.logger, err)
 	}
@@ -88,9 +107,68 @@ func (r *featureFlagRepo) GetMany(ctx context.Context,
 	return featureFlags, nil
 }
 
+// GetManyForAccount loads every flag in an application environment along
+// with the group overrides that apply to the given account in a single round
+// trip. The org, application, environment, and account are resolved inside
+// the query, the environment by ID or slug, and returned as the scope to
+// authorize. ErrNotFound is returned unless all of them exist and the account
+// belongs to the org.
+func (r *featureFlagRepo) GetManyForAccount(ctx context.Context,
+	orgSlug string,
+	appSlug string,
+	environmentID *int64,
+	envSlug string,
+	accountID int64,
+) (*types.FlagEvaluationScope, []types.FeatureFlag, []types.OrgGroupFeatureFlag, error) {
+	type flagWithGroupFlags struct {
+		types.FeatureFlag
+		GroupFlags []types.OrgGroupFeatureFlag `json:"groupFlags"`
+	}
+	type scopeWithFlags struct {
+		types.FlagEvaluationScope
+		Flags []flagWithGroupFlags `db:"flags"`
+	}
+
+	var (
+		result scopeWithFlags
+		rows   pgx.Rows
+		err    error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.FeatureFlagGetManyForAccount,
+		orgSlug,
+		appSlug,
+		environmentID,
+		envSlug,
+		accountID,
+	); err != nil {
+		return nil, nil, nil, handleError(ctx, r.logger, err)
+	}
+
+	if result, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[scopeWithFlags],
+	); err != nil {
+		return nil, nil, nil, handleError(ctx, r.logger, err)
+	}
+
+	var (
+		flags      = make([]types.FeatureFlag, len(result.Flags))
+		groupFlags []types.OrgGroupFeatureFlag
+	)
+	for i, flag := range result.Flags {
+		flags[i] = flag.FeatureFlag
+		groupFlags = append(groupFlags, flag.GroupFlags...)
+	}
+
+	return &result.FlagEvaluationScope, flags, groupFlags, nil
+}
+
 func (r *featureFlagRepo) GetOne(ctx context.Context,
 	orgID int64,
 	applicationID int64,
+	environmentID int64,
 	id *int64,
 	uuid *string,
 	name *string,
@@ -101,10 +179,11 @@ func (r *featureFlagRepo) GetOne(ctx context.Context,
 		err         error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.FeatureFlagGetOne,
 		orgID,
 		applicationID,
+		environmentID,
 		id,
 		uuid,
 		name,
@@ -125,11 +204,19 @@ func (r *featureFlagRepo) GetOne(ctx context.Context,
 func (r *featureFlagRepo) Update(ctx context.Context,
 	orgID int64,
 	applicationID int64,
+	environmentID int64,
 	id int64,
 	name string,
 	label string,
 	description string,
 	isEnabled bool,
+	rolloutPercentage *int,
+	flagType types.FeatureFlagType,
+	variants []types.FeatureFlagVariant,
+	defaultVariant *string,
+	rules []types.TargetingRule,
+	prerequisites []types.FeatureFlagPrerequisite,
+	expiresAt *time.Time,
 	modifiedBy int64,
 ) (*types.FeatureFlag, error) {
 	var (
@@ -138,15 +225,23 @@ func (r *featureFlagRepo) Update(ctx context.Context,
 		err         error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.FeatureFlagUpdate,
 		orgID,
 		applicationID,
+		environmentID,
 		id,
 		name,
 		label,
 		description,
 		isEnabled,
+		rolloutPercentage,
+		flagType,
+		variants,
+		defaultVariant,
+		rules,
+		prerequisites,
+		expiresAt,
 		modifiedBy,
 	); err != nil {
 		return nil, handleError(ctx, r.logger, err)
@@ -167,7 +262,7 @@ func (r *featureFlagRepo) Delete(ctx context.Context,
 	applicationID int64,
 	id int64,
 ) error {
-	row := r.db.QueryRow(ctx,
+	row := getConn(ctx, r.db).QueryRow(ctx,
 		queries.FeatureFlagDelete,
 		orgID,
 		applicationID,
@@ -190,12 +285,216 @@ func (r *featureFlagRepo) Delete(ctx context.Context,
 	return nil
 }
 
+// PrerequisiteRemove drops a flag from the prerequisites of every flag in the
+// application that depends on it
+func (r *featureFlagRepo) PrerequisiteRemove(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	flagID int64,
+	modifiedBy int64,
+) error {
+	if _, err := getConn(ctx, r.db).Exec(ctx,
+		queries.FeatureFlagPrerequisiteRemove,
+		orgID,
+		applicationID,
+		flagID,
+		modifiedBy,
+	); err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	return nil
+}
+
+func (r *featureFlagRepo) LifecycleUpdate(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	id int64,
+	lifecycle types.FeatureFlagLifecycle,
+	modifiedBy int64,
+) error {
+	tag, err := getConn(ctx, r.db).Exec(ctx,
+		queries.FeatureFlagLifecycleUpdate,
+		orgID,
+		applicationID,
+		id,
+		lifecycle,
+		modifiedBy,
+	)
+	if err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	if tag.RowsAffected() < 1 {
+		return types.ErrNotFound
+	}
+
+	return nil
+}
+
+// StaleGetMany returns what the stale flag report needs to know about every
+// flag of an application that is not archived, by name
+func (r *featureFlagRepo) StaleGetMany(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+) ([]types.StaleFeatureFlag, error) {
+	var (
+		flags []types.StaleFeatureFlag
+		rows  pgx.Rows
+		err   error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.FeatureFlagGetManyStale,
+		orgID,
+		applicationID,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if flags, err = pgx.CollectRows(
+		rows,
+		pgx.RowToStructByName[types.StaleFeatureFlag],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return flags, nil
+}
+
+func (r *featureFlagRepo) VersionCreate(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+	flagID int64,
+	name string,
+	label string,
+	description string,
+	isEnabled bool,
+	rolloutPercentage *int,
+	flagType types.FeatureFlagType,
+	variants []types.FeatureFlagVariant,
+	defaultVariant *string,
+	rules []types.TargetingRule,
+	prerequisites []types.FeatureFlagPrerequisite,
+	groupFlags []types.FeatureFlagVersionGroupFlag,
+	restoredFrom *int,
+	createdBy int64,
+) (*types.FeatureFlagVersion, error) {
+	var (
+		version types.FeatureFlagVersion
+		rows    pgx.Rows
+		err     error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.FeatureFlagVersionCreate,
+		orgID,
+		applicationID,
+		environmentID,
+		flagID,
+		name,
+		label,
+		description,
+		isEnabled,
+		rolloutPercentage,
+		flagType,
+		variants,
+		defaultVariant,
+		rules,
+		prerequisites,
+		groupFlags,
+		restoredFrom,
+		createdBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if version, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.FeatureFlagVersion],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &version, nil
+}
+
+func (r *featureFlagRepo) VersionsGetMany(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+	flagID int64,
+) ([]types.FeatureFlagVersion, error) {
+	var (
+		versions []types.FeatureFlagVersion
+		rows     pgx.Rows
+		err      error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.FeatureFlagVersionGetMany,
+		orgID,
+		applicationID,
+		environmentID,
+		flagID,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if versions, err = pgx.CollectRows(
+		rows,
+		pgx.RowToStructByName[types.FeatureFlagVersion],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return versions, nil
+}
+
+func (r *featureFlagRepo) VersionGetOne(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+	flagID int64,
+	version int,
+) (*types.FeatureFlagVersion, error) {
+	var (
+		flagVersion types.FeatureFlagVersion
+		rows        pgx.Rows
+		err         error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.FeatureFlagVersionGetOne,
+		orgID,
+		applicationID,
+		environmentID,
+		flagID,
+		version,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if flagVersion, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.FeatureFlagVersion],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &flagVersion, nil
+}
+
 func (r *featureFlagRepo) GroupFlagCreate(ctx context.Context,
 	orgID int64,
 	groupID int64,
 	appID int64,
+	environmentID int64,
 	flagID int64,
 	isEnabled bool,
+	rolloutPercentage *int,
+	variant *string,
 	createdBy int64,
 ) (*types.OrgGroupFeatureFlag, error) {
 	var (
@@ -204,13 +503,16 @@ func (r *featureFlagRepo) GroupFlagCreate(ctx context.Context,
 		err       error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgGroupFeatureFlagCreate,
 		orgID,
 		groupID,
 		appID,
+		environmentID,
 		flagID,
 		isEnabled,
+		rolloutPercentage,
+		variant,
 		createdBy,
 	); err != nil {
 		return nil, handleError(ctx, r.logger, err)
@@ -226,9 +528,40 @@ func (r *featureFlagRepo) GroupFlagCreate(ctx context.Context,
 	return &groupFlag, nil
 }
 
+func (r *featureFlagRepo) GroupFlagsGetMany(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+) ([]types.OrgGroupFeatureFlag, error) {
+	var (
+		groupFlags []types.OrgGroupFeatureFlag
+		rows       pgx.Rows
+		err        error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.OrgGroupFeatureFlagGetMany,
+		orgID,
+		applicationID,
+		environmentID,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if groupFlags, err = pgx.CollectRows(
+		rows,
+		pgx.RowToStructByName[types.OrgGroupFeatureFlag],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return groupFlags, nil
+}
+
 func (r *featureFlagRepo) GroupFlagsGetByFlagID(ctx context.Context,
 	orgID int64,
 	applicationID int64,
+	environmentID int64,
 	flagID int64,
 ) ([]types.OrgGroupFeatureFlag, error) {
 	var (
@@ -237,10 +570,11 @@ func (r *featureFlagRepo) GroupFlagsGetByFlagID(ctx context.Context,
 		err        error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgGroupFeatureFlagGetByFlagID,
 		orgID,
 		applicationID,
+		environmentID,
 		flagID,
 	); err != nil {
 		return nil, handleError(ctx, r.logger, err)
@@ -256,10 +590,93 @@ func (r *featureFlagRepo) GroupFlagsGetByFlagID(ctx context.Context,
 	return groupFlags, nil
 }
 
+// GroupFlagsGetManyByAccount returns the group overrides of an app
+// environment that apply to each org account, keyed by account ID. A nil
+// flagID includes overrides for every flag.
+func (r *featureFlagRepo) GroupFlagsGetManyByAccount(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+	flagID *int64,
+) (map[int64][]types.OrgGroupFeatureFlag, error) {
+	type accountGroupFlag struct {
+		AccountID int64 `db:"account_id"`
+		types.OrgGroupFeatureFlag
+	}
+
+	var (
+		accountGroupFlags []accountGroupFlag
+		rows              pgx.Rows
+		err               error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.OrgGroupFeatureFlagGetManyByAccounts,
+		orgID,
+		applicationID,
+		environmentID,
+		flagID,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if accountGroupFlags, err = pgx.CollectRows(
+		rows,
+		pgx.RowToStructByName[accountGroupFlag],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	groupFlags := make(map[int64][]types.OrgGroupFeatureFlag)
+	for _, accountGroupFlag := range accountGroupFlags {
+		groupFlags[accountGroupFlag.AccountID] = append(
+			groupFlags[accountGroupFlag.AccountID],
+			accountGroupFlag.OrgGroupFeatureFlag,
+		)
+	}
+
+	return groupFlags, nil
+}
+
+func (r *featureFlagRepo) GroupFlagsGetByAccountID(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+	flagID int64,
+	accountID int64,
+) ([]types.OrgGroupFeatureFlag, error) {
+	var (
+		groupFlags []types.OrgGroupFeatureFlag
+		rows       pgx.Rows
+		err        error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.OrgGroupFeatureFlagGetByAccountID,
+		orgID,
+		applicationID,
+		environmentID,
+		flagID,
+		accountID,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if groupFlags, err = pgx.CollectRows(
+		rows,
+		pgx.RowToStructByName[types.OrgGroupFeatureFlag],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return groupFlags, nil
+}
+
 func (r *featureFlagRepo) GroupFlagGetOne(ctx context.Context,
 	orgID int64,
 	groupID int64,
 	applicationID int64,
+	environmentID int64,
 	flagID int64,
 ) (*types.OrgGroupFeatureFlag, error) {
 	var (
@@ -268,11 +685,12 @@ func (r *featureFlagRepo) GroupFlagGetOne(ctx context.Context,
 		err       error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgGroupFeatureFlagGetOne,
 		orgID,
 		groupID,
 		applicationID,
+		environmentID,
 		flagID,
 	); err != nil {
 		return nil, handleError(ctx, r.logger, err)
@@ -292,8 +710,11 @@ func (r *featureFlagRepo) GroupFlagUpdate(ctx context.Context,
 	orgID int64,
 	groupID int64,
 	appID int64,
+	environmentID int64,
 	flagID int64,
 	isEnabled bool,
+	rolloutPercentage *int,
+	variant *string,
 	modifiedBy int64,
 ) (*types.OrgGroupFeatureFlag, error) {
 	var (
@@ -302,13 +723,60 @@ func (r *featureFlagRepo) GroupFlagUpdate(ctx context.Context,
 		err       error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgGroupFeatureFlagUpdate,
 		orgID,
 		groupID,
 		appID,
+		environmentID,
+		flagID,
+		isEnabled,
+		rolloutPercentage,
+		variant,
+		modifiedBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if groupFlag, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.OrgGroupFeatureFlag],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &groupFlag, nil
+}
+
+// GroupFlagUpsert creates the group's override of a flag, or replaces it in
+// place when the group already has one
+func (r *featureFlagRepo) GroupFlagUpsert(ctx context.Context,
+	orgID int64,
+	groupID int64,
+	appID int64,
+	environmentID int64,
+	flagID int64,
+	isEnabled bool,
+	rolloutPercentage *int,
+	variant *string,
+	modifiedBy int64,
+) (*types.OrgGroupFeatureFlag, error) {
+	var (
+		groupFlag types.OrgGroupFeatureFlag
+		rows      pgx.Rows
+		err       error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.OrgGroupFeatureFlagUpsert,
+		orgID,
+		groupID,
+		appID,
+		environmentID,
 		flagID,
 		isEnabled,
+		rolloutPercentage,
+		variant,
 		modifiedBy,
 	); err != nil {
 		return nil, handleError(ctx, r.logger, err)
@@ -328,13 +796,15 @@ func (r *featureFlagRepo) GroupFlagDelete(ctx context.Context,
 	orgID int64,
 	groupID int64,
 	appID int64,
+	environmentID int64,
 	flagID int64,
 ) error {
-	row := r.db.QueryRow(ctx,
+	row := getConn(ctx, r.db).QueryRow(ctx,
 		queries.OrgGroupFeatureFlagDelete,
 		orgID,
 		groupID,
 		appID,
+		environmentID,
 		flagID,
 	)
 
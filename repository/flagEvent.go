@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"switchcraft/repository/queries"
+	"switchcraft/types"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const flagEventChannel = "switchcraft_flag_event"
+
+func NewFlagEventRepository(logger *types.Logger, db *pgxpool.Pool) *flagEventRepo {
+	return &flagEventRepo{
+		logger: logger,
+		db:     db,
+	}
+}
+
+type flagEventRepo struct {
+	logger *types.Logger
+	db     *pgxpool.Pool
+}
+
+// Create stores a flag event and notifies listeners on every SwitchCraft
+// instance. Postgres only delivers the notification once the surrounding
+// transaction commits. An application's events are created under its Lock,
+// so their IDs are in commit order and subscribers can resume after the last
+// ID they saw. It must be called within WithTx.
+func (r *flagEventRepo) Create(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID *int64,
+	eventType types.FlagEventType,
+	flagID int64,
+	groupID *int64,
+	payload any,
+	createdBy int64,
+) (*types.FlagEvent, error) {
+	var (
+		flagEvent types.FlagEvent
+		rows      pgx.Rows
+		err       error
+	)
+
+	if err = r.Lock(ctx, applicationID); err != nil {
+		return nil, err
+	}
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.FlagEventCreate,
+		orgID,
+		applicationID,
+		environmentID,
+		eventType,
+		flagID,
+		groupID,
+		payload,
+		createdBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if flagEvent, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.FlagEvent],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &flagEvent, nil
+}
+
+// Lock takes an application's flag event advisory lock for the rest of the
+// transaction. Every flag and group flag change publishes an event, so this
+// serializes every flag change within the application from the first event
+// until commit. It must be called within WithTx.
+func (r *flagEventRepo) Lock(ctx context.Context, applicationID int64) error {
+	// The two key form only takes 32 bit keys, applications past that share a
+	// lock with another one, which only costs concurrency
+	if _, err := getConn(ctx, r.db).Exec(ctx, queries.FlagEventLock, int32(applicationID)); err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+	return nil
+}
+
+func (r *flagEventRepo) GetOne(ctx context.Context, id int64) (*types.FlagEvent, error) {
+	var (
+		flagEvent types.FlagEvent
+		rows      pgx.Rows
+		err       error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.FlagEventGetOne, id); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if flagEvent, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.FlagEvent],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &flagEvent, nil
+}
+
+func (r *flagEventRepo) GetManyAfter(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+	afterID int64,
+	limit int,
+) ([]types.FlagEvent, error) {
+	var (
+		flagEvents []types.FlagEvent
+		rows       pgx.Rows
+		err        error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.FlagEventGetManyAfter,
+		orgID,
+		applicationID,
+		environmentID,
+		afterID,
+		limit,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if flagEvents, err = pgx.CollectRows(
+		rows,
+		pgx.RowToStructByName[types.FlagEvent],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return flagEvents, nil
+}
+
+// Listen holds a dedicated pool connection subscribed to flag event
+// notifications and calls handler with each notification payload. It blocks
+// until ctx is cancelled or the connection fails.
+func (r *flagEventRepo) Listen(ctx context.Context, handler func(payload string)) error {
+	poolConn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	// The connection is taken out of the pool entirely so that it is never
+	// handed back to another caller while still subscribed
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, "LISTEN "+flagEventChannel); err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handler(notification.Payload)
+	}
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"switchcraft/types"
+	"testing"
+)
+
+func TestFlagEventLock(t *testing.T) {
+	repo, pool := testPool(t)
+	flagEvents := NewFlagEventRepository(types.NewLogger(types.LogLevelError), pool)
+
+	// Past 32 bits, the lock key wraps
+	var applicationID = int64(1)<<33 + 5
+
+	tryLock := func() bool {
+		t.Helper()
+		var locked bool
+		// Autocommit, so a successful try releases the lock right away
+		if err := pool.QueryRow(context.Background(),
+			"SELECT pg_try_advisory_xact_lock(hashtext('switchcraft_flag_event'), $1::int)",
+			int32(applicationID),
+		).Scan(&locked); err != nil {
+			t.Fatalf("trying the lock: %v", err)
+		}
+		return locked
+	}
+
+	err := repo.WithTx(context.Background(), func(ctx context.Context) error {
+		if err := flagEvents.Lock(ctx, applicationID); err != nil {
+			return err
+		}
+		// Taking it again within the same transaction does not block
+		if err := flagEvents.Lock(ctx, applicationID); err != nil {
+			return err
+		}
+
+		if tryLock() {
+			t.Error("lock was taken by another session while held")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+
+	if !tryLock() {
+		t.Error("lock was still held after commit")
+	}
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"switchcraft/repository/queries"
+	"switchcraft/types"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewFlagScheduleRepository(logger *types.Logger, db *pgxpool.Pool) *flagScheduleRepo {
+	return &flagScheduleRepo{
+		logger: logger,
+		db:     db,
+	}
+}
+
+type flagScheduleRepo struct {
+	logger *types.Logger
+	db     *pgxpool.Pool
+}
+
+func (r *flagScheduleRepo) Create(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+	flagID int64,
+	groupID *int64,
+	isEnabled bool,
+	rolloutPercentage *int,
+	executeAt time.Time,
+	createdBy int64,
+) (*types.FlagSchedule, error) {
+	var (
+		schedule types.FlagSchedule
+		rows     pgx.Rows
+		err      error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.FlagScheduleCreate,
+		orgID,
+		applicationID,
+		environmentID,
+		flagID,
+		groupID,
+		isEnabled,
+		rolloutPercentage,
+		executeAt,
+		createdBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if schedule, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.FlagSchedule],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &schedule, nil
+}
+
+func (r *flagScheduleRepo) GetMany(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+	flagID int64,
+) ([]types.FlagSchedule, error) {
+	var (
+		schedules []types.FlagSchedule
+		rows      pgx.Rows
+		err       error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.FlagScheduleGetMany,
+		orgID,
+		applicationID,
+		environmentID,
+		flagID,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if schedules, err = pgx.CollectRows(
+		rows,
+		pgx.RowToStructByName[types.FlagSchedule],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return schedules, nil
+}
+
+func (r *flagScheduleRepo) GetOne(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+	flagID int64,
+	id int64,
+) (*types.FlagSchedule, error) {
+	var (
+		schedule types.FlagSchedule
+		rows     pgx.Rows
+		err      error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.FlagScheduleGetOne,
+		orgID,
+		applicationID,
+		environmentID,
+		flagID,
+		id,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if schedule, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.FlagSchedule],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &schedule, nil
+}
+
+// Cancel only cancels pending schedules, others are not found
+func (r *flagScheduleRepo) Cancel(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+	flagID int64,
+	id int64,
+	modifiedBy int64,
+) (*types.FlagSchedule, error) {
+	var (
+		schedule types.FlagSchedule
+		rows     pgx.Rows
+		err      error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.FlagScheduleCancel,
+		orgID,
+		applicationID,
+		environmentID,
+		flagID,
+		id,
+		modifiedBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if schedule, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.FlagSchedule],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &schedule, nil
+}
+
+// Lock takes the scheduler advisory lock for the rest of the transaction,
+// returning false without waiting when another instance holds it. It must be
+// called within WithTx.
+func (r *flagScheduleRepo) Lock(ctx context.Context) (bool, error) {
+	var locked bool
+	if err := getConn(ctx, r.db).QueryRow(ctx, queries.FlagScheduleLock).Scan(&locked); err != nil {
+		return false, handleError(ctx, r.logger, err)
+	}
+
+	return locked, nil
+}
+
+// GetManyDue returns pending schedules whose time has come, oldest first, and
+// locks them for the rest of the transaction
+func (r *flagScheduleRepo) GetManyDue(ctx context.Context, limit int) ([]types.FlagSchedule, error) {
+	var (
+		schedules []types.FlagSchedule
+		rows      pgx.Rows
+		err       error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.FlagScheduleGetManyDue, limit); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if schedules, err = pgx.CollectRows(
+		rows,
+		pgx.RowToStructByName[types.FlagSchedule],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return schedules, nil
+}
+
+func (r *flagScheduleRepo) SetStatus(ctx context.Context,
+	id int64,
+	status types.FlagScheduleStatus,
+	errMessage *string,
+) (*types.FlagSchedule, error) {
+	var (
+		schedule types.FlagSchedule
+		rows     pgx.Rows
+		err      error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.FlagScheduleSetStatus,
+		id,
+		status,
+		errMessage,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if schedule, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.FlagSchedule],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &schedule, nil
+}
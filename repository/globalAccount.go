@@ -38,7 +38,7 @@ func (r *globalAccountRepo) Create(ctx context.Context,
 		err     error
 	)
 
-	if rows, err = r.db.Query(
+	if rows, err = getConn(ctx, r.db).Query(
 		ctx,
 		queries.GlobalAccountCreate,
 		isInstanceAdmin,
@@ -67,7 +67,7 @@ func (r *globalAccountRepo) GetMany(ctx context.Context) ([]types.Account, error
 		err      error
 	)
 
-	if rows, err = r.db.Query(ctx, queries.GlobalAccountGetMany); err != nil {
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.GlobalAccountGetMany); err != nil {
 		return nil, handleError(ctx, r.logger, err)
 	}
 
@@ -90,7 +90,7 @@ func (r *globalAccountRepo) GetOne(ctx context.Context,
 		err     error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.GlobalAccountGetOne,
 		id,
 		uuid,
@@ -122,7 +122,7 @@ func (r *globalAccountRepo) Update(ctx context.Context,
 		err     error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.GlobalAccountUpdate,
 		id,
 		isInstanceAdmin,
@@ -143,7 +143,7 @@ func (r *globalAccountRepo) Update(ctx context.Context,
 }
 
 func (r *globalAccountRepo) Delete(ctx context.Context, id int64) error {
-	row := r.db.QueryRow(ctx, queries.GlobalAccountDelete, id)
+	row := getConn(ctx, r.db).QueryRow(ctx, queries.GlobalAccountDelete, id)
 
 	var numDeleted int64
 	if err := row.Scan(&numDeleted); err != nil {
@@ -169,7 +169,7 @@ func (r *globalAccountRepo) GetByUsername(ctx context.Context, username string)
 		err     error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.AccountGetByUsername,
 		username,
 	); err != nil {
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"switchcraft/repository/queries"
+	"switchcraft/types"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewJobRepository(logger *types.Logger, db *pgxpool.Pool) *jobRepo {
+	return &jobRepo{
+		logger: logger,
+		db:     db,
+	}
+}
+
+type jobRepo struct {
+	logger *types.Logger
+	db     *pgxpool.Pool
+}
+
+// Create queues a job to run as soon as a worker is free. Called within
+// WithTx, the job table acts as an outbox: the job is only visible to workers
+// once the transaction commits and is discarded if it rolls back.
+func (r *jobRepo) Create(ctx context.Context,
+	kind types.JobKind,
+	payload any,
+	maxAttempts int,
+) (*types.Job, error) {
+	var (
+		job  types.Job
+		rows pgx.Rows
+		err  error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.JobCreate,
+		kind,
+		payload,
+		maxAttempts,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if job, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.Job]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &job, nil
+}
+
+// GetMany returns the latest jobs, newest first. A nil status returns jobs of
+// any status.
+func (r *jobRepo) GetMany(ctx context.Context,
+	status *types.JobStatus,
+	limit int,
+) ([]types.Job, error) {
+	var (
+		jobs []types.Job
+		rows pgx.Rows
+		err  error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.JobGetMany, status, limit); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if jobs, err = pgx.CollectRows(rows, pgx.RowToStructByName[types.Job]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return jobs, nil
+}
+
+func (r *jobRepo) GetOne(ctx context.Context, id int64) (*types.Job, error) {
+	var (
+		job  types.Job
+		rows pgx.Rows
+		err  error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.JobGetOne, id); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if job, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.Job]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &job, nil
+}
+
+// Claim marks the next due job as running and locks it to the caller for
+// lock. It returns nil without error when no job is due.
+func (r *jobRepo) Claim(ctx context.Context, lock time.Duration) (*types.Job, error) {
+	var (
+		job  types.Job
+		rows pgx.Rows
+		err  error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.JobClaim, lock.Seconds()); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if job, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.Job]); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &job, nil
+}
+
+// ResultSet records the outcome of a run and releases the job's lock. A nil
+// runAt leaves the next run time as is.
+func (r *jobRepo) ResultSet(ctx context.Context,
+	id int64,
+	attempt int,
+	status types.JobStatus,
+	runAt *time.Time,
+	errMessage *string,
+) (*types.Job, error) {
+	var (
+		job  types.Job
+		rows pgx.Rows
+		err  error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.JobResultSet,
+		id,
+		status,
+		runAt,
+		errMessage,
+		attempt,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if job, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.Job]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &job, nil
+}
+
+// Retry queues a dead job to run again with a fresh set of attempts. Jobs
+// that are not dead are not found.
+func (r *jobRepo) Retry(ctx context.Context, id int64) (*types.Job, error) {
+	var (
+		job  types.Job
+		rows pgx.Rows
+		err  error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.JobRetry, id); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if job, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.Job]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &job, nil
+}
+
+// Purge deletes succeeded jobs completed before completedBefore, returning
+// how many were deleted
+func (r *jobRepo) Purge(ctx context.Context, completedBefore time.Time) (int64, error) {
+	var numDeleted int64
+	if err := getConn(ctx, r.db).QueryRow(ctx, queries.JobPurge, completedBefore).Scan(&numDeleted); err != nil {
+		return 0, handleError(ctx, r.logger, err)
+	}
+
+	return numDeleted, nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"switchcraft/types"
+	"testing"
+)
+
+func TestJobResultSetRequiresClaimedAttempt(t *testing.T) {
+	_, pool := testPool(t)
+	jobs := NewJobRepository(types.NewLogger(types.LogLevelError), pool)
+	ctx := context.Background()
+
+	job, err := jobs.Create(ctx, types.JobKindWebhookDeliver, map[string]any{"test": true}, 5)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), "DELETE FROM queue.job WHERE id = $1", job.ID)
+	})
+
+	// A zero lock runs out straight away, so the second claim takes the job
+	// over from the first
+	first, err := jobs.Claim(ctx, 0)
+	if err != nil || first == nil || first.ID != job.ID {
+		t.Fatalf("first Claim: got %v, %v", first, err)
+	}
+	second, err := jobs.Claim(ctx, 0)
+	if err != nil || second == nil || second.ID != job.ID || second.Attempts != first.Attempts+1 {
+		t.Fatalf("second Claim: got %v, %v", second, err)
+	}
+
+	if _, err := jobs.ResultSet(ctx, job.ID, first.Attempts, types.JobStatusSucceeded, nil, nil); !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("ResultSet for the lost attempt: got %v, want ErrNotFound", err)
+	}
+
+	result, err := jobs.ResultSet(ctx, job.ID, second.Attempts, types.JobStatusSucceeded, nil, nil)
+	if err != nil {
+		t.Fatalf("ResultSet for the current attempt: %v", err)
+	}
+	if result.Status != types.JobStatusSucceeded {
+		t.Errorf("job %s, want succeeded", result.Status)
+	}
+
+	if _, err := jobs.ResultSet(ctx, job.ID, second.Attempts, types.JobStatusSucceeded, nil, nil); !errors.Is(err, types.ErrNotFound) {
+		t.Errorf("ResultSet after the job finished: got %v, want ErrNotFound", err)
+	}
+}
@@ -35,7 +35,7 @@ func (r *orgRepo) Create(ctx context.Context,
 		err  error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgCreate,
 		name,
 		slug,
@@ -59,7 +59,7 @@ func (r *orgRepo) GetMany(ctx context.Context) ([]types.Organization, error) {
 		err  error
 	)
 
-	if rows, err = r.db.Query(ctx, queries.OrgGetMany); err != nil {
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.OrgGetMany); err != nil {
 		return nil, handleError(ctx, r.logger, err)
 	}
 
@@ -85,7 +85,7 @@ func (r *orgRepo) GetOne(ctx context.Context,
 		err  error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgGetOne,
 		id,
 		uuid,
@@ -114,7 +114,7 @@ func (r *orgRepo) Update(ctx context.Context,
 		err  error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgUpdate,
 		id,
 		name,
@@ -133,7 +133,7 @@ func (r *orgRepo) Update(ctx context.Context,
 }
 
 func (r *orgRepo) Delete(ctx context.Context, id int64) error {
-	row := r.db.QueryRow(ctx, queries.OrgDelete, id)
+	row := getConn(ctx, r.db).QueryRow(ctx, queries.OrgDelete, id)
 
 	var numDeleted int64
 	if err := row.Scan(&numDeleted); err != nil {
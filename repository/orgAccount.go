@@ -37,7 +37,7 @@ func (r *orgAccountRepo) Signup(ctx context.Context,
 		err     error
 	)
 
-	if rows, err = r.db.Query(
+	if rows, err = getConn(ctx, r.db).Query(
 		ctx,
 		queries.SignupAccountCreate,
 		firstName,
@@ -71,7 +71,7 @@ func (r *orgAccountRepo) Create(ctx context.Context,
 		err     error
 	)
 
-	if rows, err = r.db.Query(
+	if rows, err = getConn(ctx, r.db).Query(
 		ctx,
 		queries.OrgAccountCreate,
 		orgID,
@@ -99,7 +99,7 @@ func (r *orgAccountRepo) GetMany(ctx context.Context, orgID int64) ([]types.Acco
 		err      error
 	)
 
-	if rows, err = r.db.Query(ctx, queries.OrgAccountGetMany, orgID); err != nil {
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.OrgAccountGetMany, orgID); err != nil {
 		return nil, handleError(ctx, r.logger, err)
 	}
 
@@ -125,7 +125,7 @@ func (r *orgAccountRepo) GetManyByID(ctx context.Context,
 		accountIDStrs[i] = strconv.FormatInt(a, 10)
 	}
 
-	if rows, err = r.db.Query(ctx, queries.OrgAccountGetManyByID, orgID, strings.Join(accountIDStrs, ",")); err != nil {
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.OrgAccountGetManyByID, orgID, strings.Join(accountIDStrs, ",")); err != nil {
 		return nil, handleError(ctx, r.logger, err)
 	}
 
@@ -148,7 +148,7 @@ func (r *orgAccountRepo) GetOne(ctx context.Context,
 		err     error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgAccountGetOne,
 		orgID,
 		id,
@@ -180,7 +180,7 @@ func (r *orgAccountRepo) Update(ctx context.Context,
 		err     error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgAccountUpdate,
 		orgID,
 		id,
@@ -210,7 +210,7 @@ func (r *orgAccountRepo) SetOrgID(ctx context.Context,
 		err     error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.SignupAccountSetOrg,
 		orgID,
 		accountID,
@@ -226,7 +226,97 @@ func (r *orgAccountRepo) SetOrgID(ctx context.Context,
 }
 
 func (r *orgAccountRepo) Delete(ctx context.Context, orgID int64, id int64) error {
-	row := r.db.QueryRow(ctx, queries.OrgAccountDelete, orgID, id)
+	row := getConn(ctx, r.db).QueryRow(ctx, queries.OrgAccountDelete, orgID, id)
+
+	var numDeleted int64
+	if err := row.Scan(&numDeleted); err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	if numDeleted < 1 {
+		return types.ErrNotFound
+	}
+
+	if numDeleted > 1 {
+		return fmt.Errorf("expected to delete 1 row, deleted %v", numDeleted)
+	}
+
+	return nil
+}
+
+func (r *orgAccountRepo) RoleGetOne(ctx context.Context,
+	orgID int64,
+	accountID int64,
+) (*types.OrgAccountRole, error) {
+	var (
+		role types.OrgAccountRole
+		rows pgx.Rows
+		err  error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.OrgAccountRoleGetOne, orgID, accountID); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if role, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.OrgAccountRole]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &role, nil
+}
+
+func (r *orgAccountRepo) RoleGetGrants(ctx context.Context,
+	orgID int64,
+	accountID int64,
+) ([]types.OrgRoleGrant, error) {
+	var (
+		grants []types.OrgRoleGrant
+		rows   pgx.Rows
+		err    error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.OrgAccountRoleGetGrants, orgID, accountID); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if grants, err = pgx.CollectRows(rows, pgx.RowToStructByName[types.OrgRoleGrant]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return grants, nil
+}
+
+func (r *orgAccountRepo) RoleSet(ctx context.Context,
+	orgID int64,
+	accountID int64,
+	role types.OrgRole,
+	createdBy int64,
+) (*types.OrgAccountRole, error) {
+	var (
+		accountRole types.OrgAccountRole
+		rows        pgx.Rows
+		err         error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.OrgAccountRoleUpsert,
+		orgID,
+		accountID,
+		role,
+		createdBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if accountRole, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.OrgAccountRole]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &accountRole, nil
+}
+
+func (r *orgAccountRepo) RoleDelete(ctx context.Context, orgID int64, accountID int64) error {
+	row := getConn(ctx, r.db).QueryRow(ctx, queries.OrgAccountRoleDelete, orgID, accountID)
 
 	var numDeleted int64
 	if err := row.Scan(&numDeleted); err != nil {
@@ -36,7 +36,7 @@ func (r *orgGroupRepo) Create(ctx context.Context,
 		err   error
 	)
 
-	if rows, err = r.db.Query(
+	if rows, err = getConn(ctx, r.db).Query(
 		ctx,
 		queries.OrgGroupCreate,
 		orgID,
@@ -62,7 +62,33 @@ func (r *orgGroupRepo) GetMany(ctx context.Context, orgID int64) ([]types.OrgGro
 		err    error
 	)
 
-	if rows, err = r.db.Query(ctx, queries.OrgGroupGetMany, orgID); err != nil {
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.OrgGroupGetMany, orgID); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if groups, err = pgx.CollectRows(rows, pgx.RowToStructByName[types.OrgGroup]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return groups, nil
+}
+
+// GetManyByAccountID returns the groups an account is a member of
+func (r *orgGroupRepo) GetManyByAccountID(ctx context.Context,
+	orgID int64,
+	accountID int64,
+) ([]types.OrgGroup, error) {
+	var (
+		groups []types.OrgGroup
+		rows   pgx.Rows
+		err    error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.OrgGroupGetManyByAccountID,
+		orgID,
+		accountID,
+	); err != nil {
 		return nil, handleError(ctx, r.logger, err)
 	}
 
@@ -85,7 +111,7 @@ func (r *orgGroupRepo) GetOne(ctx context.Context,
 		err   error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgGroupGetOne,
 		orgID,
 		id,
@@ -115,7 +141,7 @@ func (r *orgGroupRepo) Update(ctx context.Context,
 		err   error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgGroupUpdate,
 		orgID,
 		id,
@@ -137,7 +163,7 @@ func (r *orgGroupRepo) Delete(ctx context.Context,
 	orgID int64,
 	id int64,
 ) error {
-	row := r.db.QueryRow(ctx, queries.OrgGroupDelete, orgID, id)
+	row := getConn(ctx, r.db).QueryRow(ctx, queries.OrgGroupDelete, orgID, id)
 
 	var numDeleted int64
 	if err := row.Scan(&numDeleted); err != nil {
@@ -167,7 +193,7 @@ func (r *orgGroupRepo) AddAccount(ctx context.Context,
 		err       error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgGroupAccountCreate,
 		orgID,
 		groupID,
@@ -195,7 +221,7 @@ func (r *orgGroupRepo) GetAccounts(ctx context.Context,
 		err      error
 	)
 
-	if rows, err = r.db.Query(ctx,
+	if rows, err = getConn(ctx, r.db).Query(ctx,
 		queries.OrgGroupAccountGetMany,
 		orgID,
 		groupID,
@@ -210,6 +236,35 @@ func (r *orgGroupRepo) GetAccounts(ctx context.Context,
 	return accounts, nil
 }
 
+// GetAccountsByAppID returns group memberships for every group that has a
+// flag override in the application environment
+func (r *orgGroupRepo) GetAccountsByAppID(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+) ([]types.SDKGroupAccount, error) {
+	var (
+		groupAccounts []types.SDKGroupAccount
+		rows          pgx.Rows
+		err           error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.OrgGroupAccountGetManyByAppID,
+		orgID,
+		applicationID,
+		environmentID,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if groupAccounts, err = pgx.CollectRows(rows, pgx.RowToStructByName[types.SDKGroupAccount]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return groupAccounts, nil
+}
+
 func (r *orgGroupRepo) UpdateAccounts(ctx context.Context,
 	orgID int64,
 	groupID int64,
@@ -225,7 +280,7 @@ func (r *orgGroupRepo) UpdateAccounts(ctx context.Context,
 		numInserted int64
 		err         error
 	)
-	numInserted, err = r.db.CopyFrom(ctx,
+	numInserted, err = getConn(ctx, r.db).CopyFrom(ctx,
 		pgx.Identifier{"account", "org_group_account"},
 		[]string{"org_id", "group_id", "account_id", "created_by"},
 		pgx.CopyFromSlice(numAccounts, func(i int) ([]interface{}, error) {
@@ -248,7 +303,7 @@ func (r *orgGroupRepo) RemoveAccount(ctx context.Context,
 	accountID int64,
 ) error {
 
-	row := r.db.QueryRow(ctx,
+	row := getConn(ctx, r.db).QueryRow(ctx,
 		queries.OrgGroupAccountDeleteOne,
 		orgID,
 		groupID,
@@ -275,7 +330,7 @@ func (r *orgGroupRepo) RemoveAllAccounts(ctx context.Context,
 	orgID int64,
 	groupID int64,
 ) error {
-	if _, err := r.db.Exec(ctx,
+	if _, err := getConn(ctx, r.db).Exec(ctx,
 		queries.OrgGroupAccountDeleteAll,
 		orgID,
 		groupID,
@@ -285,3 +340,75 @@ func (r *orgGroupRepo) RemoveAllAccounts(ctx context.Context,
 
 	return nil
 }
+
+func (r *orgGroupRepo) RoleGetOne(ctx context.Context,
+	orgID int64,
+	groupID int64,
+) (*types.OrgGroupRole, error) {
+	var (
+		role types.OrgGroupRole
+		rows pgx.Rows
+		err  error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.OrgGroupRoleGetOne, orgID, groupID); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if role, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.OrgGroupRole]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &role, nil
+}
+
+func (r *orgGroupRepo) RoleSet(ctx context.Context,
+	orgID int64,
+	groupID int64,
+	role types.OrgRole,
+	createdBy int64,
+) (*types.OrgGroupRole, error) {
+	var (
+		groupRole types.OrgGroupRole
+		rows      pgx.Rows
+		err       error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.OrgGroupRoleUpsert,
+		orgID,
+		groupID,
+		role,
+		createdBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if groupRole, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.OrgGroupRole]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &groupRole, nil
+}
+
+func (r *orgGroupRepo) RoleDelete(ctx context.Context,
+	orgID int64,
+	groupID int64,
+) error {
+	row := getConn(ctx, r.db).QueryRow(ctx, queries.OrgGroupRoleDelete, orgID, groupID)
+
+	var numDeleted int64
+	if err := row.Scan(&numDeleted); err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	if numDeleted < 1 {
+		return types.ErrNotFound
+	}
+
+	if numDeleted > 1 {
+		return fmt.Errorf("expected to delete 1 row, deleted %v", numDeleted)
+	}
+
+	return nil
+}
@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"switchcraft/repository/queries"
+	"switchcraft/types"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewOrgSegmentRepository(logger *types.Logger, db *pgxpool.Pool) *orgSegmentRepo {
+	return &orgSegmentRepo{
+		logger: logger,
+		db:     db,
+	}
+}
+
+type orgSegmentRepo struct {
+	logger *types.Logger
+	db     *pgxpool.Pool
+}
+
+func (r *orgSegmentRepo) Create(ctx context.Context,
+	orgID int64,
+	name string,
+	description string,
+	rules []types.SegmentRule,
+	createdBy int64,
+) (*types.OrgSegment, error) {
+
+	var (
+		segment types.OrgSegment
+		rows    pgx.Rows
+		err     error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(
+		ctx,
+		queries.OrgSegmentCreate,
+		orgID,
+		name,
+		description,
+		rules,
+		createdBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if segment, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.OrgSegment]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &segment, nil
+}
+
+func (r *orgSegmentRepo) GetMany(ctx context.Context, orgID int64) ([]types.OrgSegment, error) {
+
+	var (
+		segments []types.OrgSegment
+		rows     pgx.Rows
+		err      error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.OrgSegmentGetMany, orgID); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if segments, err = pgx.CollectRows(rows, pgx.RowToStructByName[types.OrgSegment]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return segments, nil
+}
+
+func (r *orgSegmentRepo) GetManyByID(ctx context.Context,
+	orgID int64,
+	segmentIDs []int64,
+) ([]types.OrgSegment, error) {
+
+	var (
+		segments []types.OrgSegment
+		rows     pgx.Rows
+		err      error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.OrgSegmentGetManyByID,
+		orgID,
+		segmentIDs,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if segments, err = pgx.CollectRows(rows, pgx.RowToStructByName[types.OrgSegment]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return segments, nil
+}
+
+func (r *orgSegmentRepo) GetOne(ctx context.Context,
+	orgID int64,
+	id *int64,
+	uuid *string,
+) (*types.OrgSegment, error) {
+
+	var (
+		segment types.OrgSegment
+		rows    pgx.Rows
+		err     error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.OrgSegmentGetOne,
+		orgID,
+		id,
+		uuid,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if segment, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.OrgSegment]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &segment, nil
+}
+
+func (r *orgSegmentRepo) Update(ctx context.Context,
+	orgID int64,
+	id int64,
+	name string,
+	description string,
+	rules []types.SegmentRule,
+	modifiedBy int64,
+) (*types.OrgSegment, error) {
+
+	var (
+		segment types.OrgSegment
+		rows    pgx.Rows
+		err     error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.OrgSegmentUpdate,
+		orgID,
+		id,
+		name,
+		description,
+		rules,
+		modifiedBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if segment, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.OrgSegment]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &segment, nil
+}
+
+func (r *orgSegmentRepo) Delete(ctx context.Context,
+	orgID int64,
+	id int64,
+) error {
+	row := getConn(ctx, r.db).QueryRow(ctx, queries.OrgSegmentDelete, orgID, id)
+
+	var numDeleted int64
+	if err := row.Scan(&numDeleted); err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	if numDeleted < 1 {
+		return types.ErrNotFound
+	}
+
+	if numDeleted > 1 {
+		return fmt.Errorf("expected to delete 1 row, deleted %v", numDeleted)
+	}
+
+	return nil
+}
+
+// AccountsSet replaces the accounts explicitly listed in a segment
+func (r *orgSegmentRepo) AccountsSet(ctx context.Context,
+	orgID int64,
+	segmentID int64,
+	accountIDs []int64,
+	createdBy int64,
+) error {
+	if _, err := getConn(ctx, r.db).Exec(ctx,
+		queries.OrgSegmentAccountDeleteAll,
+		orgID,
+		segmentID,
+	); err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	var (
+		numAccounts = len(accountIDs)
+		numInserted int64
+		err         error
+	)
+	numInserted, err = getConn(ctx, r.db).CopyFrom(ctx,
+		pgx.Identifier{"account", "org_segment_account"},
+		[]string{"org_id", "segment_id", "account_id", "created_by"},
+		pgx.CopyFromSlice(numAccounts, func(i int) ([]interface{}, error) {
+			return []interface{}{orgID, segmentID, accountIDs[i], createdBy}, nil
+		}),
+	)
+	if err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+	if numInserted != int64(numAccounts) {
+		return handleError(ctx, r.logger, errors.New("orgSegment.AccountsSet mismatched insert and accountIDs len"))
+	}
+
+	return nil
+}
+
+// FlagRefsGetMany returns the flags whose targeting rules reference a segment
+func (r *orgSegmentRepo) FlagRefsGetMany(ctx context.Context,
+	orgID int64,
+	segmentID int64,
+) ([]types.OrgSegmentFlagRef, error) {
+	var (
+		refs []types.OrgSegmentFlagRef
+		rows pgx.Rows
+		err  error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.OrgSegmentFlagRefGetMany,
+		orgID,
+		segmentID,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if refs, err = pgx.CollectRows(rows, pgx.RowToStructByName[types.OrgSegmentFlagRef]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return refs, nil
+}
+
+// FlagRefsSet replaces the segments a flag references in one environment.
+// Segments that don't exist in the org fail with ErrLinkedItemNotFound.
+func (r *orgSegmentRepo) FlagRefsSet(ctx context.Context,
+	orgID int64,
+	environmentID int64,
+	flagID int64,
+	segmentIDs []int64,
+) error {
+	if _, err := getConn(ctx, r.db).Exec(ctx,
+		queries.OrgSegmentFlagRefDeleteAll,
+		orgID,
+		environmentID,
+		flagID,
+	); err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	if len(segmentIDs) == 0 {
+		return nil
+	}
+
+	tag, err := getConn(ctx, r.db).Exec(ctx,
+		queries.OrgSegmentFlagRefCreate,
+		orgID,
+		environmentID,
+		flagID,
+		segmentIDs,
+	)
+	if err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+	if tag.RowsAffected() != int64(len(segmentIDs)) {
+		return types.ErrLinkedItemNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+
+INSERT INTO audit.audit_log (
+	  org_id
+	, actor_id
+	, trace_id
+	, action
+	, entity_type
+	, entity_id
+	, before
+	, after
+)
+
+VALUES (
+	  $1
+	, $2
+	, $3
+	, $4
+	, $5
+	, $6
+	, $7
+	, $8
+)
+
+RETURNING
+	  org_id
+	, id
+	, actor_id
+	, trace_id
+	, action
+	, entity_type
+	, entity_id
+	, before
+	, after
+	, created;
\ No newline at end of file
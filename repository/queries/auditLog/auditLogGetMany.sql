@@ -0,0 +1,28 @@
+
+SELECT
+	  org_id
+	, id
+	, actor_id
+	, trace_id
+	, action
+	, entity_type
+	, entity_id
+	, before
+	, after
+	, created
+
+FROM
+	audit.audit_log
+
+WHERE
+	    org_id = $1
+	AND ($2::varchar IS NULL OR entity_type = $2)
+	AND ($3::bigint IS NULL OR entity_id = $3)
+	AND ($4::bigint IS NULL OR actor_id = $4)
+	AND ($5::timestamptz IS NULL OR created >= $5)
+	AND ($6::timestamptz IS NULL OR created < $6)
+
+ORDER BY
+	id DESC
+
+LIMIT $7;
\ No newline at end of file
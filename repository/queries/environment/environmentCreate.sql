@@ -0,0 +1,67 @@
+
+WITH e AS (
+	INSERT INTO application.environment (
+		  org_id
+		, application_id
+		, name
+		, slug
+		, created_by
+	)
+
+	VALUES (
+		  $1
+		, $2
+		, $3
+		, $4
+		, $5
+	)
+
+	RETURNING
+		  org_id
+		, application_id
+		, id
+		, uuid
+		, name
+		, slug
+		, created
+		, created_by
+		, modified
+		, modified_by
+),
+
+-- Existing flags start out disabled in a new environment
+ffe AS (
+	INSERT INTO application.feature_flag_environment (
+		  org_id
+		, application_id
+		, flag_id
+		, environment_id
+	)
+
+	SELECT
+		  ff.org_id
+		, ff.application_id
+		, ff.id
+		, e.id
+
+	FROM
+		e
+
+	INNER JOIN application.feature_flag AS ff
+		ON ff.application_id = e.application_id
+)
+
+SELECT
+	  org_id
+	, application_id
+	, id
+	, uuid
+	, name
+	, slug
+	, created
+	, created_by
+	, modified
+	, modified_by
+
+FROM
+	e;
\ No newline at end of file
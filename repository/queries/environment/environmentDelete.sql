@@ -0,0 +1,10 @@
+
+WITH deleted AS (
+	DELETE FROM application.environment WHERE org_id=$1 AND application_id=$2 AND id=$3 RETURNING id
+)
+
+SELECT
+	count(*) AS num_deleted
+
+FROM
+	deleted;
\ No newline at end of file
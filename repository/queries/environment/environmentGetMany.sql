@@ -0,0 +1,22 @@
+
+SELECT
+	  org_id
+	, application_id
+	, id
+	, uuid
+	, name
+	, slug
+	, created
+	, created_by
+	, modified
+	, modified_by
+
+FROM
+	application.environment
+
+WHERE
+	    org_id = $1
+	AND application_id = $2
+
+ORDER BY
+	id;
\ No newline at end of file
@@ -0,0 +1,22 @@
+
+SELECT
+	  org_id
+	, application_id
+	, id
+	, uuid
+	, name
+	, slug
+	, created
+	, created_by
+	, modified
+	, modified_by
+
+FROM
+	application.environment
+
+WHERE
+	    org_id=$1
+	AND application_id=$2
+	AND ($3::bigint IS NULL    OR id=$3::bigint)
+	AND (COALESCE($4, '') = '' OR uuid=$4::uuid)
+	AND (COALESCE($5, '') = '' OR slug=$5::text);
\ No newline at end of file
@@ -0,0 +1,25 @@
+
+UPDATE application.environment
+
+SET
+	  name = $4
+	, slug = $5
+	, modified = (now() at time zone 'utc')
+	, modified_by = $6
+
+WHERE
+	    org_id = $1
+	AND application_id = $2
+	AND id = $3
+
+RETURNING
+	  org_id
+	, application_id
+	, id
+	, uuid
+	, name
+	, slug
+	, created
+	, created_by
+	, modified
+	, modified_by;
\ No newline at end of file
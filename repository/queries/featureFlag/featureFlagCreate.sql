@@ -1,34 +1,123 @@
 
-INSERT INTO application.feature_flag (
-	  org_id
-	, application_id
-	, name
-	, label
-	, description
-	, is_enabled
-	, created_by
-)
+WITH ff AS (
+	INSERT INTO application.feature_flag (
+		  org_id
+		, application_id
+		, name
+		, label
+		, description
+		, flag_type
+		, variants
+		, default_variant
+		, prerequisites
+		, expires_at
+		, created_by
+	)
+
+	VALUES (
+		  $1
+		, $2
+		, $4
+		, $5
+		, $6
+		, $9
+		, $10
+		, $11
+		, $13
+		, $14
+		, $15
+	)
+
+	RETURNING
+		  org_id
+		, application_id
+		, id
+		, uuid
+		, name
+		, label
+		, description
+		, flag_type
+		, variants
+		, default_variant
+		, prerequisites
+		, lifecycle
+		, expires_at
+		, created
+		, created_by
+		, modified
+		, modified_by
+),
+
+-- The flag exists in every environment of the application but only starts
+-- out enabled in the one it was created from
+ffe AS (
+	INSERT INTO application.feature_flag_environment (
+		  org_id
+		, application_id
+		, flag_id
+		, environment_id
+		, is_enabled
+		, rollout_percentage
+		, rules
+	)
+
+	SELECT
+		  ff.org_id
+		, ff.application_id
+		, ff.id
+		, e.id
+		, (e.id = $3::bigint AND $7::boolean)
+		, CASE WHEN e.id = $3::bigint THEN $8::smallint END
+		, CASE WHEN e.id = $3::bigint THEN $12::jsonb ELSE '[]'::jsonb END
 
-VALUES (
-	  $1
-	, $2
-	, $3
-	, $4
-	, $5
-	, $6
-	, $7
+	FROM
+		ff
+
+	INNER JOIN application.environment AS e
+		ON e.application_id = ff.application_id
+
+	RETURNING
+		  flag_id
+		, environment_id
+		, is_enabled
+		, rollout_percentage
+		, rules
+		, modified
+		, modified_by
 )
 
-RETURNING
-		org_id
-	, application_id
-	, id
-	, uuid
-	, name
-	, label
-	, description
-	, is_enabled
-	, created
-	, created_by
-	, modified
-	, modified_by;
\ No newline at end of file
+SELECT
+	  ff.org_id
+	, ff.application_id
+	, ffe.environment_id
+	, ff.id
+	, ff.uuid
+	, ff.name
+	, ff.label
+	, ff.description
+	, ffe.is_enabled
+	, ffe.rollout_percentage
+	, ff.flag_type
+	, ff.variants
+	, ff.default_variant
+	, ffe.rules
+	, ff.prerequisites
+	, ff.lifecycle
+	, ff.expires_at
+	, ff.created
+	, ff.created_by
+	, GREATEST(ff.modified, ffe.modified) AS modified
+	, CASE
+			WHEN ffe.modified IS NULL OR ff.modified > ffe.modified THEN ff.modified_by
+			ELSE ffe.modified_by
+		END AS modified_by
+
+FROM
+	ff
+
+INNER JOIN ffe
+	ON
+		(
+					ffe.flag_id = ff.id
+			AND ffe.environment_id = $3
+		);
\ No newline at end of file
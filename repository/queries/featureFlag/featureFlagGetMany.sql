@@ -1,21 +1,40 @@
 
 SELECT
-		org_id
-	, application_id
-	, id
-	, uuid
-	, name
-	, label
-	, description
-	, is_enabled
-	, created
-	, created_by
-	, modified
-	, modified_by
+	  ff.org_id
+	, ff.application_id
+	, ffe.environment_id
+	, ff.id
+	, ff.uuid
+	, ff.name
+	, ff.label
+	, ff.description
+	, ffe.is_enabled
+	, ffe.rollout_percentage
+	, ff.flag_type
+	, ff.variants
+	, ff.default_variant
+	, ffe.rules
+	, ff.prerequisites
+	, ff.lifecycle
+	, ff.expires_at
+	, ff.created
+	, ff.created_by
+	, GREATEST(ff.modified, ffe.modified) AS modified
+	, CASE
+			WHEN ffe.modified IS NULL OR ff.modified > ffe.modified THEN ff.modified_by
+			ELSE ffe.modified_by
+		END AS modified_by
 
 FROM
-	application.feature_flag
+	application.feature_flag AS ff
+
+INNER JOIN application.feature_flag_environment AS ffe
+	ON
+		(
+					ffe.flag_id = ff.id
+			AND ffe.environment_id = $3
+		)
 
 WHERE
-	    org_id = $1
-	AND application_id = $2;
\ No newline at end of file
+	    ff.org_id = $1
+	AND ff.application_id = $2;
\ No newline at end of file
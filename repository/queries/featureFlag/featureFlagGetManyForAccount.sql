@@ -0,0 +1,130 @@
+
+WITH scope AS (
+	SELECT
+		  o.id AS org_id
+		, app.id AS application_id
+		, e.id AS environment_id
+		, a.id AS account_id
+		, a.uuid AS account_uuid
+
+	FROM
+		account.org AS o
+
+	INNER JOIN application.application AS app
+		ON app.org_id = o.id
+
+	INNER JOIN application.environment AS e
+		ON e.application_id = app.id
+
+	INNER JOIN account.account AS a
+		ON
+			(
+						a.org_id = o.id
+				AND a.id = $5
+			)
+
+	WHERE
+		    o.slug = $1
+		AND app.slug = $2
+		AND ($3::bigint IS NULL    OR e.id=$3::bigint)
+		AND (COALESCE($4, '') = '' OR e.slug=$4::text)
+)
+
+SELECT
+	  scope.org_id
+	, scope.application_id
+	, scope.environment_id
+	, scope.account_id
+	, scope.account_uuid
+	, COALESCE(
+			(
+				SELECT
+					json_agg(flag ORDER BY flag.name)
+
+				FROM
+					(
+						SELECT
+							  ff.org_id AS "orgId"
+							, ff.application_id AS "applicationId"
+							, ffe.environment_id AS "environmentId"
+							, ff.id
+							, ff.uuid
+							, ff.name
+							, ff.label
+							, ff.description
+							, ffe.is_enabled AS "isEnabled"
+							, ffe.rollout_percentage AS "rolloutPercentage"
+							, ff.flag_type AS "flagType"
+							, ff.variants
+							, ff.default_variant AS "defaultVariant"
+							, ffe.rules
+							, ff.prerequisites
+							, ff.lifecycle
+							, ff.expires_at AS "expiresAt"
+							, ff.created
+							, ff.created_by AS "createdBy"
+							, GREATEST(ff.modified, ffe.modified) AS modified
+							, CASE
+									WHEN ffe.modified IS NULL OR ff.modified > ffe.modified THEN ff.modified_by
+									ELSE ffe.modified_by
+								END AS "modifiedBy"
+							, COALESCE(
+									(
+										SELECT
+											json_agg(
+												json_build_object(
+													  'orgId', ogff.org_id
+													, 'groupId', ogff.group_id
+													, 'appId', ogff.application_id
+													, 'environmentId', ogff.environment_id
+													, 'flagId', ogff.flag_id
+													, 'isEnabled', ogff.is_enabled
+													, 'rolloutPercentage', ogff.rollout_percentage
+													, 'variant', ogff.variant
+													, 'created', ogff.created
+													, 'createdBy', ogff.created_by
+													, 'modified', ogff.modified
+													, 'modifiedBy', ogff.modified_by
+												)
+												ORDER BY ogff.group_id
+											)
+
+										FROM
+											application.org_group_feature_flag AS ogff
+
+										INNER JOIN account.org_group_account AS oga
+											ON
+												(
+															oga.org_id = ogff.org_id
+													AND oga.group_id = ogff.group_id
+													AND oga.account_id = scope.account_id
+												)
+
+										WHERE
+											    ogff.org_id = scope.org_id
+											AND ogff.flag_id = ff.id
+											AND ogff.environment_id = scope.environment_id
+									),
+									'[]'
+								) AS "groupFlags"
+
+						FROM
+							application.feature_flag AS ff
+
+						INNER JOIN application.feature_flag_environment AS ffe
+							ON
+								(
+											ffe.flag_id = ff.id
+									AND ffe.environment_id = scope.environment_id
+								)
+
+						WHERE
+							    ff.org_id = scope.org_id
+							AND ff.application_id = scope.application_id
+					) AS flag
+			),
+			'[]'
+		) AS flags
+
+FROM
+	scope;
\ No newline at end of file
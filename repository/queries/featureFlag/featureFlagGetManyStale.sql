@@ -0,0 +1,61 @@
+
+SELECT
+	  ff.id AS flag_id
+	, ff.name
+	, ff.lifecycle
+	, ff.expires_at
+	, GREATEST(ff.created, ff.modified, ffe.modified, ogff.modified) AS last_modified
+	, (
+				ffe.fully_enabled
+			AND ogff.num_overrides = 0
+			AND ff.prerequisites = '[]'::jsonb
+		) AS fully_enabled
+	, (
+				ffe.fully_disabled
+			AND ogff.num_overrides = 0
+		) AS fully_disabled
+
+FROM
+	application.feature_flag AS ff
+
+-- A flag serves the same state to everyone when no environment has rules,
+-- overrides or a partial rollout
+CROSS JOIN LATERAL (
+	SELECT
+		  MAX(modified) AS modified
+		, bool_and(
+					is_enabled
+				AND COALESCE(rollout_percentage, 100) = 100
+				AND rules = '[]'::jsonb
+			) AS fully_enabled
+		, bool_and(
+					NOT is_enabled
+				AND rules = '[]'::jsonb
+			) AS fully_disabled
+
+	FROM
+		application.feature_flag_environment
+
+	WHERE
+		flag_id = ff.id
+) AS ffe
+
+CROSS JOIN LATERAL (
+	SELECT
+		  MAX(COALESCE(modified, created)) AS modified
+		, count(*) AS num_overrides
+
+	FROM
+		application.org_group_feature_flag
+
+	WHERE
+		flag_id = ff.id
+) AS ogff
+
+WHERE
+	    ff.org_id = $1
+	AND ff.application_id = $2
+	AND ff.lifecycle <> 'archived'
+
+ORDER BY
+	ff.name;
\ No newline at end of file
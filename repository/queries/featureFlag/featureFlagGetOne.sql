@@ -1,24 +1,43 @@
 
 SELECT
-		org_id
-	, application_id
-	, id
-	, uuid
-	, name
-	, label
-	, description
-	, is_enabled
-	, created
-	, created_by
-	, modified
-	, modified_by
+	  ff.org_id
+	, ff.application_id
+	, ffe.environment_id
+	, ff.id
+	, ff.uuid
+	, ff.name
+	, ff.label
+	, ff.description
+	, ffe.is_enabled
+	, ffe.rollout_percentage
+	, ff.flag_type
+	, ff.variants
+	, ff.default_variant
+	, ffe.rules
+	, ff.prerequisites
+	, ff.lifecycle
+	, ff.expires_at
+	, ff.created
+	, ff.created_by
+	, GREATEST(ff.modified, ffe.modified) AS modified
+	, CASE
+			WHEN ffe.modified IS NULL OR ff.modified > ffe.modified THEN ff.modified_by
+			ELSE ffe.modified_by
+		END AS modified_by
 
 FROM
-	application.feature_flag
+	application.feature_flag AS ff
+
+INNER JOIN application.feature_flag_environment AS ffe
+	ON
+		(
+					ffe.flag_id = ff.id
+			AND ffe.environment_id = $3
+		)
 
 WHERE
-	    org_id=$1
-	AND application_id=$2
-	AND ($3::bigint IS NULL    OR id=$3::bigint)
-	AND (COALESCE($4, '') = '' OR uuid=$4::uuid)
-	AND (COALESCE($5, '') = '' OR name=$5::text);
\ No newline at end of file
+	    ff.org_id=$1
+	AND ff.application_id=$2
+	AND ($4::bigint IS NULL    OR ff.id=$4::bigint)
+	AND (COALESCE($5, '') = '' OR ff.uuid=$5::uuid)
+	AND (COALESCE($6, '') = '' OR ff.name=$6::text);
\ No newline at end of file
@@ -0,0 +1,12 @@
+
+UPDATE application.feature_flag
+
+SET
+	  lifecycle = $4
+	, modified = (now() at time zone 'utc')
+	, modified_by = $5
+
+WHERE
+	    org_id = $1
+	AND application_id = $2
+	AND id = $3;
\ No newline at end of file
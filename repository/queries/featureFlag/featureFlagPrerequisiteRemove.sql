@@ -0,0 +1,21 @@
+
+UPDATE application.feature_flag
+
+SET
+	  prerequisites = (
+			SELECT
+				COALESCE(jsonb_agg(p), '[]'::jsonb)
+
+			FROM
+				jsonb_array_elements(prerequisites) AS p
+
+			WHERE
+				(p->>'flagId')::bigint <> $3
+		)
+	, modified = (now() at time zone 'utc')
+	, modified_by = $4
+
+WHERE
+	    org_id = $1
+	AND application_id = $2
+	AND prerequisites @> jsonb_build_array(jsonb_build_object('flagId', $3::bigint));
\ No newline at end of file
@@ -1,29 +1,98 @@
 
-UPDATE application.feature_flag
-
-SET
-	  name = $4
-	, label = $5
-	, description = $6
-	, is_enabled = $7
-	, modified = (now() at time zone 'utc')
-	, modified_by = $8
-
-WHERE
-	    org_id = $1
-	AND application_id = $2
-	AND id = $3
-
-RETURNING
-		org_id
-	, application_id
-	, id
-	, uuid
-	, name
-	, label
-	, description
-	, is_enabled
-	, created
-	, created_by
-	, modified
-	, modified_by;
\ No newline at end of file
+WITH ff AS (
+	UPDATE application.feature_flag
+
+	SET
+		  name = $5
+		, label = $6
+		, description = $7
+		, flag_type = $10
+		, variants = $11
+		, default_variant = $12
+		, prerequisites = $14
+		, expires_at = $15
+		, modified = (now() at time zone 'utc')
+		, modified_by = $16
+
+	WHERE
+		    org_id = $1
+		AND application_id = $2
+		AND id = $4
+
+	RETURNING
+		  org_id
+		, application_id
+		, id
+		, uuid
+		, name
+		, label
+		, description
+		, flag_type
+		, variants
+		, default_variant
+		, prerequisites
+		, lifecycle
+		, expires_at
+		, created
+		, created_by
+		, modified
+		, modified_by
+),
+
+ffe AS (
+	UPDATE application.feature_flag_environment
+
+	SET
+		  is_enabled = $8
+		, rollout_percentage = $9
+		, rules = $13
+		, modified = (now() at time zone 'utc')
+		, modified_by = $16
+
+	WHERE
+		    org_id = $1
+		AND application_id = $2
+		AND environment_id = $3
+		AND flag_id = $4
+
+	RETURNING
+		  flag_id
+		, environment_id
+		, is_enabled
+		, rollout_percentage
+		, rules
+		, modified
+		, modified_by
+)
+
+SELECT
+	  ff.org_id
+	, ff.application_id
+	, ffe.environment_id
+	, ff.id
+	, ff.uuid
+	, ff.name
+	, ff.label
+	, ff.description
+	, ffe.is_enabled
+	, ffe.rollout_percentage
+	, ff.flag_type
+	, ff.variants
+	, ff.default_variant
+	, ffe.rules
+	, ff.prerequisites
+	, ff.lifecycle
+	, ff.expires_at
+	, ff.created
+	, ff.created_by
+	, GREATEST(ff.modified, ffe.modified) AS modified
+	, CASE
+			WHEN ffe.modified IS NULL OR ff.modified > ffe.modified THEN ff.modified_by
+			ELSE ffe.modified_by
+		END AS modified_by
+
+FROM
+	ff
+
+INNER JOIN ffe
+	ON ffe.flag_id = ff.id;
\ No newline at end of file
@@ -0,0 +1,69 @@
+
+INSERT INTO application.feature_flag_version (
+	  org_id
+	, application_id
+	, environment_id
+	, flag_id
+	, version
+	, name
+	, label
+	, description
+	, is_enabled
+	, rollout_percentage
+	, flag_type
+	, variants
+	, default_variant
+	, rules
+	, prerequisites
+	, group_flags
+	, restored_from
+	, created_by
+)
+
+SELECT
+	  $1
+	, $2
+	, $3
+	, $4
+	, COALESCE(MAX(version), 0) + 1
+	, $5
+	, $6
+	, $7
+	, $8
+	, $9
+	, $10
+	, $11
+	, $12
+	, $13
+	, $14
+	, $15
+	, $16
+	, $17
+
+FROM
+	application.feature_flag_version
+
+WHERE
+	    flag_id = $4
+	AND environment_id = $3
+
+RETURNING
+	  org_id
+	, application_id
+	, environment_id
+	, flag_id
+	, version
+	, name
+	, label
+	, description
+	, is_enabled
+	, rollout_percentage
+	, flag_type
+	, variants
+	, default_variant
+	, rules
+	, prerequisites
+	, group_flags
+	, restored_from
+	, created
+	, created_by;
\ No newline at end of file
@@ -0,0 +1,31 @@
+
+SELECT
+	  org_id
+	, application_id
+	, environment_id
+	, flag_id
+	, version
+	, name
+	, label
+	, description
+	, is_enabled
+	, rollout_percentage
+	, flag_type
+	, variants
+	, default_variant
+	, rules
+	, prerequisites
+	, group_flags
+	, restored_from
+	, created
+	, created_by
+
+FROM
+	application.feature_flag_version
+
+WHERE
+	    org_id = $1
+	AND application_id = $2
+	AND environment_id = $3
+	AND flag_id = $4
+	AND version = $5;
\ No newline at end of file
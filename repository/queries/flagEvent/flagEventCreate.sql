@@ -0,0 +1,58 @@
+
+WITH inserted AS (
+	INSERT INTO application.flag_event (
+		  org_id
+		, application_id
+		, environment_id
+		, event_type
+		, flag_id
+		, group_id
+		, payload
+		, created_by
+	)
+
+	VALUES (
+		  $1
+		, $2
+		, $3
+		, $4
+		, $5
+		, $6
+		, $7
+		, $8
+	)
+
+	RETURNING
+		  org_id
+		, application_id
+		, environment_id
+		, id
+		, event_type
+		, flag_id
+		, group_id
+		, payload
+		, created
+		, created_by
+)
+
+SELECT
+	  inserted.org_id
+	, inserted.application_id
+	, inserted.environment_id
+	, inserted.id
+	, inserted.event_type
+	, inserted.flag_id
+	, inserted.group_id
+	, inserted.payload
+	, inserted.created
+	, inserted.created_by
+
+FROM
+	  inserted
+	, pg_notify(
+			'switchcraft_flag_event',
+			json_build_object(
+				  'applicationId', inserted.application_id
+				, 'id', inserted.id
+			)::text
+		);
\ No newline at end of file
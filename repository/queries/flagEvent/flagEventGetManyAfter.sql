@@ -0,0 +1,26 @@
+
+SELECT
+	  org_id
+	, application_id
+	, environment_id
+	, id
+	, event_type
+	, flag_id
+	, group_id
+	, payload
+	, created
+	, created_by
+
+FROM
+	application.flag_event
+
+WHERE
+	    org_id = $1
+	AND application_id = $2
+	AND (environment_id IS NULL OR environment_id = $3)
+	AND id > $4
+
+ORDER BY
+	id
+
+LIMIT $5;
\ No newline at end of file
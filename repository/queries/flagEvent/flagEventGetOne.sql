@@ -0,0 +1,18 @@
+
+SELECT
+	  org_id
+	, application_id
+	, environment_id
+	, id
+	, event_type
+	, flag_id
+	, group_id
+	, payload
+	, created
+	, created_by
+
+FROM
+	application.flag_event
+
+WHERE
+	id = $1;
\ No newline at end of file
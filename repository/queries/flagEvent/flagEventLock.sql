@@ -0,0 +1,2 @@
+
+SELECT pg_advisory_xact_lock(hashtext('switchcraft_flag_event'), $1::int);
\ No newline at end of file
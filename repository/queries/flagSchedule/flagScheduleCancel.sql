@@ -0,0 +1,34 @@
+
+UPDATE application.flag_schedule
+
+SET
+	  status = 'cancelled'
+	, modified = (now() at time zone 'utc')
+	, modified_by = $6
+
+WHERE
+	    org_id = $1
+	AND application_id = $2
+	AND environment_id = $3
+	AND flag_id = $4
+	AND id = $5
+	AND status = 'pending'
+
+RETURNING
+	  org_id
+	, application_id
+	, environment_id
+	, flag_id
+	, group_id
+	, id
+	, uuid
+	, is_enabled
+	, rollout_percentage
+	, execute_at
+	, status
+	, error
+	, executed
+	, created
+	, created_by
+	, modified
+	, modified_by;
\ No newline at end of file
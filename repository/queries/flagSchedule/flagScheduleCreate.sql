@@ -0,0 +1,43 @@
+
+INSERT INTO application.flag_schedule (
+	  org_id
+	, application_id
+	, environment_id
+	, flag_id
+	, group_id
+	, is_enabled
+	, rollout_percentage
+	, execute_at
+	, created_by
+)
+
+VALUES (
+	  $1
+	, $2
+	, $3
+	, $4
+	, $5
+	, $6
+	, $7
+	, $8
+	, $9
+)
+
+RETURNING
+	  org_id
+	, application_id
+	, environment_id
+	, flag_id
+	, group_id
+	, id
+	, uuid
+	, is_enabled
+	, rollout_percentage
+	, execute_at
+	, status
+	, error
+	, executed
+	, created
+	, created_by
+	, modified
+	, modified_by;
\ No newline at end of file
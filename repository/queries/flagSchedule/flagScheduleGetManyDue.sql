@@ -0,0 +1,34 @@
+
+SELECT
+	  org_id
+	, application_id
+	, environment_id
+	, flag_id
+	, group_id
+	, id
+	, uuid
+	, is_enabled
+	, rollout_percentage
+	, execute_at
+	, status
+	, error
+	, executed
+	, created
+	, created_by
+	, modified
+	, modified_by
+
+FROM
+	application.flag_schedule
+
+WHERE
+	    status = 'pending'
+	AND execute_at <= now()
+
+ORDER BY
+	  execute_at
+	, id
+
+LIMIT $1
+
+FOR UPDATE;
\ No newline at end of file
@@ -0,0 +1,2 @@
+
+SELECT pg_try_advisory_xact_lock(hashtext('switchcraft_flag_schedule'));
\ No newline at end of file
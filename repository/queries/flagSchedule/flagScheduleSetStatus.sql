@@ -0,0 +1,29 @@
+
+UPDATE application.flag_schedule
+
+SET
+	  status = $2
+	, error = $3
+	, executed = (now() at time zone 'utc')
+
+WHERE
+	id = $1
+
+RETURNING
+	  org_id
+	, application_id
+	, environment_id
+	, flag_id
+	, group_id
+	, id
+	, uuid
+	, is_enabled
+	, rollout_percentage
+	, execute_at
+	, status
+	, error
+	, executed
+	, created
+	, created_by
+	, modified
+	, modified_by;
\ No newline at end of file
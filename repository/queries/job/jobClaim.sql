@@ -0,0 +1,40 @@
+
+-- Claims the next due job, counting the attempt up front. Jobs left running by
+-- a crashed worker are claimed again once their lock runs out.
+UPDATE
+	queue.job
+
+SET
+	  status = 'running'
+	, attempts = attempts + 1
+	, locked_until = now() + make_interval(secs => $1)
+
+WHERE
+	id = (
+		SELECT
+			id
+		FROM
+			queue.job
+		WHERE
+			   (status = 'pending' AND run_at <= now())
+			OR (status = 'running' AND locked_until <= now())
+		ORDER BY
+			  run_at
+			, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	)
+
+RETURNING
+	  id
+	, uuid
+	, kind
+	, payload
+	, status
+	, attempts
+	, max_attempts
+	, run_at
+	, locked_until
+	, last_error
+	, created
+	, completed;
\ No newline at end of file
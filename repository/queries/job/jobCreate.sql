@@ -0,0 +1,26 @@
+
+INSERT INTO queue.job (
+	  kind
+	, payload
+	, max_attempts
+)
+
+VALUES (
+	  $1
+	, $2
+	, $3
+)
+
+RETURNING
+	  id
+	, uuid
+	, kind
+	, payload
+	, status
+	, attempts
+	, max_attempts
+	, run_at
+	, locked_until
+	, last_error
+	, created
+	, completed;
\ No newline at end of file
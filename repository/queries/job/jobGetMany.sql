@@ -0,0 +1,25 @@
+
+SELECT
+	  id
+	, uuid
+	, kind
+	, payload
+	, status
+	, attempts
+	, max_attempts
+	, run_at
+	, locked_until
+	, last_error
+	, created
+	, completed
+
+FROM
+	queue.job
+
+WHERE
+	$1::varchar IS NULL OR status = $1
+
+ORDER BY
+	id DESC
+
+LIMIT $2;
\ No newline at end of file
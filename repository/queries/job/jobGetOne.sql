@@ -0,0 +1,20 @@
+
+SELECT
+	  id
+	, uuid
+	, kind
+	, payload
+	, status
+	, attempts
+	, max_attempts
+	, run_at
+	, locked_until
+	, last_error
+	, created
+	, completed
+
+FROM
+	queue.job
+
+WHERE
+	id = $1;
\ No newline at end of file
@@ -0,0 +1,10 @@
+
+WITH deleted AS (
+	DELETE FROM queue.job WHERE status = 'succeeded' AND completed < $1 RETURNING id
+)
+
+SELECT
+	count(*) AS num_deleted
+
+FROM
+	deleted;
\ No newline at end of file
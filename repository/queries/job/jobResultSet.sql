@@ -0,0 +1,32 @@
+
+-- A nil run_at leaves the next run time as is. Only the worker holding the
+-- claimed attempt may record its result, a job reclaimed after its lock ran
+-- out is not matched.
+UPDATE
+	queue.job
+
+SET
+	  status = $2
+	, run_at = COALESCE($3, run_at)
+	, locked_until = NULL
+	, last_error = $4
+	, completed = CASE WHEN $2 IN ('succeeded', 'dead') THEN now() ELSE NULL END
+
+WHERE
+	    id = $1
+	AND status = 'running'
+	AND attempts = $5
+
+RETURNING
+	  id
+	, uuid
+	, kind
+	, payload
+	, status
+	, attempts
+	, max_attempts
+	, run_at
+	, locked_until
+	, last_error
+	, created
+	, completed;
\ No newline at end of file
@@ -0,0 +1,28 @@
+
+-- Gives a dead job a fresh set of attempts, keeping its last error
+UPDATE
+	queue.job
+
+SET
+	  status = 'pending'
+	, attempts = 0
+	, run_at = now()
+	, completed = NULL
+
+WHERE
+	    id = $1
+	AND status = 'dead'
+
+RETURNING
+	  id
+	, uuid
+	, kind
+	, payload
+	, status
+	, attempts
+	, max_attempts
+	, run_at
+	, locked_until
+	, last_error
+	, created
+	, completed;
\ No newline at end of file
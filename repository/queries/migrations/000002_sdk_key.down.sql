@@ -0,0 +1,5 @@
+BEGIN TRANSACTION;
+
+DROP TABLE application.sdk_key;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,24 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE application.sdk_key (
+	  org_id          bigint       NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, application_id  bigint       NOT NULL REFERENCES application.application(id) ON DELETE CASCADE ON UPDATE CASCADE
+
+	, id              bigint       NOT NULL PRIMARY KEY GENERATED ALWAYS AS IDENTITY
+	, uuid            uuid         NOT NULL UNIQUE DEFAULT gen_random_uuid()
+	, name            varchar(64)  NOT NULL
+	, key_type        varchar(16)  NOT NULL CHECK (key_type IN ('server', 'client'))
+	, key_prefix      varchar(32)  NOT NULL
+	, key_hash        char(64)     NOT NULL UNIQUE
+
+	, created      timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+	, created_by   bigint                    REFERENCES account.account(id)
+	, modified     timestamp with time zone
+	, modified_by  bigint                    REFERENCES account.account(id)
+	, revoked      timestamp with time zone
+	, revoked_by   bigint                    REFERENCES account.account(id)
+
+	, UNIQUE (application_id, name)
+);
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN TRANSACTION;
+
+DROP TABLE application.flag_event;
+
+END TRANSACTION;
\ No newline at end of file
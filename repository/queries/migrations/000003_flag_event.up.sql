@@ -0,0 +1,19 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE application.flag_event (
+	  org_id          bigint       NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, application_id  bigint       NOT NULL REFERENCES application.application(id) ON DELETE CASCADE ON UPDATE CASCADE
+
+	, id              bigint       NOT NULL PRIMARY KEY GENERATED ALWAYS AS IDENTITY
+	, event_type      varchar(32)  NOT NULL
+	, flag_id         bigint       NOT NULL
+	, group_id        bigint
+	, payload         jsonb        NOT NULL DEFAULT '{}'
+
+	, created      timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+	, created_by   bigint                    REFERENCES account.account(id)
+);
+
+CREATE INDEX flag_event_application_id_id_idx ON application.flag_event (application_id, id);
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+DROP TABLE audit.audit_log;
+DROP FUNCTION audit.audit_log_append_only;
+DROP SCHEMA audit;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,34 @@
+BEGIN TRANSACTION;
+
+CREATE SCHEMA audit;
+
+CREATE TABLE audit.audit_log (
+	  org_id       bigint
+
+	, id           bigint        NOT NULL PRIMARY KEY GENERATED ALWAYS AS IDENTITY
+	, actor_id     bigint
+	, trace_id     varchar(64)   NOT NULL
+	, action       varchar(16)   NOT NULL CHECK (action IN ('create', 'update', 'delete'))
+	, entity_type  varchar(32)   NOT NULL
+	, entity_id    bigint        NOT NULL
+	, before       jsonb
+	, after        jsonb
+
+	, created      timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+CREATE INDEX audit_log_org_id_created_idx ON audit.audit_log (org_id, created);
+CREATE INDEX audit_log_entity_idx ON audit.audit_log (entity_type, entity_id);
+CREATE INDEX audit_log_actor_id_idx ON audit.audit_log (actor_id);
+
+CREATE FUNCTION audit.audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit.audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+	BEFORE UPDATE OR DELETE OR TRUNCATE ON audit.audit_log
+	FOR EACH STATEMENT EXECUTE FUNCTION audit.audit_log_append_only();
+
+END TRANSACTION;
\ No newline at end of file
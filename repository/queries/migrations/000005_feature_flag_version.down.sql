@@ -0,0 +1,5 @@
+BEGIN TRANSACTION;
+
+DROP TABLE application.feature_flag_version;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,66 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE application.feature_flag_version (
+	  org_id          bigint       NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, application_id  bigint       NOT NULL REFERENCES application.application(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, flag_id         bigint       NOT NULL REFERENCES application.feature_flag(id) ON DELETE CASCADE ON UPDATE CASCADE
+
+	, version         int          NOT NULL
+	, name            varchar(64)  NOT NULL
+	, label           varchar(64)  NOT NULL
+	, description     text
+	, is_enabled      boolean      NOT NULL
+	, group_flags     jsonb        NOT NULL DEFAULT '[]'
+	, restored_from   int
+
+	, created      timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+	, created_by   bigint                    REFERENCES account.account(id)
+
+	, PRIMARY KEY (flag_id, version)
+);
+
+INSERT INTO application.feature_flag_version (
+	  org_id
+	, application_id
+	, flag_id
+	, version
+	, name
+	, label
+	, description
+	, is_enabled
+	, group_flags
+	, created_by
+)
+
+SELECT
+	  ff.org_id
+	, ff.application_id
+	, ff.id
+	, 1
+	, ff.name
+	, ff.label
+	, COALESCE(ff.description, '')
+	, ff.is_enabled
+	, COALESCE(
+			(
+				SELECT
+					json_agg(
+						json_build_object(
+							  'groupId', ogff.group_id
+							, 'isEnabled', ogff.is_enabled
+						)
+						ORDER BY ogff.group_id
+					)
+				FROM
+					application.org_group_feature_flag AS ogff
+				WHERE
+					ogff.flag_id = ff.id
+			),
+			'[]'
+		)
+	, COALESCE(ff.modified_by, ff.created_by)
+
+FROM
+	application.feature_flag AS ff;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN TRANSACTION;
+
+DROP TABLE account.org_group_role;
+DROP TABLE account.org_account_role;
+
+END TRANSACTION;
\ No newline at end of file
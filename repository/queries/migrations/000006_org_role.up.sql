@@ -0,0 +1,41 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE account.org_account_role (
+	  org_id      bigint       NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, account_id  bigint       NOT NULL REFERENCES account.account(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, role        varchar(16)  NOT NULL CHECK (role IN ('owner', 'admin', 'editor', 'viewer'))
+
+	, created      timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+	, created_by   bigint                    REFERENCES account.account(id)
+	, modified     timestamp with time zone
+	, modified_by  bigint                    REFERENCES account.account(id)
+
+	, PRIMARY KEY (org_id, account_id)
+);
+
+CREATE TABLE account.org_group_role (
+	  org_id    bigint       NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, group_id  bigint       NOT NULL REFERENCES account.org_group(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, role      varchar(16)  NOT NULL CHECK (role IN ('owner', 'admin', 'editor', 'viewer'))
+
+	, created      timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+	, created_by   bigint                    REFERENCES account.account(id)
+	, modified     timestamp with time zone
+	, modified_by  bigint                    REFERENCES account.account(id)
+
+	, PRIMARY KEY (org_id, group_id)
+);
+
+INSERT INTO account.org_account_role (org_id, account_id, role)
+SELECT
+	  a.org_id
+	, a.id
+	, 'admin'
+
+FROM
+	account.account a
+
+WHERE
+	a.org_id IS NOT NULL;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE application.feature_flag_version DROP COLUMN rollout_percentage;
+ALTER TABLE application.org_group_feature_flag DROP COLUMN rollout_percentage;
+ALTER TABLE application.feature_flag DROP COLUMN rollout_percentage;
+
+END TRANSACTION;
\ No newline at end of file
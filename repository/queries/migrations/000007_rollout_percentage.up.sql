@@ -0,0 +1,12 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE application.feature_flag
+	ADD COLUMN rollout_percentage smallint CHECK (rollout_percentage BETWEEN 0 AND 100);
+
+ALTER TABLE application.org_group_feature_flag
+	ADD COLUMN rollout_percentage smallint CHECK (rollout_percentage BETWEEN 0 AND 100);
+
+ALTER TABLE application.feature_flag_version
+	ADD COLUMN rollout_percentage smallint;
+
+END TRANSACTION;
\ No newline at end of file
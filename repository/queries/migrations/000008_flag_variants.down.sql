@@ -0,0 +1,15 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE application.feature_flag_version
+	  DROP COLUMN default_variant
+	, DROP COLUMN variants
+	, DROP COLUMN flag_type;
+
+ALTER TABLE application.org_group_feature_flag DROP COLUMN variant;
+
+ALTER TABLE application.feature_flag
+	  DROP COLUMN default_variant
+	, DROP COLUMN variants
+	, DROP COLUMN flag_type;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,16 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE application.feature_flag
+	  ADD COLUMN flag_type        varchar(16)  NOT NULL DEFAULT 'boolean' CHECK (flag_type IN ('boolean', 'string', 'number', 'json'))
+	, ADD COLUMN variants         jsonb        NOT NULL DEFAULT '[]'
+	, ADD COLUMN default_variant  varchar(64);
+
+ALTER TABLE application.org_group_feature_flag
+	ADD COLUMN variant varchar(64);
+
+ALTER TABLE application.feature_flag_version
+	  ADD COLUMN flag_type        varchar(16)  NOT NULL DEFAULT 'boolean'
+	, ADD COLUMN variants         jsonb        NOT NULL DEFAULT '[]'
+	, ADD COLUMN default_variant  varchar(64);
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE application.feature_flag_version DROP COLUMN rules;
+ALTER TABLE application.feature_flag DROP COLUMN rules;
+
+END TRANSACTION;
\ No newline at end of file
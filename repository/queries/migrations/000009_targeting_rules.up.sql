@@ -0,0 +1,9 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE application.feature_flag
+	ADD COLUMN rules jsonb NOT NULL DEFAULT '[]';
+
+ALTER TABLE application.feature_flag_version
+	ADD COLUMN rules jsonb NOT NULL DEFAULT '[]';
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+DROP TABLE application.feature_flag_segment;
+DROP TABLE account.org_segment_account;
+DROP TABLE account.org_segment;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,43 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE account.org_segment (
+	  org_id bigint NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+
+	, id              bigint       NOT NULL PRIMARY KEY GENERATED ALWAYS AS IDENTITY
+	, uuid            uuid         NOT NULL UNIQUE DEFAULT gen_random_uuid()
+	, name            varchar(64)  NOT NULL
+	, description     text
+	, rules           jsonb        NOT NULL DEFAULT '[]'
+
+	, created      timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+	, created_by   bigint                    REFERENCES account.account(id)
+	, modified     timestamp with time zone
+	, modified_by  bigint                    REFERENCES account.account(id)
+
+	, UNIQUE (org_id, name)
+);
+
+CREATE TABLE account.org_segment_account (
+	  org_id      bigint  NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, segment_id  bigint  NOT NULL REFERENCES account.org_segment(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, account_id  bigint  NOT NULL REFERENCES account.account(id) ON DELETE CASCADE ON UPDATE CASCADE
+
+	, created      timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+	, created_by   bigint                    REFERENCES account.account(id)
+
+	, PRIMARY KEY (segment_id, account_id)
+);
+
+-- Segments referenced by a flag's targeting rules, kept in sync on every flag
+-- write so a segment cannot be deleted out from under a flag
+CREATE TABLE application.feature_flag_segment (
+	  org_id      bigint  NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, flag_id     bigint  NOT NULL REFERENCES application.feature_flag(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, segment_id  bigint  NOT NULL REFERENCES account.org_segment(id) ON DELETE RESTRICT ON UPDATE CASCADE
+
+	, PRIMARY KEY (flag_id, segment_id)
+);
+
+CREATE INDEX ON application.feature_flag_segment (segment_id);
+
+END TRANSACTION;
\ No newline at end of file
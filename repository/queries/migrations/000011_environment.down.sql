@@ -0,0 +1,60 @@
+BEGIN TRANSACTION;
+
+-- Only the production environment can be kept, everything else is dropped
+
+ALTER TABLE application.flag_event
+	DROP COLUMN environment_id;
+
+DELETE FROM application.sdk_key AS sk
+USING application.environment AS e
+WHERE e.id = sk.environment_id AND e.slug <> 'production';
+
+ALTER TABLE application.sdk_key
+	DROP COLUMN environment_id;
+
+DELETE FROM application.feature_flag_segment AS ffs
+USING application.environment AS e
+WHERE e.id = ffs.environment_id AND e.slug <> 'production';
+
+ALTER TABLE application.feature_flag_segment
+	  DROP CONSTRAINT feature_flag_segment_pkey
+	, DROP COLUMN environment_id
+	, ADD PRIMARY KEY (flag_id, segment_id);
+
+DELETE FROM application.feature_flag_version AS ffv
+USING application.environment AS e
+WHERE e.id = ffv.environment_id AND e.slug <> 'production';
+
+ALTER TABLE application.feature_flag_version
+	  DROP CONSTRAINT feature_flag_version_pkey
+	, DROP COLUMN environment_id
+	, ADD PRIMARY KEY (flag_id, version);
+
+DELETE FROM application.org_group_feature_flag AS ogff
+USING application.environment AS e
+WHERE e.id = ogff.environment_id AND e.slug <> 'production';
+
+ALTER TABLE application.org_group_feature_flag
+	  DROP CONSTRAINT org_group_feature_flag_env_key
+	, DROP COLUMN environment_id
+	, ADD UNIQUE (org_id, group_id, flag_id);
+
+ALTER TABLE application.feature_flag
+	  ADD COLUMN is_enabled          boolean   NOT NULL DEFAULT false
+	, ADD COLUMN rollout_percentage  smallint  CHECK (rollout_percentage BETWEEN 0 AND 100)
+	, ADD COLUMN rules               jsonb     NOT NULL DEFAULT '[]';
+
+UPDATE application.feature_flag AS ff
+SET
+	  is_enabled = ffe.is_enabled
+	, rollout_percentage = ffe.rollout_percentage
+	, rules = ffe.rules
+FROM application.feature_flag_environment AS ffe
+INNER JOIN application.environment AS e
+	ON e.id = ffe.environment_id
+WHERE ffe.flag_id = ff.id AND e.slug = 'production';
+
+DROP TABLE application.feature_flag_environment;
+DROP TABLE application.environment;
+
+END TRANSACTION;
\ No newline at end of file
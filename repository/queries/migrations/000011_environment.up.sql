@@ -0,0 +1,145 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE application.environment (
+	  org_id          bigint       NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, application_id  bigint       NOT NULL REFERENCES application.application(id) ON DELETE CASCADE ON UPDATE CASCADE
+
+	, id              bigint       NOT NULL PRIMARY KEY GENERATED ALWAYS AS IDENTITY
+	, uuid            uuid         NOT NULL UNIQUE DEFAULT gen_random_uuid()
+	, name            varchar(64)  NOT NULL
+	, slug            varchar(64)  NOT NULL
+
+	, created      timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+	, created_by   bigint                    REFERENCES account.account(id)
+	, modified     timestamp with time zone
+	, modified_by  bigint                    REFERENCES account.account(id)
+
+	, UNIQUE (application_id, slug)
+);
+
+-- Existing flag state, overrides, and keys all move to a production
+-- environment in every application
+INSERT INTO application.environment (
+	  org_id
+	, application_id
+	, name
+	, slug
+	, created_by
+)
+
+SELECT
+	  app.org_id
+	, app.id
+	, 'Production'
+	, 'production'
+	, app.created_by
+
+FROM
+	application.application AS app;
+
+CREATE TABLE application.feature_flag_environment (
+	  org_id              bigint    NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, application_id      bigint    NOT NULL REFERENCES application.application(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, flag_id             bigint    NOT NULL REFERENCES application.feature_flag(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, environment_id      bigint    NOT NULL REFERENCES application.environment(id) ON DELETE CASCADE ON UPDATE CASCADE
+
+	, is_enabled          boolean   NOT NULL DEFAULT false
+	, rollout_percentage  smallint  CHECK (rollout_percentage BETWEEN 0 AND 100)
+	, rules               jsonb     NOT NULL DEFAULT '[]'
+
+	, modified     timestamp with time zone
+	, modified_by  bigint                    REFERENCES account.account(id)
+
+	, PRIMARY KEY (flag_id, environment_id)
+);
+
+INSERT INTO application.feature_flag_environment (
+	  org_id
+	, application_id
+	, flag_id
+	, environment_id
+	, is_enabled
+	, rollout_percentage
+	, rules
+	, modified
+	, modified_by
+)
+
+SELECT
+	  ff.org_id
+	, ff.application_id
+	, ff.id
+	, e.id
+	, ff.is_enabled
+	, ff.rollout_percentage
+	, ff.rules
+	, ff.modified
+	, ff.modified_by
+
+FROM
+	application.feature_flag AS ff
+
+INNER JOIN application.environment AS e
+	ON e.application_id = ff.application_id;
+
+ALTER TABLE application.feature_flag
+	  DROP COLUMN is_enabled
+	, DROP COLUMN rollout_percentage
+	, DROP COLUMN rules;
+
+ALTER TABLE application.org_group_feature_flag
+	ADD COLUMN environment_id bigint REFERENCES application.environment(id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+UPDATE application.org_group_feature_flag AS ogff
+SET environment_id = e.id
+FROM application.environment AS e
+WHERE e.application_id = ogff.application_id;
+
+ALTER TABLE application.org_group_feature_flag
+	  ALTER COLUMN environment_id SET NOT NULL
+	, DROP CONSTRAINT org_group_feature_flag_org_id_group_id_flag_id_key
+	, ADD CONSTRAINT org_group_feature_flag_env_key UNIQUE (org_id, group_id, flag_id, environment_id);
+
+ALTER TABLE application.feature_flag_version
+	ADD COLUMN environment_id bigint REFERENCES application.environment(id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+UPDATE application.feature_flag_version AS ffv
+SET environment_id = e.id
+FROM application.environment AS e
+WHERE e.application_id = ffv.application_id;
+
+ALTER TABLE application.feature_flag_version
+	  ALTER COLUMN environment_id SET NOT NULL
+	, DROP CONSTRAINT feature_flag_version_pkey
+	, ADD PRIMARY KEY (flag_id, environment_id, version);
+
+ALTER TABLE application.feature_flag_segment
+	ADD COLUMN environment_id bigint REFERENCES application.environment(id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+UPDATE application.feature_flag_segment AS ffs
+SET environment_id = e.id
+FROM application.feature_flag AS ff, application.environment AS e
+WHERE ff.id = ffs.flag_id AND e.application_id = ff.application_id;
+
+ALTER TABLE application.feature_flag_segment
+	  ALTER COLUMN environment_id SET NOT NULL
+	, DROP CONSTRAINT feature_flag_segment_pkey
+	, ADD PRIMARY KEY (flag_id, environment_id, segment_id);
+
+ALTER TABLE application.sdk_key
+	ADD COLUMN environment_id bigint REFERENCES application.environment(id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+UPDATE application.sdk_key AS sk
+SET environment_id = e.id
+FROM application.environment AS e
+WHERE e.application_id = sk.application_id;
+
+ALTER TABLE application.sdk_key
+	ALTER COLUMN environment_id SET NOT NULL;
+
+-- Events without an environment, such as flag definition changes, apply to
+-- every environment of the application
+ALTER TABLE application.flag_event
+	ADD COLUMN environment_id bigint REFERENCES application.environment(id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+END TRANSACTION;
\ No newline at end of file
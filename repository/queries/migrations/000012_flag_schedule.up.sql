@@ -0,0 +1,28 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE application.flag_schedule (
+	  org_id          bigint       NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, application_id  bigint       NOT NULL REFERENCES application.application(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, environment_id  bigint       NOT NULL REFERENCES application.environment(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, flag_id         bigint       NOT NULL REFERENCES application.feature_flag(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, group_id        bigint       REFERENCES account.org_group(id) ON DELETE CASCADE ON UPDATE CASCADE
+
+	, id                  bigint       NOT NULL PRIMARY KEY GENERATED ALWAYS AS IDENTITY
+	, uuid                uuid         NOT NULL UNIQUE DEFAULT gen_random_uuid()
+	, is_enabled          boolean      NOT NULL
+	, rollout_percentage  smallint     CHECK (rollout_percentage BETWEEN 0 AND 100)
+	, execute_at          timestamp with time zone  NOT NULL
+	, status              varchar(16)  NOT NULL DEFAULT 'pending'
+	, error               text
+	, executed            timestamp with time zone
+
+	, created      timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+	, created_by   bigint                    NOT NULL REFERENCES account.account(id)
+	, modified     timestamp with time zone
+	, modified_by  bigint                    REFERENCES account.account(id)
+);
+
+CREATE INDEX flag_schedule_flag_id_idx ON application.flag_schedule (flag_id, environment_id);
+CREATE INDEX flag_schedule_pending_execute_at_idx ON application.flag_schedule (execute_at) WHERE status = 'pending';
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE application.feature_flag_version DROP COLUMN prerequisites;
+ALTER TABLE application.feature_flag DROP COLUMN prerequisites;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,9 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE application.feature_flag
+	ADD COLUMN prerequisites jsonb NOT NULL DEFAULT '[]';
+
+ALTER TABLE application.feature_flag_version
+	ADD COLUMN prerequisites jsonb NOT NULL DEFAULT '[]';
+
+END TRANSACTION;
\ No newline at end of file
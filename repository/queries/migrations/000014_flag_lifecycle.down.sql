@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE application.feature_flag
+	  DROP COLUMN expires_at
+	, DROP COLUMN lifecycle;
+
+END TRANSACTION;
\ No newline at end of file
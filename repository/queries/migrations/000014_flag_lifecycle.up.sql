@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE application.feature_flag
+	  ADD COLUMN lifecycle   varchar(16)  NOT NULL DEFAULT 'active' CHECK (lifecycle IN ('active', 'deprecated', 'archived'))
+	, ADD COLUMN expires_at  timestamp with time zone;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN TRANSACTION;
+
+DROP TABLE account.webhook_delivery;
+DROP TABLE account.webhook;
+
+END TRANSACTION;
\ No newline at end of file
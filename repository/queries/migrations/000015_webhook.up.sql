@@ -0,0 +1,42 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE account.webhook (
+	  org_id bigint NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+
+	, id           bigint          NOT NULL PRIMARY KEY GENERATED ALWAYS AS IDENTITY
+	, uuid         uuid            NOT NULL UNIQUE DEFAULT gen_random_uuid()
+	, url          text            NOT NULL
+	, secret       varchar(256)    NOT NULL
+	, event_types  varchar(32)[]   NOT NULL DEFAULT '{}'
+	, is_enabled   boolean         NOT NULL DEFAULT true
+
+	, created      timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+	, created_by   bigint                    REFERENCES account.account(id)
+	, modified     timestamp with time zone
+	, modified_by  bigint                    REFERENCES account.account(id)
+);
+
+CREATE INDEX webhook_org_id_idx ON account.webhook (org_id);
+
+CREATE TABLE account.webhook_delivery (
+	  org_id      bigint  NOT NULL REFERENCES account.org(id) ON DELETE CASCADE ON UPDATE CASCADE
+	, webhook_id  bigint  NOT NULL REFERENCES account.webhook(id) ON DELETE CASCADE ON UPDATE CASCADE
+
+	, id               bigint       NOT NULL PRIMARY KEY GENERATED ALWAYS AS IDENTITY
+	, uuid             uuid         NOT NULL UNIQUE DEFAULT gen_random_uuid()
+	, event_type       varchar(32)  NOT NULL
+	, payload          jsonb        NOT NULL
+	, status           varchar(16)  NOT NULL DEFAULT 'pending'
+	, attempts         smallint     NOT NULL DEFAULT 0
+	, next_attempt_at  timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+	, response_status  smallint
+	, error            text
+	, delivered        timestamp with time zone
+
+	, created  timestamp with time zone  NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+CREATE INDEX webhook_delivery_webhook_id_id_idx ON account.webhook_delivery (webhook_id, id);
+CREATE INDEX webhook_delivery_pending_next_attempt_at_idx ON account.webhook_delivery (next_attempt_at) WHERE status = 'pending';
+
+END TRANSACTION;
\ No newline at end of file
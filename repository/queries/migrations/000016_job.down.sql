@@ -0,0 +1,9 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE account.webhook_delivery ADD COLUMN next_attempt_at timestamp with time zone NOT NULL DEFAULT (now() at time zone 'utc');
+CREATE INDEX webhook_delivery_pending_next_attempt_at_idx ON account.webhook_delivery (next_attempt_at) WHERE status = 'pending';
+
+DROP TABLE queue.job;
+DROP SCHEMA queue;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,29 @@
+BEGIN TRANSACTION;
+
+CREATE SCHEMA queue;
+
+CREATE TABLE queue.job (
+	  id            bigint       NOT NULL PRIMARY KEY GENERATED ALWAYS AS IDENTITY
+	, uuid          uuid         NOT NULL UNIQUE DEFAULT gen_random_uuid()
+	, kind          varchar(64)  NOT NULL
+	, payload       jsonb        NOT NULL
+	, status        varchar(16)  NOT NULL DEFAULT 'pending'
+	, attempts      smallint     NOT NULL DEFAULT 0
+	, max_attempts  smallint     NOT NULL
+	, run_at        timestamp with time zone  NOT NULL DEFAULT now()
+	, locked_until  timestamp with time zone
+	, last_error    text
+
+	, created    timestamp with time zone  NOT NULL DEFAULT now()
+	, completed  timestamp with time zone
+);
+
+CREATE INDEX job_pending_run_at_idx ON queue.job (run_at) WHERE status = 'pending';
+CREATE INDEX job_running_locked_until_idx ON queue.job (locked_until) WHERE status = 'running';
+CREATE INDEX job_status_id_idx ON queue.job (status, id);
+
+-- Webhook deliveries are retried by the job queue now
+DROP INDEX account.webhook_delivery_pending_next_attempt_at_idx;
+ALTER TABLE account.webhook_delivery DROP COLUMN next_attempt_at;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,10 @@
+
+WITH deleted AS (
+	DELETE FROM account.org_account_role WHERE org_id=$1 AND account_id=$2 RETURNING account_id
+)
+
+SELECT
+	count(*) AS num_deleted
+
+FROM
+	deleted;
\ No newline at end of file
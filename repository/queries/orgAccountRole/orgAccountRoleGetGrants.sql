@@ -0,0 +1,30 @@
+
+SELECT
+	  NULL::bigint AS group_id
+	, role
+
+FROM
+	account.org_account_role
+
+WHERE
+	    org_id=$1
+	AND account_id=$2
+
+UNION ALL
+
+SELECT
+	  gr.group_id
+	, gr.role
+
+FROM
+	account.org_group_role gr
+
+	INNER JOIN account.org_group_account ga
+		ON ga.group_id=gr.group_id
+
+WHERE
+	    gr.org_id=$1
+	AND ga.account_id=$2
+
+ORDER BY
+	group_id NULLS FIRST;
\ No newline at end of file
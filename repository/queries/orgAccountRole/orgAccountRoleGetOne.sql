@@ -0,0 +1,16 @@
+
+SELECT
+	  org_id
+	, account_id
+	, role
+	, created
+	, created_by
+	, modified
+	, modified_by
+
+FROM
+	account.org_account_role
+
+WHERE
+	    org_id=$1
+	AND account_id=$2;
\ No newline at end of file
@@ -0,0 +1,28 @@
+
+SELECT
+	  og.org_id
+	, og.id
+	, og.uuid
+	, og.name
+	, og.description
+	, og.created
+	, og.created_by
+	, og.modified
+	, og.modified_by
+
+FROM
+	account.org_group AS og
+
+INNER JOIN account.org_group_account AS oga
+	ON
+		(
+					oga.org_id = og.org_id
+			AND oga.group_id = og.id
+		)
+
+WHERE
+	    og.org_id = $1
+	AND oga.account_id = $2
+
+ORDER BY
+	og.id;
\ No newline at end of file
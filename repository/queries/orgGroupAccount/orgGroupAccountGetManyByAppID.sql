@@ -0,0 +1,26 @@
+
+-- Memberships for the SDK config, only groups with an override in the
+-- environment and only the columns evaluation needs
+SELECT
+	  oga.group_id
+	, oga.account_id
+
+FROM
+	account.org_group_account AS oga
+
+WHERE
+	    oga.org_id = $1
+	AND oga.group_id IN (
+		SELECT DISTINCT
+			ogff.group_id
+		FROM
+			application.org_group_feature_flag AS ogff
+		WHERE
+			    ogff.org_id = $1
+			AND ogff.application_id = $2
+			AND ogff.environment_id = $3
+	)
+
+ORDER BY
+	  oga.account_id
+	, oga.group_id;
\ No newline at end of file
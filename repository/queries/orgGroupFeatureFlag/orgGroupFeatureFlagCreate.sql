@@ -3,8 +3,11 @@ INSERT INTO application.org_group_feature_flag (
 	  org_id
 	, group_id
 	, application_id
+	, environment_id
 	, flag_id
 	, is_enabled
+	, rollout_percentage
+	, variant
 	, created_by
 )
 
@@ -15,14 +18,20 @@ VALUES (
 	, $4
 	, $5
 	, $6
+	, $7
+	, $8
+	, $9
 )
 
 RETURNING
 	  org_id
 	, group_id
 	, application_id
+	, environment_id
 	, flag_id
 	, is_enabled
+	, rollout_percentage
+	, variant
 	, created
 	, created_by
 	, modified
@@ -7,7 +7,8 @@ WITH deleted AS (
 		    org_id = $1
 		AND group_id = $2
 		AND application_id = $3
-		AND flag_id = $4
+		AND environment_id = $4
+		AND flag_id = $5
 
 	RETURNING group_id
 )
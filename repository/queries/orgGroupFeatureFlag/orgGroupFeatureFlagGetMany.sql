@@ -0,0 +1,26 @@
+
+SELECT
+	  org_id
+	, group_id
+	, application_id
+	, environment_id
+	, flag_id
+	, is_enabled
+	, rollout_percentage
+	, variant
+	, created
+	, created_by
+	, modified
+	, modified_by
+
+FROM
+	application.org_group_feature_flag
+
+WHERE
+	    org_id = $1
+	AND application_id = $2
+	AND environment_id = $3
+
+ORDER BY
+	  flag_id
+	, group_id;
\ No newline at end of file
@@ -0,0 +1,38 @@
+
+-- Every group override in an app environment paired with each account in
+-- the group, so overrides can be resolved for a whole org at once
+SELECT
+	  oga.account_id
+	, ogff.org_id
+	, ogff.group_id
+	, ogff.application_id
+	, ogff.environment_id
+	, ogff.flag_id
+	, ogff.is_enabled
+	, ogff.rollout_percentage
+	, ogff.variant
+	, ogff.created
+	, ogff.created_by
+	, ogff.modified
+	, ogff.modified_by
+
+FROM
+	application.org_group_feature_flag AS ogff
+
+INNER JOIN account.org_group_account AS oga
+	ON
+		(
+					oga.org_id = ogff.org_id
+			AND oga.group_id = ogff.group_id
+		)
+
+WHERE
+	    ogff.org_id = $1
+	AND ogff.application_id = $2
+	AND ogff.environment_id = $3
+	AND ($4::bigint IS NULL OR ogff.flag_id = $4::bigint)
+
+ORDER BY
+	  oga.account_id
+	, ogff.flag_id
+	, ogff.group_id;
\ No newline at end of file
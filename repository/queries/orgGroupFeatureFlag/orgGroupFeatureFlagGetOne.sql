@@ -3,8 +3,11 @@ SELECT
 	  org_id
 	, group_id
 	, application_id
+	, environment_id
 	, flag_id
 	, is_enabled
+	, rollout_percentage
+	, variant
 	, created
 	, created_by
 	, modified
@@ -17,4 +20,5 @@ WHERE
 	    org_id = $1
 	AND group_id = $2
 	AND application_id = $3
-	AND flag_id = $4;
\ No newline at end of file
+	AND environment_id = $4
+	AND flag_id = $5;
\ No newline at end of file
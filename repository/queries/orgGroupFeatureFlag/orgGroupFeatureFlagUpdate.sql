@@ -3,22 +3,28 @@ UPDATE
 	application.org_group_feature_flag
 
 SET
-	  is_enabled = $5
+	  is_enabled = $6
+	, rollout_percentage = $7
+	, variant = $8
 	, modified = (now() at time zone 'utc')
-	, modified_by = $6
+	, modified_by = $9
 
 WHERE
 	    org_id = $1
 	AND group_id = $2
 	AND application_id = $3
-	AND flag_id = $4
+	AND environment_id = $4
+	AND flag_id = $5
 
 RETURNING
 	  org_id
 	, group_id
 	, application_id
+	, environment_id
 	, flag_id
 	, is_enabled
+	, rollout_percentage
+	, variant
 	, created
 	, created_by
 	, modified
@@ -0,0 +1,35 @@
+
+INSERT INTO application.org_group_feature_flag (
+	  org_id
+	, group_id
+	, application_id
+	, environment_id
+	, flag_id
+	, is_enabled
+	, rollout_percentage
+	, variant
+	, created_by
+)
+
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+
+ON CONFLICT (org_id, group_id, flag_id, environment_id) DO UPDATE SET
+	  is_enabled = EXCLUDED.is_enabled
+	, rollout_percentage = EXCLUDED.rollout_percentage
+	, variant = EXCLUDED.variant
+	, modified = (now() at time zone 'utc')
+	, modified_by = EXCLUDED.created_by
+
+RETURNING
+	  org_id
+	, group_id
+	, application_id
+	, environment_id
+	, flag_id
+	, is_enabled
+	, rollout_percentage
+	, variant
+	, created
+	, created_by
+	, modified
+	, modified_by;
\ No newline at end of file
@@ -0,0 +1,16 @@
+
+SELECT
+	  org_id
+	, group_id
+	, role
+	, created
+	, created_by
+	, modified
+	, modified_by
+
+FROM
+	account.org_group_role
+
+WHERE
+	    org_id=$1
+	AND group_id=$2;
\ No newline at end of file
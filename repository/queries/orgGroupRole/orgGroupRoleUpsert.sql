@@ -0,0 +1,23 @@
+
+INSERT INTO account.org_group_role (
+	  org_id
+	, group_id
+	, role
+	, created_by
+)
+
+VALUES ($1, $2, $3, $4)
+
+ON CONFLICT (org_id, group_id) DO UPDATE SET
+	  role = EXCLUDED.role
+	, modified = (now() at time zone 'utc')
+	, modified_by = EXCLUDED.created_by
+
+RETURNING
+	  org_id
+	, group_id
+	, role
+	, created
+	, created_by
+	, modified
+	, modified_by;
\ No newline at end of file
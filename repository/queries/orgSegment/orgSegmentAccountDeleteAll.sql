@@ -0,0 +1,2 @@
+
+DELETE FROM account.org_segment_account WHERE org_id=$1 AND segment_id=$2;
\ No newline at end of file
@@ -0,0 +1,29 @@
+
+INSERT INTO account.org_segment (
+	  org_id
+	, name
+	, description
+	, rules
+	, created_by
+)
+
+VALUES (
+	  $1
+	, $2
+	, $3
+	, $4
+	, $5
+)
+
+RETURNING
+	  org_id
+	, id
+	, uuid
+	, name
+	, description
+	, rules
+	, '{}'::bigint[] AS account_ids
+	, created
+	, created_by
+	, modified
+	, modified_by;
\ No newline at end of file
@@ -0,0 +1,20 @@
+
+INSERT INTO application.feature_flag_segment (
+	  org_id
+	, environment_id
+	, flag_id
+	, segment_id
+)
+
+SELECT
+	  $1
+	, $2
+	, $3
+	, s.id
+
+FROM
+	account.org_segment AS s
+
+WHERE
+	    s.org_id = $1
+	AND s.id = ANY($4::bigint[]);
\ No newline at end of file
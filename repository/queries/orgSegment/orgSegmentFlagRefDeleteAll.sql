@@ -0,0 +1,2 @@
+
+DELETE FROM application.feature_flag_segment WHERE org_id=$1 AND environment_id=$2 AND flag_id=$3;
\ No newline at end of file
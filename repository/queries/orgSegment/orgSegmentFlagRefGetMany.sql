@@ -0,0 +1,30 @@
+
+SELECT
+	  ffs.segment_id
+	, ff.application_id
+	, a.slug AS application_slug
+	, ffs.environment_id
+	, e.slug AS environment_slug
+	, ff.id AS flag_id
+	, ff.name AS flag_name
+
+FROM
+	application.feature_flag_segment AS ffs
+
+INNER JOIN application.feature_flag AS ff
+	ON ff.id = ffs.flag_id
+
+INNER JOIN application.application AS a
+	ON a.id = ff.application_id
+
+INNER JOIN application.environment AS e
+	ON e.id = ffs.environment_id
+
+WHERE
+	    ffs.org_id = $1
+	AND ffs.segment_id = $2
+
+ORDER BY
+	  a.slug
+	, ff.name
+	, e.slug;
\ No newline at end of file
@@ -0,0 +1,30 @@
+
+SELECT
+	  s.org_id
+	, s.id
+	, s.uuid
+	, s.name
+	, s.description
+	, s.rules
+	, COALESCE(
+			(
+				SELECT
+					array_agg(osa.account_id ORDER BY osa.account_id)
+				FROM
+					account.org_segment_account AS osa
+				WHERE
+					osa.segment_id = s.id
+			),
+			'{}'
+		) AS account_ids
+	, s.created
+	, s.created_by
+	, s.modified
+	, s.modified_by
+
+FROM
+	account.org_segment AS s
+
+WHERE
+	    s.org_id = $1
+	AND s.id = ANY($2::bigint[]);
\ No newline at end of file
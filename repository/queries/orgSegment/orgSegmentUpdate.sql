@@ -0,0 +1,37 @@
+
+UPDATE
+	account.org_segment AS s
+
+SET
+	  name = $3
+	, description = $4
+	, rules = $5
+	, modified = (now() at time zone 'utc')
+	, modified_by = $6
+
+WHERE
+	    s.org_id = $1
+	AND s.id=$2
+
+RETURNING
+	  s.org_id
+	, s.id
+	, s.uuid
+	, s.name
+	, s.description
+	, s.rules
+	, COALESCE(
+			(
+				SELECT
+					array_agg(osa.account_id ORDER BY osa.account_id)
+				FROM
+					account.org_segment_account AS osa
+				WHERE
+					osa.segment_id = s.id
+			),
+			'{}'
+		) AS account_ids
+	, s.created
+	, s.created_by
+	, s.modified
+	, s.modified_by;
\ No newline at end of file
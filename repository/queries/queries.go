@@ -68,6 +68,9 @@ var OrgGroupCreate string
 //go:embed orgGroup/orgGroupGetMany.sql
 var OrgGroupGetMany string
 
+//go:embed orgGroup/orgGroupGetManyByAccountID.sql
+var OrgGroupGetManyByAccountID string
+
 //go:embed orgGroup/orgGroupGetOne.sql
 var OrgGroupGetOne string
 
@@ -87,6 +90,9 @@ var OrgGroupAccountCreate string
 //go:embed orgGroupAccount/orgGroupAccountGetMany.sql
 var OrgGroupAccountGetMany string
 
+//go:embed orgGroupAccount/orgGroupAccountGetManyByAppID.sql
+var OrgGroupAccountGetManyByAppID string
+
 //go:embed orgGroupAccount/orgGroupAccountDeleteOne.sql
 var OrgGroupAccountDeleteOne string
 
@@ -141,6 +147,9 @@ var FeatureFlagCreate string
 //go:embed featureFlag/featureFlagGetMany.sql
 var FeatureFlagGetMany string
 
+//go:embed featureFlag/featureFlagGetManyForAccount.sql
+var FeatureFlagGetManyForAccount string
+
 //go:embed featureFlag/featureFlagGetOne.sql
 var FeatureFlagGetOne string
 
@@ -150,6 +159,28 @@ var FeatureFlagUpdate string
 //go:embed featureFlag/featureFlagDelete.sql
 var FeatureFlagDelete string
 
+//go:embed featureFlag/featureFlagPrerequisiteRemove.sql
+var FeatureFlagPrerequisiteRemove string
+
+//go:embed featureFlag/featureFlagLifecycleUpdate.sql
+var FeatureFlagLifecycleUpdate string
+
+//go:embed featureFlag/featureFlagGetManyStale.sql
+var FeatureFlagGetManyStale string
+
+/* ------------------------------------ */
+/* === FEATURE FLAG VERSION QUERIES === */
+/* ------------------------------------ */
+
+//go:embed featureFlagVersion/featureFlagVersionCreate.sql
+var FeatureFlagVersionCreate string
+
+//go:embed featureFlagVersion/featureFlagVersionGetMany.sql
+var FeatureFlagVersionGetMany string
+
+//go:embed featureFlagVersion/featureFlagVersionGetOne.sql
+var FeatureFlagVersionGetOne string
+
 /* -------------------------------------- */
 /* === ORG GROUP FEATURE FLAG QUERIES === */
 /* -------------------------------------- */
@@ -157,18 +188,244 @@ var FeatureFlagDelete string
 //go:embed orgGroupFeatureFlag/orgGroupFeatureFlagCreate.sql
 var OrgGroupFeatureFlagCreate string
 
+//go:embed orgGroupFeatureFlag/orgGroupFeatureFlagGetMany.sql
+var OrgGroupFeatureFlagGetMany string
+
 //go:embed orgGroupFeatureFlag/orgGroupFeatureFlagGetByFlagID.sql
 var OrgGroupFeatureFlagGetByFlagID string
 
+//go:embed orgGroupFeatureFlag/orgGroupFeatureFlagGetByAccountID.sql
+var OrgGroupFeatureFlagGetByAccountID string
+
+//go:embed orgGroupFeatureFlag/orgGroupFeatureFlagGetManyByAccounts.sql
+var OrgGroupFeatureFlagGetManyByAccounts string
+
 //go:embed orgGroupFeatureFlag/orgGroupFeatureFlagGetOne.sql
 var OrgGroupFeatureFlagGetOne string
 
 //go:embed orgGroupFeatureFlag/orgGroupFeatureFlagUpdate.sql
 var OrgGroupFeatureFlagUpdate string
 
+//go:embed orgGroupFeatureFlag/orgGroupFeatureFlagUpsert.sql
+var OrgGroupFeatureFlagUpsert string
+
 //go:embed orgGroupFeatureFlag/orgGroupFeatureFlagDelete.sql
 var OrgGroupFeatureFlagDelete string
 
+/* ----------------------- */
+/* === SDK KEY QUERIES === */
+/* ----------------------- */
+
+//go:embed sdkKey/sdkKeyCreate.sql
+var SDKKeyCreate string
+
+//go:embed sdkKey/sdkKeyGetMany.sql
+var SDKKeyGetMany string
+
+//go:embed sdkKey/sdkKeyGetOne.sql
+var SDKKeyGetOne string
+
+//go:embed sdkKey/sdkKeyGetByHash.sql
+var SDKKeyGetByHash string
+
+//go:embed sdkKey/sdkKeyRotate.sql
+var SDKKeyRotate string
+
+//go:embed sdkKey/sdkKeyRevoke.sql
+var SDKKeyRevoke string
+
+/* -------------------------- */
+/* === FLAG EVENT QUERIES === */
+/* -------------------------- */
+
+//go:embed flagEvent/flagEventCreate.sql
+var FlagEventCreate string
+
+//go:embed flagEvent/flagEventGetOne.sql
+var FlagEventGetOne string
+
+//go:embed flagEvent/flagEventGetManyAfter.sql
+var FlagEventGetManyAfter string
+
+//go:embed flagEvent/flagEventLock.sql
+var FlagEventLock string
+
+/* ------------------------- */
+/* === AUDIT LOG QUERIES === */
+/* ------------------------- */
+
+//go:embed auditLog/auditLogCreate.sql
+var AuditLogCreate string
+
+//go:embed auditLog/auditLogGetMany.sql
+var AuditLogGetMany string
+
+/* -------------------------------- */
+/* === ORG ACCOUNT ROLE QUERIES === */
+/* -------------------------------- */
+
+//go:embed orgAccountRole/orgAccountRoleGetOne.sql
+var OrgAccountRoleGetOne string
+
+//go:embed orgAccountRole/orgAccountRoleGetGrants.sql
+var OrgAccountRoleGetGrants string
+
+//go:embed orgAccountRole/orgAccountRoleUpsert.sql
+var OrgAccountRoleUpsert string
+
+//go:embed orgAccountRole/orgAccountRoleDelete.sql
+var OrgAccountRoleDelete string
+
+/* ------------------------------ */
+/* === ORG GROUP ROLE QUERIES === */
+/* ------------------------------ */
+
+//go:embed orgGroupRole/orgGroupRoleGetOne.sql
+var OrgGroupRoleGetOne string
+
+//go:embed orgGroupRole/orgGroupRoleUpsert.sql
+var OrgGroupRoleUpsert string
+
+//go:embed orgGroupRole/orgGroupRoleDelete.sql
+var OrgGroupRoleDelete string
+
+/* --------------------------- */
+/* === ORG SEGMENT QUERIES === */
+/* --------------------------- */
+
+//go:embed orgSegment/orgSegmentCreate.sql
+var OrgSegmentCreate string
+
+//go:embed orgSegment/orgSegmentGetMany.sql
+var OrgSegmentGetMany string
+
+//go:embed orgSegment/orgSegmentGetManyByID.sql
+var OrgSegmentGetManyByID string
+
+//go:embed orgSegment/orgSegmentGetOne.sql
+var OrgSegmentGetOne string
+
+//go:embed orgSegment/orgSegmentUpdate.sql
+var OrgSegmentUpdate string
+
+//go:embed orgSegment/orgSegmentDelete.sql
+var OrgSegmentDelete string
+
+//go:embed orgSegment/orgSegmentAccountDeleteAll.sql
+var OrgSegmentAccountDeleteAll string
+
+//go:embed orgSegment/orgSegmentFlagRefGetMany.sql
+var OrgSegmentFlagRefGetMany string
+
+//go:embed orgSegment/orgSegmentFlagRefCreate.sql
+var OrgSegmentFlagRefCreate string
+
+//go:embed orgSegment/orgSegmentFlagRefDeleteAll.sql
+var OrgSegmentFlagRefDeleteAll string
+
+/* --------------------------- */
+/* === ENVIRONMENT QUERIES === */
+/* --------------------------- */
+
+//go:embed environment/environmentCreate.sql
+var EnvironmentCreate string
+
+//go:embed environment/environmentGetMany.sql
+var EnvironmentGetMany string
+
+//go:embed environment/environmentGetOne.sql
+var EnvironmentGetOne string
+
+//go:embed environment/environmentUpdate.sql
+var EnvironmentUpdate string
+
+//go:embed environment/environmentDelete.sql
+var EnvironmentDelete string
+
+/* ----------------------------- */
+/* === FLAG SCHEDULE QUERIES === */
+/* ----------------------------- */
+
+//go:embed flagSchedule/flagScheduleCreate.sql
+var FlagScheduleCreate string
+
+//go:embed flagSchedule/flagScheduleGetMany.sql
+var FlagScheduleGetMany string
+
+//go:embed flagSchedule/flagScheduleGetOne.sql
+var FlagScheduleGetOne string
+
+//go:embed flagSchedule/flagScheduleCancel.sql
+var FlagScheduleCancel string
+
+//go:embed flagSchedule/flagScheduleGetManyDue.sql
+var FlagScheduleGetManyDue string
+
+//go:embed flagSchedule/flagScheduleSetStatus.sql
+var FlagScheduleSetStatus string
+
+//go:embed flagSchedule/flagScheduleLock.sql
+var FlagScheduleLock string
+
+/* ----------------------- */
+/* === WEBHOOK QUERIES === */
+/* ----------------------- */
+
+//go:embed webhook/webhookCreate.sql
+var WebhookCreate string
+
+//go:embed webhook/webhookGetMany.sql
+var WebhookGetMany string
+
+//go:embed webhook/webhookGetOne.sql
+var WebhookGetOne string
+
+//go:embed webhook/webhookUpdate.sql
+var WebhookUpdate string
+
+//go:embed webhook/webhookDelete.sql
+var WebhookDelete string
+
+//go:embed webhook/webhookDeliveryEnqueue.sql
+var WebhookDeliveryEnqueue string
+
+//go:embed webhook/webhookDeliveryGetMany.sql
+var WebhookDeliveryGetMany string
+
+//go:embed webhook/webhookDeliveryGetOne.sql
+var WebhookDeliveryGetOne string
+
+//go:embed webhook/webhookDeliveryAttemptSet.sql
+var WebhookDeliveryAttemptSet string
+
+//go:embed webhook/webhookDeliveryRedeliver.sql
+var WebhookDeliveryRedeliver string
+
+/* ------------------- */
+/* === JOB QUERIES === */
+/* ------------------- */
+
+//go:embed job/jobCreate.sql
+var JobCreate string
+
+//go:embed job/jobGetMany.sql
+var JobGetMany string
+
+//go:embed job/jobGetOne.sql
+var JobGetOne string
+
+//go:embed job/jobClaim.sql
+var JobClaim string
+
+//go:embed job/jobResultSet.sql
+var JobResultSet string
+
+//go:embed job/jobRetry.sql
+var JobRetry string
+
+//go:embed job/jobPurge.sql
+var JobPurge string
+
 /* ------------------------- */
 /* === MIGRATION QUERIES === */
 /* ------------------------- */
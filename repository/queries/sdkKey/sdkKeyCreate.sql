@@ -0,0 +1,39 @@
+
+INSERT INTO application.sdk_key (
+	  org_id
+	, application_id
+	, environment_id
+	, name
+	, key_type
+	, key_prefix
+	, key_hash
+	, created_by
+)
+
+VALUES (
+	  $1
+	, $2
+	, $3
+	, $4
+	, $5
+	, $6
+	, $7
+	, $8
+)
+
+RETURNING
+	  org_id
+	, application_id
+	, environment_id
+	, id
+	, uuid
+	, name
+	, key_type
+	, key_prefix
+	, key_hash
+	, created
+	, created_by
+	, modified
+	, modified_by
+	, revoked
+	, revoked_by;
\ No newline at end of file
@@ -0,0 +1,24 @@
+
+SELECT
+	  org_id
+	, application_id
+	, environment_id
+	, id
+	, uuid
+	, name
+	, key_type
+	, key_prefix
+	, key_hash
+	, created
+	, created_by
+	, modified
+	, modified_by
+	, revoked
+	, revoked_by
+
+FROM
+	application.sdk_key
+
+WHERE
+	    key_hash = $1
+	AND revoked IS NULL;
\ No newline at end of file
@@ -0,0 +1,27 @@
+
+SELECT
+	  org_id
+	, application_id
+	, environment_id
+	, id
+	, uuid
+	, name
+	, key_type
+	, key_prefix
+	, key_hash
+	, created
+	, created_by
+	, modified
+	, modified_by
+	, revoked
+	, revoked_by
+
+FROM
+	application.sdk_key
+
+WHERE
+	    org_id = $1
+	AND application_id = $2
+
+ORDER BY
+	id;
\ No newline at end of file
@@ -0,0 +1,29 @@
+
+UPDATE application.sdk_key
+
+SET
+	  revoked = (now() at time zone 'utc')
+	, revoked_by = $4
+
+WHERE
+	    org_id = $1
+	AND application_id = $2
+	AND id = $3
+	AND revoked IS NULL
+
+RETURNING
+	  org_id
+	, application_id
+	, environment_id
+	, id
+	, uuid
+	, name
+	, key_type
+	, key_prefix
+	, key_hash
+	, created
+	, created_by
+	, modified
+	, modified_by
+	, revoked
+	, revoked_by;
\ No newline at end of file
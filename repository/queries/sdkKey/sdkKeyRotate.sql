@@ -0,0 +1,31 @@
+
+UPDATE application.sdk_key
+
+SET
+	  key_prefix = $4
+	, key_hash = $5
+	, modified = (now() at time zone 'utc')
+	, modified_by = $6
+
+WHERE
+	    org_id = $1
+	AND application_id = $2
+	AND id = $3
+	AND revoked IS NULL
+
+RETURNING
+	  org_id
+	, application_id
+	, environment_id
+	, id
+	, uuid
+	, name
+	, key_type
+	, key_prefix
+	, key_hash
+	, created
+	, created_by
+	, modified
+	, modified_by
+	, revoked
+	, revoked_by;
\ No newline at end of file
@@ -0,0 +1,31 @@
+
+INSERT INTO account.webhook (
+	  org_id
+	, url
+	, secret
+	, event_types
+	, is_enabled
+	, created_by
+)
+
+VALUES (
+	  $1
+	, $2
+	, $3
+	, $4
+	, $5
+	, $6
+)
+
+RETURNING
+	  org_id
+	, id
+	, uuid
+	, url
+	, secret
+	, event_types
+	, is_enabled
+	, created
+	, created_by
+	, modified
+	, modified_by;
\ No newline at end of file
@@ -0,0 +1,10 @@
+
+WITH deleted AS (
+	DELETE FROM account.webhook WHERE org_id=$1 AND id=$2 RETURNING id
+)
+
+SELECT
+	count(*) AS num_deleted
+
+FROM
+	deleted;
\ No newline at end of file
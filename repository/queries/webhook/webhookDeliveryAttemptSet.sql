@@ -0,0 +1,27 @@
+
+UPDATE
+	account.webhook_delivery
+
+SET
+	  status = $2
+	, attempts = attempts + 1
+	, response_status = $3
+	, error = $4
+	, delivered = CASE WHEN $2 = 'delivered' THEN (now() at time zone 'utc') ELSE delivered END
+
+WHERE
+	id = $1
+
+RETURNING
+	  org_id
+	, webhook_id
+	, id
+	, uuid
+	, event_type
+	, payload
+	, status
+	, attempts
+	, response_status
+	, error
+	, delivered
+	, created;
\ No newline at end of file
@@ -0,0 +1,29 @@
+
+-- One delivery for every enabled webhook of the org subscribed to the event,
+-- an empty event_types subscribes to every event
+INSERT INTO account.webhook_delivery (
+	  org_id
+	, webhook_id
+	, event_type
+	, payload
+)
+
+SELECT
+	  w.org_id
+	, w.id
+	, $2
+	, $3
+
+FROM
+	account.webhook AS w
+
+WHERE
+	    w.org_id = $1
+	AND w.is_enabled
+	AND (
+				cardinality(w.event_types) = 0
+			OR $2 = ANY(w.event_types)
+		)
+
+RETURNING
+	id;
\ No newline at end of file
@@ -0,0 +1,21 @@
+
+SELECT
+	  org_id
+	, webhook_id
+	, id
+	, uuid
+	, event_type
+	, payload
+	, status
+	, attempts
+	, response_status
+	, error
+	, delivered
+	, created
+
+FROM
+	account.webhook_delivery
+
+WHERE
+	    org_id = $1
+	AND id = $2;
\ No newline at end of file
@@ -0,0 +1,35 @@
+
+INSERT INTO account.webhook_delivery (
+	  org_id
+	, webhook_id
+	, event_type
+	, payload
+)
+
+SELECT
+	  org_id
+	, webhook_id
+	, event_type
+	, payload
+
+FROM
+	account.webhook_delivery
+
+WHERE
+	    org_id = $1
+	AND webhook_id = $2
+	AND id = $3
+
+RETURNING
+	  org_id
+	, webhook_id
+	, id
+	, uuid
+	, event_type
+	, payload
+	, status
+	, attempts
+	, response_status
+	, error
+	, delivered
+	, created;
\ No newline at end of file
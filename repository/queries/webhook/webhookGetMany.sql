@@ -0,0 +1,22 @@
+
+SELECT
+	  org_id
+	, id
+	, uuid
+	, url
+	, secret
+	, event_types
+	, is_enabled
+	, created
+	, created_by
+	, modified
+	, modified_by
+
+FROM
+	account.webhook
+
+WHERE
+	org_id = $1
+
+ORDER BY
+	id;
\ No newline at end of file
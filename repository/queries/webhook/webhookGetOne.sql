@@ -0,0 +1,20 @@
+
+SELECT
+	  org_id
+	, id
+	, uuid
+	, url
+	, secret
+	, event_types
+	, is_enabled
+	, created
+	, created_by
+	, modified
+	, modified_by
+
+FROM
+	account.webhook
+
+WHERE
+	    org_id = $1
+	AND id = $2;
\ No newline at end of file
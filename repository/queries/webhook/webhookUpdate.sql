@@ -0,0 +1,28 @@
+
+UPDATE
+	account.webhook
+
+SET
+	  url = $3
+	, secret = COALESCE(NULLIF($4, ''), secret)
+	, event_types = $5
+	, is_enabled = $6
+	, modified = (now() at time zone 'utc')
+	, modified_by = $7
+
+WHERE
+	    org_id = $1
+	AND id = $2
+
+RETURNING
+	  org_id
+	, id
+	, uuid
+	, url
+	, secret
+	, event_types
+	, is_enabled
+	, created
+	, created_by
+	, modified
+	, modified_by;
\ No newline at end of file
@@ -5,17 +5,48 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand/v2"
 	"switchcraft/repository/queries"
 	"switchcraft/types"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 )
 
+type repoCtxType int
+
+const (
+	_ repoCtxType = iota
+	ctxTx
+)
+
+// dbConn is satisfied by both the pool and an open transaction
+type dbConn interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	CopyFrom(ctx context.Context,
+		tableName pgx.Identifier,
+		columnNames []string,
+		rowSrc pgx.CopyFromSource,
+	) (int64, error)
+}
+
+// getConn returns the transaction started by WithTx when ctx carries one so
+// that every repository call made within fn shares it, otherwise the pool
+func getConn(ctx context.Context, db *pgxpool.Pool) dbConn {
+	if tx, ok := ctx.Value(ctxTx).(pgx.Tx); ok {
+		return tx
+	}
+	return db
+}
+
 type Repository struct {
 	logger *types.Logger
 	db     *pgxpool.Pool
@@ -67,6 +98,105 @@ func (r *Repository) MigrateDown() error {
 	return nil
 }
 
+// Transactions aborted as a deadlock are retried up to txMaxAttempts times in
+// total, waiting a little longer before each retry
+const (
+	txMaxAttempts    = 5
+	txRetryBaseDelay = 20 * time.Millisecond
+)
+
+// WithTx runs fn in a single database transaction, committing if fn returns
+// nil and rolling back otherwise. Repository calls must use the ctx passed to
+// fn to take part in the transaction. Nested calls join the outer transaction.
+// Transactions run at READ COMMITTED, where Postgres never reports
+// serialization failures. Concurrent writers are ordered by row and advisory
+// locks instead, and the whole transaction is retried when two of them
+// deadlock, so fn may run more than once and must not have effects outside
+// the database.
+func (r *Repository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(ctxTx).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := r.runTx(ctx, fn)
+		if err == nil || !isTxRetryable(err) || attempt == txMaxAttempts {
+			return err
+		}
+
+		tracer, _ := ctx.Value(types.CtxOperationTracer).(types.OperationTracer)
+		r.logger.Warn(tracer, "Retrying transaction", map[string]any{
+			"attempt": attempt,
+			"error":   err.Error(),
+		})
+
+		// Jittered so the transactions that collided do not collide again
+		delay := txRetryBaseDelay << (attempt - 1)
+		select {
+		case <-time.After(delay/2 + rand.N(delay/2)):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+func (r *Repository) runTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Explicit, so a different default_transaction_isolation on the server
+	// does not change how the locks WithTx relies on behave
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+	// No-op once committed
+	defer tx.Rollback(context.Background())
+
+	if err = fn(context.WithValue(ctx, ctxTx, tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	return nil
+}
+
+func isTxRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	// deadlock_detected
+	return pgErr.Code == "40P01"
+}
+
+// WithSavepoint runs fn within the transaction started by WithTx, rolling back
+// only the changes made by fn if it returns an error so the rest of the
+// transaction can carry on. Without a transaction it behaves like WithTx.
+func (r *Repository) WithSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, ok := ctx.Value(ctxTx).(pgx.Tx)
+	if !ok {
+		return r.WithTx(ctx, fn)
+	}
+
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+	// No-op once released
+	defer savepoint.Rollback(context.Background())
+
+	if err = fn(context.WithValue(ctx, ctxTx, savepoint)); err != nil {
+		return err
+	}
+
+	if err = savepoint.Commit(ctx); err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	return nil
+}
+
 func (r *Repository) getMigration() (*migrate.Migrate, error) {
 	databaseDriver, err := postgres.WithInstance(stdlib.OpenDBFromPool(r.db), &postgres.Config{})
 	if err != nil {
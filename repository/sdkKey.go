@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"switchcraft/repository/queries"
+	"switchcraft/types"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewSDKKeyRepository(logger *types.Logger, db *pgxpool.Pool) *sdkKeyRepo {
+	return &sdkKeyRepo{
+		logger: logger,
+		db:     db,
+	}
+}
+
+type sdkKeyRepo struct {
+	logger *types.Logger
+	db     *pgxpool.Pool
+}
+
+func (r *sdkKeyRepo) Create(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	environmentID int64,
+	name string,
+	keyType types.SDKKeyType,
+	keyPrefix string,
+	keyHash string,
+	createdBy int64,
+) (*types.SDKKey, error) {
+	var (
+		sdkKey types.SDKKey
+		rows   pgx.Rows
+		err    error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.SDKKeyCreate,
+		orgID,
+		applicationID,
+		environmentID,
+		name,
+		keyType,
+		keyPrefix,
+		keyHash,
+		createdBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if sdkKey, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.SDKKey],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &sdkKey, nil
+}
+
+func (r *sdkKeyRepo) GetMany(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+) ([]types.SDKKey, error) {
+	var (
+		sdkKeys []types.SDKKey
+		rows    pgx.Rows
+		err     error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.SDKKeyGetMany,
+		orgID,
+		applicationID,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if sdkKeys, err = pgx.CollectRows(
+		rows,
+		pgx.RowToStructByName[types.SDKKey],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return sdkKeys, nil
+}
+
+func (r *sdkKeyRepo) GetOne(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	id int64,
+) (*types.SDKKey, error) {
+	var (
+		sdkKey types.SDKKey
+		rows   pgx.Rows
+		err    error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.SDKKeyGetOne,
+		orgID,
+		applicationID,
+		id,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if sdkKey, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.SDKKey],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &sdkKey, nil
+}
+
+func (r *sdkKeyRepo) GetByHash(ctx context.Context, keyHash string) (*types.SDKKey, error) {
+	var (
+		sdkKey types.SDKKey
+		rows   pgx.Rows
+		err    error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.SDKKeyGetByHash, keyHash); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if sdkKey, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.SDKKey],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &sdkKey, nil
+}
+
+func (r *sdkKeyRepo) Rotate(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	id int64,
+	keyPrefix string,
+	keyHash string,
+	modifiedBy int64,
+) (*types.SDKKey, error) {
+	var (
+		sdkKey types.SDKKey
+		rows   pgx.Rows
+		err    error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.SDKKeyRotate,
+		orgID,
+		applicationID,
+		id,
+		keyPrefix,
+		keyHash,
+		modifiedBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if sdkKey, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.SDKKey],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &sdkKey, nil
+}
+
+func (r *sdkKeyRepo) Revoke(ctx context.Context,
+	orgID int64,
+	applicationID int64,
+	id int64,
+	revokedBy int64,
+) (*types.SDKKey, error) {
+	var (
+		sdkKey types.SDKKey
+		rows   pgx.Rows
+		err    error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.SDKKeyRevoke,
+		orgID,
+		applicationID,
+		id,
+		revokedBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if sdkKey, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.SDKKey],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &sdkKey, nil
+}
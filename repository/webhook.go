@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"switchcraft/repository/queries"
+	"switchcraft/types"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewWebhookRepository(logger *types.Logger, db *pgxpool.Pool) *webhookRepo {
+	return &webhookRepo{
+		logger: logger,
+		db:     db,
+	}
+}
+
+type webhookRepo struct {
+	logger *types.Logger
+	db     *pgxpool.Pool
+}
+
+func (r *webhookRepo) Create(ctx context.Context,
+	orgID int64,
+	url string,
+	secret string,
+	eventTypes []types.WebhookEventType,
+	isEnabled bool,
+	createdBy int64,
+) (*types.Webhook, error) {
+	var (
+		webhook types.Webhook
+		rows    pgx.Rows
+		err     error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.WebhookCreate,
+		orgID,
+		url,
+		secret,
+		eventTypes,
+		isEnabled,
+		createdBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if webhook, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.Webhook]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &webhook, nil
+}
+
+func (r *webhookRepo) GetMany(ctx context.Context, orgID int64) ([]types.Webhook, error) {
+	var (
+		webhooks []types.Webhook
+		rows     pgx.Rows
+		err      error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.WebhookGetMany, orgID); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if webhooks, err = pgx.CollectRows(rows, pgx.RowToStructByName[types.Webhook]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *webhookRepo) GetOne(ctx context.Context,
+	orgID int64,
+	id int64,
+) (*types.Webhook, error) {
+	var (
+		webhook types.Webhook
+		rows    pgx.Rows
+		err     error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.WebhookGetOne, orgID, id); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if webhook, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.Webhook]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &webhook, nil
+}
+
+// Update keeps the current secret when secret is empty
+func (r *webhookRepo) Update(ctx context.Context,
+	orgID int64,
+	id int64,
+	url string,
+	secret string,
+	eventTypes []types.WebhookEventType,
+	isEnabled bool,
+	modifiedBy int64,
+) (*types.Webhook, error) {
+	var (
+		webhook types.Webhook
+		rows    pgx.Rows
+		err     error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.WebhookUpdate,
+		orgID,
+		id,
+		url,
+		secret,
+		eventTypes,
+		isEnabled,
+		modifiedBy,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if webhook, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[types.Webhook]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &webhook, nil
+}
+
+func (r *webhookRepo) Delete(ctx context.Context,
+	orgID int64,
+	id int64,
+) error {
+	row := getConn(ctx, r.db).QueryRow(ctx, queries.WebhookDelete, orgID, id)
+
+	var numDeleted int64
+	if err := row.Scan(&numDeleted); err != nil {
+		return handleError(ctx, r.logger, err)
+	}
+
+	if numDeleted < 1 {
+		return types.ErrNotFound
+	}
+
+	if numDeleted > 1 {
+		return fmt.Errorf("expected to delete 1 row, deleted %v", numDeleted)
+	}
+
+	return nil
+}
+
+// DeliveryEnqueue records a delivery for every enabled webhook of the org
+// subscribed to the event, returning their IDs
+func (r *webhookRepo) DeliveryEnqueue(ctx context.Context,
+	orgID int64,
+	eventType types.WebhookEventType,
+	payload any,
+) ([]int64, error) {
+	var (
+		ids  []int64
+		rows pgx.Rows
+		err  error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.WebhookDeliveryEnqueue,
+		orgID,
+		eventType,
+		payload,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if ids, err = pgx.CollectRows(rows, pgx.RowTo[int64]); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return ids, nil
+}
+
+// DeliveryGetMany returns a webhook's latest deliveries, newest first
+func (r *webhookRepo) DeliveryGetMany(ctx context.Context,
+	orgID int64,
+	webhookID int64,
+	limit int,
+) ([]types.WebhookDelivery, error) {
+	var (
+		deliveries []types.WebhookDelivery
+		rows       pgx.Rows
+		err        error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.WebhookDeliveryGetMany,
+		orgID,
+		webhookID,
+		limit,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if deliveries, err = pgx.CollectRows(
+		rows,
+		pgx.RowToStructByName[types.WebhookDelivery],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookRepo) DeliveryGetOne(ctx context.Context,
+	orgID int64,
+	id int64,
+) (*types.WebhookDelivery, error) {
+	var (
+		delivery types.WebhookDelivery
+		rows     pgx.Rows
+		err      error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx, queries.WebhookDeliveryGetOne, orgID, id); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if delivery, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.WebhookDelivery],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &delivery, nil
+}
+
+// DeliveryAttemptSet records the outcome of an attempt
+func (r *webhookRepo) DeliveryAttemptSet(ctx context.Context,
+	id int64,
+	status types.WebhookDeliveryStatus,
+	responseStatus *int,
+	errMessage *string,
+) (*types.WebhookDelivery, error) {
+	var (
+		delivery types.WebhookDelivery
+		rows     pgx.Rows
+		err      error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.WebhookDeliveryAttemptSet,
+		id,
+		status,
+		responseStatus,
+		errMessage,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if delivery, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.WebhookDelivery],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &delivery, nil
+}
+
+// DeliveryRedeliver queues a new delivery with the same event and payload as
+// an earlier one
+func (r *webhookRepo) DeliveryRedeliver(ctx context.Context,
+	orgID int64,
+	webhookID int64,
+	id int64,
+) (*types.WebhookDelivery, error) {
+	var (
+		delivery types.WebhookDelivery
+		rows     pgx.Rows
+		err      error
+	)
+
+	if rows, err = getConn(ctx, r.db).Query(ctx,
+		queries.WebhookDeliveryRedeliver,
+		orgID,
+		webhookID,
+		id,
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	if delivery, err = pgx.CollectOneRow(
+		rows,
+		pgx.RowToStructByName[types.WebhookDelivery],
+	); err != nil {
+		return nil, handleError(ctx, r.logger, err)
+	}
+
+	return &delivery, nil
+}
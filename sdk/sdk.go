@@ -0,0 +1,342 @@
+// Package sdk is the Go client for SwitchCraft. It loads an application's
+// flags using a server SDK key, keeps them cached in memory, and evaluates
+// flags locally so checking a flag never makes a network call.
+package sdk
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"switchcraft/types"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultRefreshInterval = 30 * time.Second
+	defaultRequestTimeout  = 10 * time.Second
+)
+
+type Config struct {
+	// Base URL of the SwitchCraft REST API, e.g. https://flags.example.com
+	BaseURL string
+	OrgSlug string
+	AppSlug string
+
+	// Optional, defaults to the environment the SDK key belongs to
+	EnvSlug string
+
+	// Server SDK key for the application environment
+	SDKKey string
+
+	// How often flags are reloaded. When Streaming is enabled this is only a
+	// fallback in case change events are missed. Defaults to 30 seconds.
+	RefreshInterval time.Duration
+
+	// Reload flags as soon as they change using the application event stream
+	Streaming bool
+
+	// Optional, defaults to a client with a 10 second timeout
+	HTTPClient *http.Client
+
+	// Optional, refresh errors are only logged when set
+	Logger *types.Logger
+}
+
+type Client struct {
+	config     Config
+	httpClient *http.Client
+	store      atomic.Pointer[flagStore]
+	ready      chan struct{}
+	readyOnce  sync.Once
+	cancel     context.CancelFunc
+	done       sync.WaitGroup
+}
+
+type ctxType int
+
+const (
+	_ ctxType = iota
+	ctxAttributes
+)
+
+// WithAttributes returns a context carrying the attributes targeting rules are
+// matched against, e.g. {"country": "DE", "plan": "enterprise"}
+func WithAttributes(ctx context.Context, attributes map[string]any) context.Context {
+	return context.WithValue(ctx, ctxAttributes, attributes)
+}
+
+// NewClient creates a client and starts refreshing flags in the background.
+// It does not wait for the first load, use WaitReady for that. Until flags
+// have loaded, and whenever the server is unreachable before the first load,
+// IsEnabled returns the caller's default.
+func NewClient(config Config) (*Client, error) {
+	if config.BaseURL == "" {
+		return nil, errors.New("sdk.NewClient BaseURL cannot be empty")
+	}
+	if config.OrgSlug == "" {
+		return nil, errors.New("sdk.NewClient OrgSlug cannot be empty")
+	}
+	if config.AppSlug == "" {
+		return nil, errors.New("sdk.NewClient AppSlug cannot be empty")
+	}
+	if !strings.HasPrefix(config.SDKKey, types.SDKKeyPrefix) {
+		return nil, errors.New("sdk.NewClient SDKKey is not a valid SDK key")
+	}
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = defaultRefreshInterval
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultRequestTimeout}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &Client{
+		config:     config,
+		httpClient: httpClient,
+		ready:      make(chan struct{}),
+		cancel:     cancel,
+	}
+
+	refresh := make(chan struct{}, 1)
+
+	c.done.Add(1)
+	go c.poll(ctx, refresh)
+
+	if config.Streaming {
+		c.done.Add(1)
+		go c.stream(ctx, refresh)
+	}
+
+	return c, nil
+}
+
+// WaitReady blocks until flags have loaded at least once or ctx is done
+func (c *Client) WaitReady(ctx context.Context) error {
+	select {
+	case <-c.ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops background refreshes. Cached flags remain usable.
+func (c *Client) Close() {
+	c.cancel()
+	c.done.Wait()
+}
+
+// IsEnabled evaluates a flag for an org account from the local cache. The
+// default is returned when flags have not loaded yet or the flag does not
+// exist. Accounts created since the last refresh are left out of percentage
+// rollouts until the next one. Targeting rules are matched against attributes
+// added to ctx with WithAttributes.
+func (c *Client) IsEnabled(ctx context.Context, flagName string, accountID int64, defaultValue bool) bool {
+	store := c.store.Load()
+	if store == nil {
+		return defaultValue
+	}
+
+	flag, ok := store.flags[flagName]
+	if !ok {
+		return defaultValue
+	}
+
+	return store.evaluate(ctx, flag, accountID).IsEnabled
+}
+
+// Value evaluates a string, number, or JSON flag for an org account from the
+// local cache and returns the resolved variant's value. The default is
+// returned when flags have not loaded yet, the flag does not exist, or the
+// flag is disabled for the account.
+func (c *Client) Value(ctx context.Context, flagName string, accountID int64, defaultValue json.RawMessage) json.RawMessage {
+	store := c.store.Load()
+	if store == nil {
+		return defaultValue
+	}
+
+	flag, ok := store.flags[flagName]
+	if !ok {
+		return defaultValue
+	}
+
+	evaluation := store.evaluate(ctx, flag, accountID)
+	if evaluation.Variant == nil {
+		return defaultValue
+	}
+
+	return evaluation.Value
+}
+
+// poll refreshes flags on an interval, or immediately when signaled by the
+// stream
+func (c *Client) poll(ctx context.Context, refresh <-chan struct{}) {
+	defer c.done.Done()
+
+	ticker := time.NewTicker(c.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.refresh(ctx); err != nil && ctx.Err() == nil {
+			c.logError("sdk error refreshing flags", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-refresh:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Client) refresh(ctx context.Context) error {
+	var config types.SDKConfig
+	if err := c.get(ctx, "sdk-config", &config); err != nil {
+		return err
+	}
+
+	c.store.Store(newFlagStore(config))
+	c.readyOnce.Do(func() { close(c.ready) })
+
+	return nil
+}
+
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.appURL(path), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.SDKKey)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("sdk unexpected response status %d from %s", res.StatusCode, path)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func (c *Client) appURL(path string) string {
+	if c.config.EnvSlug != "" {
+		path = fmt.Sprintf("env/%s/%s", url.PathEscape(c.config.EnvSlug), path)
+	}
+	return fmt.Sprintf("%s/org/%s/app/%s/%s",
+		strings.TrimRight(c.config.BaseURL, "/"),
+		url.PathEscape(c.config.OrgSlug),
+		url.PathEscape(c.config.AppSlug),
+		path,
+	)
+}
+
+func (c *Client) logError(message string, err error) {
+	if c.config.Logger == nil {
+		return
+	}
+	c.config.Logger.Error(types.OperationTracer{}, message, map[string]any{
+		"orgSlug": c.config.OrgSlug,
+		"appSlug": c.config.AppSlug,
+		"error":   err.Error(),
+	})
+}
+
+// flagStore is an immutable snapshot of an application's flags, replaced
+// wholesale on every refresh
+type flagStore struct {
+	flags         map[string]*types.FeatureFlag
+	flagsByID     map[int64]*types.FeatureFlag
+	groupFlags    map[int64][]types.OrgGroupFeatureFlag
+	accountGroups map[int64][]int64
+	accountUUIDs  map[int64]string
+	segments      map[int64]*types.OrgSegment
+}
+
+func newFlagStore(config types.SDKConfig) *flagStore {
+	store := &flagStore{
+		flags:         make(map[string]*types.FeatureFlag, len(config.Flags)),
+		flagsByID:     make(map[int64]*types.FeatureFlag, len(config.Flags)),
+		groupFlags:    make(map[int64][]types.OrgGroupFeatureFlag),
+		accountGroups: make(map[int64][]int64),
+		accountUUIDs:  config.AccountUUIDs,
+		segments:      make(map[int64]*types.OrgSegment, len(config.Segments)),
+	}
+
+	for i := range config.Flags {
+		store.flags[config.Flags[i].Name] = &config.Flags[i]
+		store.flagsByID[config.Flags[i].ID] = &config.Flags[i]
+	}
+	for i := range config.Segments {
+		store.segments[config.Segments[i].ID] = &config.Segments[i]
+	}
+
+	// Evaluation gives precedence to the first disabled override, keep group
+	// flags ordered by group ID to match the server
+	slices.SortFunc(config.GroupFlags, func(a, b types.OrgGroupFeatureFlag) int {
+		return cmp.Compare(a.GroupID, b.GroupID)
+	})
+	for _, groupFlag := range config.GroupFlags {
+		store.groupFlags[groupFlag.FlagID] = append(store.groupFlags[groupFlag.FlagID], groupFlag)
+	}
+
+	for _, groupAccount := range config.GroupAccounts {
+		store.accountGroups[groupAccount.AccountID] = append(
+			store.accountGroups[groupAccount.AccountID],
+			groupAccount.GroupID,
+		)
+	}
+
+	return store
+}
+
+// evaluate resolves a flag and its prerequisites for an account using
+// attributes carried by ctx
+func (s *flagStore) evaluate(ctx context.Context, flag *types.FeatureFlag, accountID int64) types.FeatureFlagEvaluation {
+	attributes, _ := ctx.Value(ctxAttributes).(map[string]any)
+	evaluator := types.NewFeatureFlagEvaluator(
+		s.flagsByID,
+		func(flagID int64) []types.OrgGroupFeatureFlag {
+			return s.groupFlagsFor(flagID, accountID)
+		},
+		types.EvaluationContext{
+			AccountID:   accountID,
+			AccountUUID: s.accountUUIDs[accountID],
+			Attributes:  attributes,
+			Segments:    s.segments,
+		},
+	)
+	return evaluator.Evaluate(flag)
+}
+
+// groupFlagsFor returns the overrides of a flag for groups the account is in
+func (s *flagStore) groupFlagsFor(flagID int64, accountID int64) []types.OrgGroupFeatureFlag {
+	groupIDs := s.accountGroups[accountID]
+	if len(groupIDs) == 0 {
+		return nil
+	}
+
+	var groupFlags []types.OrgGroupFeatureFlag
+	for _, groupFlag := range s.groupFlags[flagID] {
+		if slices.Contains(groupIDs, groupFlag.GroupID) {
+			groupFlags = append(groupFlags, groupFlag)
+		}
+	}
+
+	return groupFlags
+}
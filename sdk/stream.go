@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const streamRetryInterval = 5 * time.Second
+
+// stream listens to the application's flag event stream and signals refresh
+// whenever something changes. Flags are always reloaded in full so events
+// only need to be noticed, not applied.
+func (c *Client) stream(ctx context.Context, refresh chan<- struct{}) {
+	defer c.done.Done()
+
+	// The stream stays open indefinitely so the configured client timeout
+	// cannot be used
+	streamClient := &http.Client{Transport: c.httpClient.Transport}
+
+	var lastEventID string
+	for {
+		err := c.streamOnce(ctx, streamClient, &lastEventID, refresh)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			c.logError("sdk flag event stream disconnected", err)
+		}
+
+		// Anything missed while disconnected is replayed using lastEventID,
+		// refresh anyway in case the replay window was exceeded
+		signalRefresh(refresh)
+
+		select {
+		case <-time.After(streamRetryInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Client) streamOnce(ctx context.Context,
+	streamClient *http.Client,
+	lastEventID *string,
+	refresh chan<- struct{},
+) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.appURL("stream"), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.SDKKey)
+	req.Header.Set("Accept", "text/event-stream")
+	if *lastEventID != "" {
+		req.Header.Set("Last-Event-ID", *lastEventID)
+	}
+
+	res, err := streamClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("sdk unexpected response status %d from stream", res.StatusCode)
+	}
+
+	var (
+		scanner  = bufio.NewScanner(res.Body)
+		hasEvent bool
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			// Blank line terminates an event
+			if hasEvent {
+				signalRefresh(refresh)
+				hasEvent = false
+			}
+		case strings.HasPrefix(line, "id:"):
+			*lastEventID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "data:"):
+			hasEvent = true
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("sdk flag event stream closed by server")
+}
+
+// signalRefresh never blocks, bursts of events collapse into one refresh
+func signalRefresh(refresh chan<- struct{}) {
+	select {
+	case refresh <- struct{}{}:
+	default:
+	}
+}
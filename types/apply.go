@@ -0,0 +1,118 @@
+package types
+
+// ApplyConfig declares organizations and everything in them. It is the seed
+// file format, so a seed file can be applied as is.
+type ApplyConfig struct {
+	Organizations []ApplyOrganization `json:"organizations"`
+}
+
+// ApplyOrganization is matched by slug. The owner is optional, when set
+// ownership is transferred to that account.
+type ApplyOrganization struct {
+	Name         string             `json:"name"`
+	Slug         string             `json:"slug"`
+	Owner        ApplyAccount       `json:"owner"`
+	Accounts     []ApplyAccount     `json:"accounts"`
+	Groups       []ApplyGroup       `json:"groups"`
+	Applications []ApplyApplication `json:"applications"`
+}
+
+// ApplyAccount is matched by username
+type ApplyAccount struct {
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Email     string `json:"email"`
+	Username  string `json:"username"`
+}
+
+// ApplyGroup is matched by name, Members are usernames
+type ApplyGroup struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Members     []string `json:"members"`
+}
+
+// ApplyApplication is matched by slug. Its flags are applied to Environment,
+// production when empty.
+type ApplyApplication struct {
+	Name         string             `json:"name"`
+	Slug         string             `json:"slug"`
+	Environment  string             `json:"environment"`
+	FeatureFlags []ApplyFeatureFlag `json:"featureFlags"`
+}
+
+// ApplyFeatureFlag is matched by name. Prerequisites and expiry are not part
+// of the file and are left as they are.
+type ApplyFeatureFlag struct {
+	Name              string               `json:"name"`
+	Label             string               `json:"label"`
+	Description       string               `json:"description"`
+	IsEnabled         bool                 `json:"isEnabled"`
+	RolloutPercentage *int                 `json:"rolloutPercentage"`
+	FlagType          FeatureFlagType      `json:"flagType"`
+	Variants          []FeatureFlagVariant `json:"variants"`
+	DefaultVariant    *string              `json:"defaultVariant"`
+	Rules             []TargetingRule      `json:"rules"`
+	GroupFlags        []ApplyGroupFlag     `json:"groupFlags"`
+}
+
+// ApplyGroupFlag overrides a flag for the group with the given name
+type ApplyGroupFlag struct {
+	Group             string  `json:"group"`
+	IsEnabled         bool    `json:"isEnabled"`
+	RolloutPercentage *int    `json:"rolloutPercentage"`
+	Variant           *string `json:"variant"`
+}
+
+type ApplyAction string
+
+const (
+	ApplyActionCreate ApplyAction = "create"
+	ApplyActionUpdate ApplyAction = "update"
+	ApplyActionDelete ApplyAction = "delete"
+)
+
+type ApplyResource string
+
+const (
+	ApplyResourceOrg         ApplyResource = "org"
+	ApplyResourceAccount     ApplyResource = "account"
+	ApplyResourceGroup       ApplyResource = "group"
+	ApplyResourceGroupMember ApplyResource = "groupMember"
+	ApplyResourceApplication ApplyResource = "application"
+	ApplyResourceFeatureFlag ApplyResource = "featureFlag"
+	ApplyResourceGroupFlag   ApplyResource = "groupFlag"
+)
+
+// ApplyChange is a single change made, or planned for a dry run. Address
+// names the resource by slugs and names, e.g. my-org/my-app/production/MY_FLAG.
+// Fields lists what an update changes.
+type ApplyChange struct {
+	Action   ApplyAction   `json:"action"`
+	Resource ApplyResource `json:"resource"`
+	Address  string        `json:"address"`
+	Fields   []string      `json:"fields"`
+}
+
+// ApplyPlan lists the changes needed for the current state to match a config
+// file, in the order they are made. An empty plan means nothing differs.
+type ApplyPlan struct {
+	Changes []ApplyChange `json:"changes"`
+	Prune   bool          `json:"prune"`
+	DryRun  bool          `json:"dryRun"`
+}
+
+// Counts returns the number of creates, updates and deletes in the plan
+func (p *ApplyPlan) Counts() (creates int, updates int, deletes int) {
+	for _, change := range p.Changes {
+		switch change.Action {
+		case ApplyActionCreate:
+			creates++
+		case ApplyActionUpdate:
+			updates++
+		case ApplyActionDelete:
+			deletes++
+		}
+	}
+	return creates, updates, deletes
+}
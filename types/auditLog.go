@@ -0,0 +1,50 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+type AuditEntityType string
+
+const (
+	AuditEntityOrg             AuditEntityType = "org"
+	AuditEntityAccount         AuditEntityType = "account"
+	AuditEntityOrgGroup        AuditEntityType = "group"
+	AuditEntityOrgGroupAccount AuditEntityType = "group_account"
+	AuditEntityApplication     AuditEntityType = "application"
+	AuditEntityFeatureFlag     AuditEntityType = "feature_flag"
+	AuditEntityGroupFlag       AuditEntityType = "group_flag"
+	AuditEntitySDKKey          AuditEntityType = "sdk_key"
+	AuditEntityAccountRole     AuditEntityType = "account_role"
+	AuditEntityGroupRole       AuditEntityType = "group_role"
+	AuditEntitySegment         AuditEntityType = "segment"
+	AuditEntityEnvironment     AuditEntityType = "environment"
+	AuditEntityFlagSchedule    AuditEntityType = "flag_schedule"
+	AuditEntityWebhook         AuditEntityType = "webhook"
+)
+
+// AuditLogEntry records a single change. Group memberships, group roles and
+// group flags are logged against the group ID and account roles against the
+// account ID, since none has an ID of its own. A group flag's flag and
+// environment are in its before and after state.
+type AuditLogEntry struct {
+	OrgID      *int64          `json:"orgId" db:"org_id"`
+	ID         int64           `json:"id" db:"id"`
+	ActorID    *int64          `json:"actorId" db:"actor_id"`
+	TraceID    string          `json:"traceId" db:"trace_id"`
+	Action     AuditAction     `json:"action" db:"action"`
+	EntityType AuditEntityType `json:"entityType" db:"entity_type"`
+	EntityID   int64           `json:"entityId" db:"entity_id"`
+	Before     json.RawMessage `json:"before" db:"before"`
+	After      json.RawMessage `json:"after" db:"after"`
+	Created    time.Time       `json:"created" db:"created"`
+}
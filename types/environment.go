@@ -0,0 +1,23 @@
+package types
+
+import "time"
+
+// DefaultEnvironmentSlug is the environment used when a request does not name
+// one. Data that predates environments was migrated into it.
+const DefaultEnvironmentSlug = "production"
+
+// Environment is a deployment stage of an application. Flag definitions are
+// shared by every environment of an application while enabled state, rules,
+// and group overrides are kept per environment.
+type Environment struct {
+	OrgID         int64      `json:"orgId" db:"org_id"`
+	ApplicationID int64      `json:"applicationId" db:"application_id"`
+	ID            int64      `json:"id" db:"id"`
+	UUID          string     `json:"uuid" db:"uuid"`
+	Name          string     `json:"name" db:"name"`
+	Slug          string     `json:"slug" db:"slug"`
+	Created       time.Time  `json:"created" db:"created"`
+	CreatedBy     int64      `json:"createdBy" db:"created_by"`
+	Modified      *time.Time `json:"modified" db:"modified"`
+	ModifiedBy    *int64     `json:"modifiedBy" db:"modified_by"`
+}
@@ -6,3 +6,5 @@ var ErrNotFound = errors.New("item not found")
 var ErrItemExists = errors.New("item already exists")
 var ErrOperationNotPermitted = errors.New("operation not permitted")
 var ErrLinkedItemNotFound = errors.New("linked item not found")
+var ErrItemInUse = errors.New("item is in use")
+var ErrInvalidState = errors.New("item is in the wrong state")
@@ -1,18 +1,103 @@
 package types
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+type FeatureFlagType string
+
+const (
+	FeatureFlagTypeBoolean FeatureFlagType = "boolean"
+	FeatureFlagTypeString  FeatureFlagType = "string"
+	FeatureFlagTypeNumber  FeatureFlagType = "number"
+	FeatureFlagTypeJSON    FeatureFlagType = "json"
+)
+
+func (t FeatureFlagType) IsValid() bool {
+	switch t {
+	case FeatureFlagTypeBoolean, FeatureFlagTypeString, FeatureFlagTypeNumber, FeatureFlagTypeJSON:
+		return true
+	}
+	return false
+}
+
+// ValueIsValid reports whether a variant value is valid JSON of the type
+func (t FeatureFlagType) ValueIsValid(value json.RawMessage) bool {
+	var decoded any
+	if err := json.Unmarshal(value, &decoded); err != nil {
+		return false
+	}
+
+	switch t {
+	case FeatureFlagTypeString:
+		_, ok := decoded.(string)
+		return ok
+	case FeatureFlagTypeNumber:
+		_, ok := decoded.(float64)
+		return ok
+	case FeatureFlagTypeJSON:
+		return true
+	}
+	return false
+}
+
+// FeatureFlagLifecycle is shared by every environment of a flag. Deprecated
+// flags still evaluate as usual, archived flags are always disabled and can no
+// longer be changed until restored.
+type FeatureFlagLifecycle string
+
+const (
+	FeatureFlagLifecycleActive     FeatureFlagLifecycle = "active"
+	FeatureFlagLifecycleDeprecated FeatureFlagLifecycle = "deprecated"
+	FeatureFlagLifecycleArchived   FeatureFlagLifecycle = "archived"
+)
+
+// FeatureFlagVariant is a named value served by a non-boolean flag
+type FeatureFlagVariant struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+// FeatureFlagPrerequisite is another flag of the same application that must
+// be enabled for an account, and serve Variant when set, before the flag
+// depending on it is evaluated
+type FeatureFlagPrerequisite struct {
+	FlagID  int64   `json:"flagId"`
+	Variant *string `json:"variant"`
+}
 
 type FeatureFlag struct {
-	OrgID         int64      `json:"orgId" db:"org_id"`
-	ApplicationID int64      `json:"applicationId" db:"application_id"`
-	ID            int64      `json:"id" db:"id"`
-	UUID          string     `json:"uuid" db:"uuid"`
-	Name          string     `json:"name" db:"name"`
-	Label         string     `json:"label" db:"label"`
-	Description   string     `json:"description" db:"description"`
-	IsEnabled     bool       `json:"isEnabled" db:"is_enabled"`
-	Created       time.Time  `json:"created" db:"created"`
-	CreatedBy     int64      `json:"createdBy" db:"created_by"`
-	Modified      *time.Time `json:"modified" db:"modified"`
-	ModifiedBy    *int64     `json:"modifiedBy" db:"modified_by"`
+	OrgID             int64                     `json:"orgId" db:"org_id"`
+	ApplicationID     int64                     `json:"applicationId" db:"application_id"`
+	EnvironmentID     int64                     `json:"environmentId" db:"environment_id"`
+	ID                int64                     `json:"id" db:"id"`
+	UUID              string                    `json:"uuid" db:"uuid"`
+	Name              string                    `json:"name" db:"name"`
+	Label             string                    `json:"label" db:"label"`
+	Description       string                    `json:"description" db:"description"`
+	IsEnabled         bool                      `json:"isEnabled" db:"is_enabled"`
+	RolloutPercentage *int                      `json:"rolloutPercentage" db:"rollout_percentage"`
+	FlagType          FeatureFlagType           `json:"flagType" db:"flag_type"`
+	Variants          []FeatureFlagVariant      `json:"variants" db:"variants"`
+	DefaultVariant    *string                   `json:"defaultVariant" db:"default_variant"`
+	Rules             []TargetingRule           `json:"rules" db:"rules"`
+	Prerequisites     []FeatureFlagPrerequisite `json:"prerequisites" db:"prerequisites"`
+	Lifecycle         FeatureFlagLifecycle      `json:"lifecycle" db:"lifecycle"`
+	ExpiresAt         *time.Time                `json:"expiresAt" db:"expires_at"`
+	Created           time.Time                 `json:"created" db:"created"`
+	CreatedBy         int64                     `json:"createdBy" db:"created_by"`
+	Modified          *time.Time                `json:"modified" db:"modified"`
+	ModifiedBy        *int64                    `json:"modifiedBy" db:"modified_by"`
+}
+
+// Variant looks up one of the flag's variants by name, nil if it has none by
+// that name
+func (f *FeatureFlag) Variant(name string) *FeatureFlagVariant {
+	for i := range f.Variants {
+		if f.Variants[i].Name == name {
+			return &f.Variants[i]
+		}
+	}
+	return nil
 }
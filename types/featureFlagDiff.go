@@ -0,0 +1,45 @@
+package types
+
+type FlagDiffStatus string
+
+const (
+	FlagDiffStatusAdded     FlagDiffStatus = "added"
+	FlagDiffStatusRemoved   FlagDiffStatus = "removed"
+	FlagDiffStatusChanged   FlagDiffStatus = "changed"
+	FlagDiffStatusUnchanged FlagDiffStatus = "unchanged"
+)
+
+// FeatureFlagDiff compares a flag, matched by name, between a source and a
+// target environment. Added flags only exist in the source and removed flags
+// only in the target. Fields lists the flag fields that differ, GroupFlags
+// only the group overrides that differ.
+type FeatureFlagDiff struct {
+	Name       string          `json:"name"`
+	Status     FlagDiffStatus  `json:"status"`
+	Fields     []string        `json:"fields"`
+	Source     *FeatureFlag    `json:"source"`
+	Target     *FeatureFlag    `json:"target"`
+	GroupFlags []GroupFlagDiff `json:"groupFlags"`
+}
+
+type GroupFlagDiff struct {
+	GroupID int64                `json:"groupId"`
+	Status  FlagDiffStatus       `json:"status"`
+	Source  *OrgGroupFeatureFlag `json:"source"`
+	Target  *OrgGroupFeatureFlag `json:"target"`
+}
+
+type FeatureFlagSetDiff struct {
+	SourceAppSlug string            `json:"sourceAppSlug"`
+	SourceEnvSlug string            `json:"sourceEnvSlug"`
+	TargetAppSlug string            `json:"targetAppSlug"`
+	TargetEnvSlug string            `json:"targetEnvSlug"`
+	Flags         []FeatureFlagDiff `json:"flags"`
+}
+
+// FeatureFlagPromotion lists the selected flags as they differed before being
+// promoted, or would be promoted for a dry run
+type FeatureFlagPromotion struct {
+	FeatureFlagSetDiff
+	DryRun bool `json:"dryRun"`
+}
@@ -0,0 +1,318 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"strconv"
+)
+
+type FlagEvaluationReason string
+
+const (
+	FlagEvaluationReasonDefault       FlagEvaluationReason = "DEFAULT"
+	FlagEvaluationReasonRollout       FlagEvaluationReason = "ROLLOUT"
+	FlagEvaluationReasonTargetingRule FlagEvaluationReason = "TARGETING_RULE"
+	FlagEvaluationReasonGroupOverride FlagEvaluationReason = "GROUP_OVERRIDE"
+
+	FlagEvaluationReasonPrerequisiteFailed FlagEvaluationReason = "PREREQUISITE_FAILED"
+	FlagEvaluationReasonArchived           FlagEvaluationReason = "ARCHIVED"
+)
+
+// FlagEvaluationScope is the org, application, environment, and org account
+// flags are evaluated for
+type FlagEvaluationScope struct {
+	OrgID         int64  `db:"org_id"`
+	ApplicationID int64  `db:"application_id"`
+	EnvironmentID int64  `db:"environment_id"`
+	AccountID     int64  `db:"account_id"`
+	AccountUUID   string `db:"account_uuid"`
+}
+
+type FeatureFlagEvaluation struct {
+	FlagID             int64                `json:"flagId"`
+	FlagName           string               `json:"flagName"`
+	AccountID          int64                `json:"accountId"`
+	IsEnabled          bool                 `json:"isEnabled"`
+	Reason             FlagEvaluationReason `json:"reason"`
+	GroupID            *int64               `json:"groupId"`
+	RuleIndex          *int                 `json:"ruleIndex"`
+	PrerequisiteFlagID *int64               `json:"prerequisiteFlagId"`
+	Variant            *string              `json:"variant"`
+	Value              json.RawMessage      `json:"value"`
+}
+
+// FeatureFlagEvaluationsCSV turns evaluations into CSV records, starting with
+// a header row. Empty cells stand for null.
+func FeatureFlagEvaluationsCSV(evaluations []FeatureFlagEvaluation) [][]string {
+	records := [][]string{{
+		"accountId",
+		"flagId",
+		"flagName",
+		"isEnabled",
+		"reason",
+		"groupId",
+		"ruleIndex",
+		"prerequisiteFlagId",
+		"variant",
+		"value",
+	}}
+
+	for _, evaluation := range evaluations {
+		record := []string{
+			strconv.FormatInt(evaluation.AccountID, 10),
+			strconv.FormatInt(evaluation.FlagID, 10),
+			evaluation.FlagName,
+			strconv.FormatBool(evaluation.IsEnabled),
+			string(evaluation.Reason),
+			"",
+			"",
+			"",
+			"",
+			string(evaluation.Value),
+		}
+		if evaluation.GroupID != nil {
+			record[5] = strconv.FormatInt(*evaluation.GroupID, 10)
+		}
+		if evaluation.RuleIndex != nil {
+			record[6] = strconv.Itoa(*evaluation.RuleIndex)
+		}
+		if evaluation.PrerequisiteFlagID != nil {
+			record[7] = strconv.FormatInt(*evaluation.PrerequisiteFlagID, 10)
+		}
+		if evaluation.Variant != nil {
+			record[8] = *evaluation.Variant
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// EvaluateFeatureFlag applies flag precedence for a single account. The
+// groupFlags passed in must already be limited to groups the account is a
+// member of. Any enabled group override wins, otherwise the first disabled
+// override wins, then the first targeting rule matching the context's
+// attributes and segments, otherwise the flag's own state is used.
+//
+// Flags and overrides with a rollout percentage are only enabled for accounts
+// whose bucket falls within it. Accounts without a UUID are left out of every
+// partial rollout.
+//
+// Boolean flags resolve to a true or false value. Other flag types resolve to
+// the variant pinned by the winning override or rule, falling back to the
+// flag's default variant, and to a null value while disabled.
+//
+// Lifecycle and prerequisites are not checked here, see FeatureFlagEvaluator.
+func EvaluateFeatureFlag(
+	flag *FeatureFlag,
+	groupFlags []OrgGroupFeatureFlag,
+	evalCtx EvaluationContext,
+) FeatureFlagEvaluation {
+	bucket := -1
+	if evalCtx.AccountUUID != "" {
+		bucket = RolloutBucket(flag.UUID, evalCtx.AccountUUID)
+	}
+
+	evaluation := FeatureFlagEvaluation{
+		FlagID:    flag.ID,
+		FlagName:  flag.Name,
+		IsEnabled: rolloutEnabled(flag.IsEnabled, flag.RolloutPercentage, bucket),
+		Reason:    FlagEvaluationReasonDefault,
+	}
+	if flag.IsEnabled && flag.RolloutPercentage != nil {
+		evaluation.Reason = FlagEvaluationReasonRollout
+	}
+
+	var (
+		matched        *OrgGroupFeatureFlag
+		matchedEnabled bool
+	)
+	for i, groupFlag := range groupFlags {
+		if groupFlag.FlagID != flag.ID {
+			continue
+		}
+		isEnabled := rolloutEnabled(groupFlag.IsEnabled, groupFlag.RolloutPercentage, bucket)
+		if matched == nil || (isEnabled && !matchedEnabled) {
+			matched = &groupFlags[i]
+			matchedEnabled = isEnabled
+		}
+	}
+
+	var pinnedVariant *string
+	if matched != nil {
+		groupID := matched.GroupID
+		evaluation.IsEnabled = matchedEnabled
+		evaluation.Reason = FlagEvaluationReasonGroupOverride
+		evaluation.GroupID = &groupID
+		pinnedVariant = matched.Variant
+	} else {
+		for i := range flag.Rules {
+			if !flag.Rules[i].Matches(evalCtx) {
+				continue
+			}
+			ruleIndex := i
+			evaluation.IsEnabled = flag.Rules[i].IsEnabled
+			evaluation.Reason = FlagEvaluationReasonTargetingRule
+			evaluation.RuleIndex = &ruleIndex
+			pinnedVariant = flag.Rules[i].Variant
+			break
+		}
+	}
+
+	evaluation.Variant, evaluation.Value = resolveFeatureFlagVariant(flag, pinnedVariant, evaluation.IsEnabled)
+
+	return evaluation
+}
+
+// FeatureFlagEvaluator evaluates the flags of one application environment for
+// a single account, evaluating each flag's prerequisites before the flag
+// itself. Evaluations are kept so a prerequisite shared by several flags is
+// only evaluated once.
+type FeatureFlagEvaluator struct {
+	flags       map[int64]*FeatureFlag
+	groupFlags  func(flagID int64) []OrgGroupFeatureFlag
+	evalCtx     EvaluationContext
+	evaluations map[int64]FeatureFlagEvaluation
+	evaluating  map[int64]bool
+}
+
+// NewFeatureFlagEvaluator takes every flag of the application environment by
+// ID, so prerequisites can be looked up, and a function returning a flag's
+// overrides for groups the account is a member of
+func NewFeatureFlagEvaluator(
+	flags map[int64]*FeatureFlag,
+	groupFlags func(flagID int64) []OrgGroupFeatureFlag,
+	evalCtx EvaluationContext,
+) *FeatureFlagEvaluator {
+	return &FeatureFlagEvaluator{
+		flags:       flags,
+		groupFlags:  groupFlags,
+		evalCtx:     evalCtx,
+		evaluations: make(map[int64]FeatureFlagEvaluation),
+		evaluating:  make(map[int64]bool),
+	}
+}
+
+// Evaluate resolves a flag once all of its prerequisites are met, see
+// EvaluateFeatureFlag. A prerequisite that is missing, disabled for the
+// account, serving another variant than the one required or part of a cycle
+// leaves the flag disabled with reason PREREQUISITE_FAILED. Archived flags are
+// always disabled with reason ARCHIVED.
+func (e *FeatureFlagEvaluator) Evaluate(flag *FeatureFlag) FeatureFlagEvaluation {
+	if evaluation, ok := e.evaluations[flag.ID]; ok {
+		return evaluation
+	}
+
+	e.evaluating[flag.ID] = true
+	defer delete(e.evaluating, flag.ID)
+
+	evaluation := e.evaluate(flag)
+	e.evaluations[flag.ID] = evaluation
+
+	return evaluation
+}
+
+func (e *FeatureFlagEvaluator) evaluate(flag *FeatureFlag) FeatureFlagEvaluation {
+	if flag.Lifecycle == FeatureFlagLifecycleArchived {
+		evaluation := FeatureFlagEvaluation{
+			FlagID:    flag.ID,
+			FlagName:  flag.Name,
+			IsEnabled: false,
+			Reason:    FlagEvaluationReasonArchived,
+		}
+		evaluation.Variant, evaluation.Value = resolveFeatureFlagVariant(flag, nil, false)
+
+		return evaluation
+	}
+
+	for _, prerequisite := range flag.Prerequisites {
+		if e.prerequisiteMet(prerequisite) {
+			continue
+		}
+
+		prerequisiteFlagID := prerequisite.FlagID
+		evaluation := FeatureFlagEvaluation{
+			FlagID:             flag.ID,
+			FlagName:           flag.Name,
+			IsEnabled:          false,
+			Reason:             FlagEvaluationReasonPrerequisiteFailed,
+			PrerequisiteFlagID: &prerequisiteFlagID,
+		}
+		evaluation.Variant, evaluation.Value = resolveFeatureFlagVariant(flag, nil, false)
+
+		return evaluation
+	}
+
+	var groupFlags []OrgGroupFeatureFlag
+	if e.groupFlags != nil {
+		groupFlags = e.groupFlags(flag.ID)
+	}
+
+	return EvaluateFeatureFlag(flag, groupFlags, e.evalCtx)
+}
+
+func (e *FeatureFlagEvaluator) prerequisiteMet(prerequisite FeatureFlagPrerequisite) bool {
+	flag, ok := e.flags[prerequisite.FlagID]
+	if !ok || e.evaluating[prerequisite.FlagID] {
+		return false
+	}
+
+	evaluation := e.Evaluate(flag)
+	if !evaluation.IsEnabled {
+		return false
+	}
+
+	return prerequisite.Variant == nil ||
+		(evaluation.Variant != nil && *evaluation.Variant == *prerequisite.Variant)
+}
+
+func resolveFeatureFlagVariant(
+	flag *FeatureFlag,
+	pinnedVariant *string,
+	isEnabled bool,
+) (*string, json.RawMessage) {
+	if flag.FlagType == FeatureFlagTypeBoolean || flag.FlagType == "" {
+		if isEnabled {
+			return nil, json.RawMessage("true")
+		}
+		return nil, json.RawMessage("false")
+	}
+
+	if !isEnabled {
+		return nil, json.RawMessage("null")
+	}
+
+	var variant *FeatureFlagVariant
+	if pinnedVariant != nil {
+		variant = flag.Variant(*pinnedVariant)
+	}
+	if variant == nil && flag.DefaultVariant != nil {
+		variant = flag.Variant(*flag.DefaultVariant)
+	}
+	if variant == nil {
+		return nil, json.RawMessage("null")
+	}
+
+	name := variant.Name
+	return &name, variant.Value
+}
+
+// RolloutBucket deterministically places an account in one of 100 buckets for
+// a flag. An account keeps its bucket as the percentage grows, so raising a
+// rollout only ever adds accounts. Including the flag UUID means each flag
+// rolls out to a different slice of accounts.
+func RolloutBucket(flagUUID string, accountUUID string) int {
+	sum := sha256.Sum256([]byte(flagUUID + ":" + accountUUID))
+	return int(binary.BigEndian.Uint64(sum[:8]) % 100)
+}
+
+func rolloutEnabled(isEnabled bool, rolloutPercentage *int, bucket int) bool {
+	if !isEnabled {
+		return false
+	}
+	if rolloutPercentage == nil || *rolloutPercentage >= 100 {
+		return true
+	}
+	return bucket >= 0 && bucket < *rolloutPercentage
+}
@@ -0,0 +1,151 @@
+package types
+
+// FeatureFlagExplanation is the full decision trace behind a single flag
+// evaluation, meant for working out why an account does or does not get a
+// feature. Evaluation is the result the account actually receives.
+type FeatureFlagExplanation struct {
+	FlagID            int64                `json:"flagId"`
+	FlagName          string               `json:"flagName"`
+	FlagType          FeatureFlagType      `json:"flagType"`
+	Lifecycle         FeatureFlagLifecycle `json:"lifecycle"`
+	IsEnabled         bool                 `json:"isEnabled"`
+	RolloutPercentage *int                 `json:"rolloutPercentage"`
+	DefaultVariant    *string              `json:"defaultVariant"`
+
+	AccountID   int64  `json:"accountId"`
+	AccountUUID string `json:"accountUuid"`
+	// Nil when the account has no UUID and is left out of partial rollouts
+	RolloutBucket *int `json:"rolloutBucket"`
+
+	Prerequisites []FeatureFlagPrerequisiteExplanation `json:"prerequisites"`
+	Groups        []FeatureFlagGroupExplanation        `json:"groups"`
+	Rules         []FeatureFlagRuleExplanation         `json:"rules"`
+
+	Evaluation FeatureFlagEvaluation `json:"evaluation"`
+}
+
+// FeatureFlagPrerequisiteExplanation holds the evaluation of a prerequisite
+// for the account, Evaluation is nil when the prerequisite flag is missing
+type FeatureFlagPrerequisiteExplanation struct {
+	FlagID     int64                  `json:"flagId"`
+	Variant    *string                `json:"variant"`
+	IsMet      bool                   `json:"isMet"`
+	Evaluation *FeatureFlagEvaluation `json:"evaluation"`
+}
+
+// FeatureFlagGroupExplanation describes one group the account is a member
+// of. Override is nil when the group has no override for the flag,
+// OverrideEnabled is the override's state for the account after its rollout.
+type FeatureFlagGroupExplanation struct {
+	GroupID         int64                `json:"groupId"`
+	GroupName       string               `json:"groupName"`
+	Override        *OrgGroupFeatureFlag `json:"override"`
+	OverrideEnabled *bool                `json:"overrideEnabled"`
+	IsMatched       bool                 `json:"isMatched"`
+}
+
+// FeatureFlagRuleExplanation reports whether each condition of a targeting
+// rule matched the evaluation context. Only the first matching rule is
+// applied, and only when no group override matched.
+type FeatureFlagRuleExplanation struct {
+	RuleIndex  int                               `json:"ruleIndex"`
+	IsEnabled  bool                              `json:"isEnabled"`
+	Variant    *string                           `json:"variant"`
+	Conditions []FeatureFlagConditionExplanation `json:"conditions"`
+	IsMatched  bool                              `json:"isMatched"`
+	IsApplied  bool                              `json:"isApplied"`
+}
+
+type FeatureFlagConditionExplanation struct {
+	TargetingCondition
+	IsMatched bool `json:"isMatched"`
+}
+
+// Explain evaluates a flag like Evaluate and records every step that went
+// into the result. Groups are the groups the account is a member of.
+func (e *FeatureFlagEvaluator) Explain(flag *FeatureFlag, groups []OrgGroup) FeatureFlagExplanation {
+	evaluation := e.Evaluate(flag)
+
+	explanation := FeatureFlagExplanation{
+		FlagID:            flag.ID,
+		FlagName:          flag.Name,
+		FlagType:          flag.FlagType,
+		Lifecycle:         flag.Lifecycle,
+		IsEnabled:         flag.IsEnabled,
+		RolloutPercentage: flag.RolloutPercentage,
+		DefaultVariant:    flag.DefaultVariant,
+		AccountID:         e.evalCtx.AccountID,
+		AccountUUID:       e.evalCtx.AccountUUID,
+		Prerequisites:     []FeatureFlagPrerequisiteExplanation{},
+		Groups:            []FeatureFlagGroupExplanation{},
+		Rules:             []FeatureFlagRuleExplanation{},
+		Evaluation:        evaluation,
+	}
+
+	bucket := -1
+	if e.evalCtx.AccountUUID != "" {
+		bucket = RolloutBucket(flag.UUID, e.evalCtx.AccountUUID)
+		explanation.RolloutBucket = &bucket
+	}
+
+	for _, prerequisite := range flag.Prerequisites {
+		prerequisiteExplanation := FeatureFlagPrerequisiteExplanation{
+			FlagID:  prerequisite.FlagID,
+			Variant: prerequisite.Variant,
+		}
+		if prerequisiteFlag, ok := e.flags[prerequisite.FlagID]; ok {
+			// Already evaluated while evaluating the flag itself
+			prerequisiteEvaluation := e.Evaluate(prerequisiteFlag)
+			prerequisiteExplanation.Evaluation = &prerequisiteEvaluation
+			prerequisiteExplanation.IsMet = prerequisiteEvaluation.IsEnabled &&
+				(prerequisite.Variant == nil ||
+					(prerequisiteEvaluation.Variant != nil && *prerequisiteEvaluation.Variant == *prerequisite.Variant))
+		}
+		explanation.Prerequisites = append(explanation.Prerequisites, prerequisiteExplanation)
+	}
+
+	var groupFlags []OrgGroupFeatureFlag
+	if e.groupFlags != nil {
+		groupFlags = e.groupFlags(flag.ID)
+	}
+	for _, group := range groups {
+		groupExplanation := FeatureFlagGroupExplanation{
+			GroupID:   group.ID,
+			GroupName: group.Name,
+			IsMatched: evaluation.GroupID != nil && *evaluation.GroupID == group.ID,
+		}
+		for i := range groupFlags {
+			if groupFlags[i].GroupID != group.ID || groupFlags[i].FlagID != flag.ID {
+				continue
+			}
+			isEnabled := rolloutEnabled(groupFlags[i].IsEnabled, groupFlags[i].RolloutPercentage, bucket)
+			groupExplanation.Override = &groupFlags[i]
+			groupExplanation.OverrideEnabled = &isEnabled
+			break
+		}
+		explanation.Groups = append(explanation.Groups, groupExplanation)
+	}
+
+	for i := range flag.Rules {
+		rule := &flag.Rules[i]
+		ruleExplanation := FeatureFlagRuleExplanation{
+			RuleIndex:  i,
+			IsEnabled:  rule.IsEnabled,
+			Variant:    rule.Variant,
+			Conditions: make([]FeatureFlagConditionExplanation, len(rule.Conditions)),
+			IsMatched:  true,
+			IsApplied:  evaluation.RuleIndex != nil && *evaluation.RuleIndex == i,
+		}
+		for j := range rule.Conditions {
+			isMatched := rule.Conditions[j].Matches(e.evalCtx)
+			ruleExplanation.Conditions[j] = FeatureFlagConditionExplanation{
+				TargetingCondition: rule.Conditions[j],
+				IsMatched:          isMatched,
+			}
+			ruleExplanation.IsMatched = ruleExplanation.IsMatched && isMatched
+		}
+		explanation.Rules = append(explanation.Rules, ruleExplanation)
+	}
+
+	return explanation
+}
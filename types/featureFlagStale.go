@@ -0,0 +1,34 @@
+package types
+
+import "time"
+
+type StaleFlagReason string
+
+const (
+	StaleFlagReasonUnchanged     StaleFlagReason = "unchanged"
+	StaleFlagReasonFullyEnabled  StaleFlagReason = "fully_enabled"
+	StaleFlagReasonFullyDisabled StaleFlagReason = "fully_disabled"
+	StaleFlagReasonExpired       StaleFlagReason = "expired"
+	StaleFlagReasonDeprecated    StaleFlagReason = "deprecated"
+)
+
+// StaleFeatureFlag is a flag that is likely safe to remove from code.
+// LastModified covers the flag, its state in every environment and its group
+// overrides. A flag is fully enabled or disabled when it serves the same state
+// to every account in every environment.
+type StaleFeatureFlag struct {
+	FlagID        int64                `json:"flagId" db:"flag_id"`
+	Name          string               `json:"name" db:"name"`
+	Lifecycle     FeatureFlagLifecycle `json:"lifecycle" db:"lifecycle"`
+	ExpiresAt     *time.Time           `json:"expiresAt" db:"expires_at"`
+	LastModified  time.Time            `json:"lastModified" db:"last_modified"`
+	FullyEnabled  bool                 `json:"fullyEnabled" db:"fully_enabled"`
+	FullyDisabled bool                 `json:"fullyDisabled" db:"fully_disabled"`
+	Reasons       []StaleFlagReason    `json:"reasons" db:"-"`
+}
+
+type FeatureFlagStaleReport struct {
+	AppSlug   string             `json:"appSlug"`
+	StaleDays int                `json:"staleDays"`
+	Flags     []StaleFeatureFlag `json:"flags"`
+}
@@ -0,0 +1,34 @@
+package types
+
+import "time"
+
+// FeatureFlagVersion is a snapshot of a flag and all of its group overrides in
+// one environment taken after every change to either
+type FeatureFlagVersion struct {
+	OrgID             int64                         `json:"orgId" db:"org_id"`
+	ApplicationID     int64                         `json:"applicationId" db:"application_id"`
+	EnvironmentID     int64                         `json:"environmentId" db:"environment_id"`
+	FlagID            int64                         `json:"flagId" db:"flag_id"`
+	Version           int                           `json:"version" db:"version"`
+	Name              string                        `json:"name" db:"name"`
+	Label             string                        `json:"label" db:"label"`
+	Description       string                        `json:"description" db:"description"`
+	IsEnabled         bool                          `json:"isEnabled" db:"is_enabled"`
+	RolloutPercentage *int                          `json:"rolloutPercentage" db:"rollout_percentage"`
+	FlagType          FeatureFlagType               `json:"flagType" db:"flag_type"`
+	Variants          []FeatureFlagVariant          `json:"variants" db:"variants"`
+	DefaultVariant    *string                       `json:"defaultVariant" db:"default_variant"`
+	Rules             []TargetingRule               `json:"rules" db:"rules"`
+	Prerequisites     []FeatureFlagPrerequisite     `json:"prerequisites" db:"prerequisites"`
+	GroupFlags        []FeatureFlagVersionGroupFlag `json:"groupFlags" db:"group_flags"`
+	RestoredFrom      *int                          `json:"restoredFrom" db:"restored_from"`
+	Created           time.Time                     `json:"created" db:"created"`
+	CreatedBy         int64                         `json:"createdBy" db:"created_by"`
+}
+
+type FeatureFlagVersionGroupFlag struct {
+	GroupID           int64   `json:"groupId"`
+	IsEnabled         bool    `json:"isEnabled"`
+	RolloutPercentage *int    `json:"rolloutPercentage"`
+	Variant           *string `json:"variant"`
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type FlagEventType string
+
+const (
+	FlagEventFlagCreated      FlagEventType = "flag.created"
+	FlagEventFlagUpdated      FlagEventType = "flag.updated"
+	FlagEventFlagDeleted      FlagEventType = "flag.deleted"
+	FlagEventGroupFlagCreated FlagEventType = "groupflag.created"
+	FlagEventGroupFlagUpdated FlagEventType = "groupflag.updated"
+	FlagEventGroupFlagDeleted FlagEventType = "groupflag.deleted"
+)
+
+// FlagEvent records a change to a flag. Events without an environment, such
+// as changes to a flag's definition, apply to every environment.
+type FlagEvent struct {
+	OrgID         int64           `json:"orgId" db:"org_id"`
+	ApplicationID int64           `json:"applicationId" db:"application_id"`
+	EnvironmentID *int64          `json:"environmentId" db:"environment_id"`
+	ID            int64           `json:"id" db:"id"`
+	EventType     FlagEventType   `json:"eventType" db:"event_type"`
+	FlagID        int64           `json:"flagId" db:"flag_id"`
+	GroupID       *int64          `json:"groupId" db:"group_id"`
+	Payload       json.RawMessage `json:"payload" db:"payload"`
+	Created       time.Time       `json:"created" db:"created"`
+	CreatedBy     int64           `json:"createdBy" db:"created_by"`
+}
+
+// FlagEventNotification is the payload sent over Postgres NOTIFY when a flag
+// event is written. Listeners load the full event by ID.
+type FlagEventNotification struct {
+	ApplicationID int64 `json:"applicationId"`
+	ID            int64 `json:"id"`
+}
@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+type FlagScheduleStatus string
+
+const (
+	FlagScheduleStatusPending   FlagScheduleStatus = "pending"
+	FlagScheduleStatusExecuted  FlagScheduleStatus = "executed"
+	FlagScheduleStatusFailed    FlagScheduleStatus = "failed"
+	FlagScheduleStatusCancelled FlagScheduleStatus = "cancelled"
+)
+
+// FlagSchedule is a change to a flag, or to a group's override of it when
+// GroupID is set, applied in one environment at ExecuteAt. The change is made
+// on behalf of the account that scheduled it.
+type FlagSchedule struct {
+	OrgID             int64              `json:"orgId" db:"org_id"`
+	ApplicationID     int64              `json:"applicationId" db:"application_id"`
+	EnvironmentID     int64              `json:"environmentId" db:"environment_id"`
+	FlagID            int64              `json:"flagId" db:"flag_id"`
+	GroupID           *int64             `json:"groupId" db:"group_id"`
+	ID                int64              `json:"id" db:"id"`
+	UUID              string             `json:"uuid" db:"uuid"`
+	IsEnabled         bool               `json:"isEnabled" db:"is_enabled"`
+	RolloutPercentage *int               `json:"rolloutPercentage" db:"rollout_percentage"`
+	ExecuteAt         time.Time          `json:"executeAt" db:"execute_at"`
+	Status            FlagScheduleStatus `json:"status" db:"status"`
+	Error             *string            `json:"error" db:"error"`
+	Executed          *time.Time         `json:"executed" db:"executed"`
+	Created           time.Time          `json:"created" db:"created"`
+	CreatedBy         int64              `json:"createdBy" db:"created_by"`
+	Modified          *time.Time         `json:"modified" db:"modified"`
+	ModifiedBy        *int64             `json:"modifiedBy" db:"modified_by"`
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type JobKind string
+
+const (
+	JobKindWebhookDeliver JobKind = "webhook.deliver"
+)
+
+type JobStatus string
+
+// Jobs that fail are retried with backoff and become dead once they run out
+// of attempts, where they stay until retried by hand
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusDead      JobStatus = "dead"
+)
+
+func (s JobStatus) IsValid() bool {
+	switch s {
+	case JobStatusPending, JobStatusRunning, JobStatusSucceeded, JobStatusDead:
+		return true
+	}
+	return false
+}
+
+// Job is a unit of background work. Attempts includes the current one while
+// the job is running, LastError is the error of the latest failed attempt.
+type Job struct {
+	ID          int64           `json:"id" db:"id"`
+	UUID        string          `json:"uuid" db:"uuid"`
+	Kind        JobKind         `json:"kind" db:"kind"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	Status      JobStatus       `json:"status" db:"status"`
+	Attempts    int             `json:"attempts" db:"attempts"`
+	MaxAttempts int             `json:"maxAttempts" db:"max_attempts"`
+	RunAt       time.Time       `json:"runAt" db:"run_at"`
+	LockedUntil *time.Time      `json:"lockedUntil" db:"locked_until"`
+	LastError   *string         `json:"lastError" db:"last_error"`
+	Created     time.Time       `json:"created" db:"created"`
+	Completed   *time.Time      `json:"completed" db:"completed"`
+}
@@ -18,6 +18,7 @@ type OperationTracer struct {
 	TraceID     string
 	StartTime   time.Time
 	AuthAccount Account
+	SDKKey      *SDKKey
 }
 
 func NewOperationCtx(
@@ -3,13 +3,25 @@ package types
 import "time"
 
 type OrgGroupFeatureFlag struct {
-	OrgID      int64      `json:"orgId" db:"org_id"`
-	GroupID    int64      `json:"groupId" db:"group_id"`
-	AppID      int64      `json:"appId" db:"application_id"`
-	FlagID     int64      `json:"flagId" db:"flag_id"`
-	IsEnabled  bool       `json:"isEnabled" db:"is_enabled"`
-	Created    time.Time  `json:"created" db:"created"`
-	CreatedBy  int64      `json:"createdBy" db:"created_by"`
-	Modified   *time.Time `json:"modified" db:"modified"`
-	ModifiedBy *int64     `json:"modifiedBy" db:"modified_by"`
+	OrgID             int64      `json:"orgId" db:"org_id"`
+	GroupID           int64      `json:"groupId" db:"group_id"`
+	AppID             int64      `json:"appId" db:"application_id"`
+	EnvironmentID     int64      `json:"environmentId" db:"environment_id"`
+	FlagID            int64      `json:"flagId" db:"flag_id"`
+	IsEnabled         bool       `json:"isEnabled" db:"is_enabled"`
+	RolloutPercentage *int       `json:"rolloutPercentage" db:"rollout_percentage"`
+	Variant           *string    `json:"variant" db:"variant"`
+	Created           time.Time  `json:"created" db:"created"`
+	CreatedBy         int64      `json:"createdBy" db:"created_by"`
+	Modified          *time.Time `json:"modified" db:"modified"`
+	ModifiedBy        *int64     `json:"modifiedBy" db:"modified_by"`
+}
+
+// GroupFlagOverride is a group's override of a flag on its own, as used to
+// replace every override of a flag at once
+type GroupFlagOverride struct {
+	GroupID           int64   `json:"groupId"`
+	IsEnabled         bool    `json:"isEnabled"`
+	RolloutPercentage *int    `json:"rolloutPercentage"`
+	Variant           *string `json:"variant"`
 }
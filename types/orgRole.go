@@ -0,0 +1,68 @@
+package types
+
+import "time"
+
+type OrgRole string
+
+const (
+	OrgRoleOwner  OrgRole = "owner"
+	OrgRoleAdmin  OrgRole = "admin"
+	OrgRoleEditor OrgRole = "editor"
+	OrgRoleViewer OrgRole = "viewer"
+)
+
+var orgRoleRank = map[OrgRole]int{
+	OrgRoleViewer: 1,
+	OrgRoleEditor: 2,
+	OrgRoleAdmin:  3,
+	OrgRoleOwner:  4,
+}
+
+func (r OrgRole) IsValid() bool {
+	_, ok := orgRoleRank[r]
+	return ok
+}
+
+// Includes reports whether r grants everything other does, e.g. admins can do
+// anything editors can
+func (r OrgRole) Includes(other OrgRole) bool {
+	return r.IsValid() && orgRoleRank[r] >= orgRoleRank[other]
+}
+
+type OrgAccountRole struct {
+	OrgID      int64      `json:"orgId" db:"org_id"`
+	AccountID  int64      `json:"accountId" db:"account_id"`
+	Role       OrgRole    `json:"role" db:"role"`
+	Created    time.Time  `json:"created" db:"created"`
+	CreatedBy  int64      `json:"createdBy" db:"created_by"`
+	Modified   *time.Time `json:"modified" db:"modified"`
+	ModifiedBy *int64     `json:"modifiedBy" db:"modified_by"`
+}
+
+type OrgGroupRole struct {
+	OrgID      int64      `json:"orgId" db:"org_id"`
+	GroupID    int64      `json:"groupId" db:"group_id"`
+	Role       OrgRole    `json:"role" db:"role"`
+	Created    time.Time  `json:"created" db:"created"`
+	CreatedBy  int64      `json:"createdBy" db:"created_by"`
+	Modified   *time.Time `json:"modified" db:"modified"`
+	ModifiedBy *int64     `json:"modifiedBy" db:"modified_by"`
+}
+
+// OrgRoleGrant is a role held by an account, either directly or through a
+// group when GroupID is set
+type OrgRoleGrant struct {
+	GroupID *int64  `json:"groupId" db:"group_id"`
+	Role    OrgRole `json:"role" db:"role"`
+}
+
+// OrgAccountRoles is the effective role of an account within an org, the
+// highest of its grants. Org owners always hold the owner role and every org
+// account is at least a viewer.
+type OrgAccountRoles struct {
+	OrgID     int64          `json:"orgId"`
+	AccountID int64          `json:"accountId"`
+	Role      OrgRole        `json:"role"`
+	IsOwner   bool           `json:"isOwner"`
+	Grants    []OrgRoleGrant `json:"grants"`
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// OrgSegment is a reusable audience that targeting rules of any flag in the
+// org can reference. An account is in the segment when it is listed
+// explicitly or matches any of the segment's rules.
+type OrgSegment struct {
+	OrgID       int64         `json:"orgId" db:"org_id"`
+	ID          int64         `json:"id" db:"id"`
+	UUID        string        `json:"uuid" db:"uuid"`
+	Name        string        `json:"name" db:"name"`
+	Description string        `json:"description" db:"description"`
+	Rules       []SegmentRule `json:"rules" db:"rules"`
+	AccountIDs  []int64       `json:"accountIds" db:"account_ids"`
+	Created     time.Time     `json:"created" db:"created"`
+	CreatedBy   int64         `json:"createdBy" db:"created_by"`
+	Modified    *time.Time    `json:"modified" db:"modified"`
+	ModifiedBy  *int64        `json:"modifiedBy" db:"modified_by"`
+}
+
+// SegmentRule matches accounts whose evaluation context matches every
+// condition. Segment rules cannot reference other segments.
+type SegmentRule struct {
+	Conditions []TargetingCondition `json:"conditions"`
+}
+
+// OrgSegmentFlagRef is a flag whose targeting rules reference a segment
+type OrgSegmentFlagRef struct {
+	SegmentID       int64  `json:"segmentId" db:"segment_id"`
+	ApplicationID   int64  `json:"applicationId" db:"application_id"`
+	ApplicationSlug string `json:"applicationSlug" db:"application_slug"`
+	EnvironmentID   int64  `json:"environmentId" db:"environment_id"`
+	EnvironmentSlug string `json:"environmentSlug" db:"environment_slug"`
+	FlagID          int64  `json:"flagId" db:"flag_id"`
+	FlagName        string `json:"flagName" db:"flag_name"`
+}
+
+func (r *SegmentRule) Validate() error {
+	if len(r.Conditions) == 0 {
+		return fmt.Errorf("%w: rule must have at least one condition", ErrInvalidState)
+	}
+	for i := range r.Conditions {
+		if r.Conditions[i].Operator.IsSegment() {
+			return fmt.Errorf("%w: condition %d: segment rules cannot reference other segments", ErrInvalidState, i)
+		}
+		if err := r.Conditions[i].Validate(); err != nil {
+			return fmt.Errorf("condition %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Contains reports whether the account in the evaluation context belongs to
+// the segment
+func (s *OrgSegment) Contains(evalCtx EvaluationContext) bool {
+	if evalCtx.AccountID > 0 && slices.Contains(s.AccountIDs, evalCtx.AccountID) {
+		return true
+	}
+
+	for i := range s.Rules {
+		matched := true
+		for j := range s.Rules[i].Conditions {
+			if !s.Rules[i].Conditions[j].Matches(evalCtx) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TargetingRuleSegmentIDs returns the distinct segments referenced by rules
+func TargetingRuleSegmentIDs(rules []TargetingRule) []int64 {
+	var segmentIDs []int64
+	for i := range rules {
+		for _, condition := range rules[i].Conditions {
+			if !condition.Operator.IsSegment() {
+				continue
+			}
+			if segmentID, ok := condition.SegmentID(); ok && !slices.Contains(segmentIDs, segmentID) {
+				segmentIDs = append(segmentIDs, segmentID)
+			}
+		}
+	}
+	return segmentIDs
+}
@@ -0,0 +1,23 @@
+package types
+
+// SDKConfig is everything an SDK needs to evaluate an application's flags
+// locally. GroupAccounts only includes memberships of groups with overrides
+// in the environment. AccountUUIDs maps org account IDs to UUIDs for rollout
+// bucketing and is only populated when the app has a percentage rollout.
+// Segments only includes segments referenced by the app's targeting rules.
+//
+// The config carries org account identifiers, which is why it is only served
+// to server SDK keys and should never be forwarded to untrusted clients.
+type SDKConfig struct {
+	Flags         []FeatureFlag         `json:"flags"`
+	GroupFlags    []OrgGroupFeatureFlag `json:"groupFlags"`
+	GroupAccounts []SDKGroupAccount     `json:"groupAccounts"`
+	AccountUUIDs  map[int64]string      `json:"accountUuids"`
+	Segments      []OrgSegment          `json:"segments"`
+}
+
+// SDKGroupAccount is a group membership trimmed to what evaluation needs
+type SDKGroupAccount struct {
+	GroupID   int64 `json:"groupId" db:"group_id"`
+	AccountID int64 `json:"accountId" db:"account_id"`
+}
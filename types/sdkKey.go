@@ -0,0 +1,41 @@
+package types
+
+import "time"
+
+type SDKKeyType string
+
+const (
+	// Server keys are secret and may read full flag configuration
+	SDKKeyTypeServer SDKKeyType = "server"
+	// Client keys are safe to ship to browsers/devices and may only evaluate
+	SDKKeyTypeClient SDKKeyType = "client"
+)
+
+// SDKKeyPrefix is prepended to every generated SDK key so they can be told
+// apart from user JWTs in the Authorization header.
+const SDKKeyPrefix = "sc_"
+
+type SDKKey struct {
+	OrgID         int64      `json:"orgId" db:"org_id"`
+	ApplicationID int64      `json:"applicationId" db:"application_id"`
+	EnvironmentID int64      `json:"environmentId" db:"environment_id"`
+	ID            int64      `json:"id" db:"id"`
+	UUID          string     `json:"uuid" db:"uuid"`
+	Name          string     `json:"name" db:"name"`
+	KeyType       SDKKeyType `json:"keyType" db:"key_type"`
+	KeyPrefix     string     `json:"keyPrefix" db:"key_prefix"`
+	KeyHash       string     `json:"-" db:"key_hash"`
+	Created       time.Time  `json:"created" db:"created"`
+	CreatedBy     int64      `json:"createdBy" db:"created_by"`
+	Modified      *time.Time `json:"modified" db:"modified"`
+	ModifiedBy    *int64     `json:"modifiedBy" db:"modified_by"`
+	Revoked       *time.Time `json:"revoked" db:"revoked"`
+	RevokedBy     *int64     `json:"revokedBy" db:"revoked_by"`
+}
+
+// SDKKeyWithSecret is only returned when a key is created or rotated, the
+// plaintext key is never stored and cannot be retrieved again.
+type SDKKeyWithSecret struct {
+	SDKKey
+	Key string `json:"key"`
+}
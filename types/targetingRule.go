@@ -0,0 +1,487 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type TargetingOperator string
+
+const (
+	TargetingOperatorEquals     TargetingOperator = "equals"
+	TargetingOperatorNotEquals  TargetingOperator = "not_equals"
+	TargetingOperatorIn         TargetingOperator = "in"
+	TargetingOperatorNotIn      TargetingOperator = "not_in"
+	TargetingOperatorContains   TargetingOperator = "contains"
+	TargetingOperatorStartsWith TargetingOperator = "starts_with"
+	TargetingOperatorEndsWith   TargetingOperator = "ends_with"
+	TargetingOperatorRegex      TargetingOperator = "regex"
+	TargetingOperatorGT         TargetingOperator = "gt"
+	TargetingOperatorGTE        TargetingOperator = "gte"
+	TargetingOperatorLT         TargetingOperator = "lt"
+	TargetingOperatorLTE        TargetingOperator = "lte"
+	TargetingOperatorSemverEq   TargetingOperator = "semver_eq"
+	TargetingOperatorSemverGT   TargetingOperator = "semver_gt"
+	TargetingOperatorSemverGTE  TargetingOperator = "semver_gte"
+	TargetingOperatorSemverLT   TargetingOperator = "semver_lt"
+	TargetingOperatorSemverLTE  TargetingOperator = "semver_lte"
+	TargetingOperatorBefore     TargetingOperator = "before"
+	TargetingOperatorAfter      TargetingOperator = "after"
+
+	TargetingOperatorInSegment    TargetingOperator = "in_segment"
+	TargetingOperatorNotInSegment TargetingOperator = "not_in_segment"
+)
+
+// IsSegment reports whether the operator matches segment membership rather
+// than an attribute
+func (o TargetingOperator) IsSegment() bool {
+	return o == TargetingOperatorInSegment || o == TargetingOperatorNotInSegment
+}
+
+// TargetingRule serves IsEnabled, and Variant for non-boolean flags, to
+// accounts whose evaluation context matches every condition
+type TargetingRule struct {
+	Conditions []TargetingCondition `json:"conditions"`
+	IsEnabled  bool                 `json:"isEnabled"`
+	Variant    *string              `json:"variant"`
+}
+
+// TargetingCondition compares a single evaluation context attribute. Value is
+// an array for in/not_in, a number for numeric comparisons, an RFC 3339
+// timestamp for before/after, and a string for everything else.
+//
+// Segment conditions (in_segment/not_in_segment) take no attribute, their
+// value is the ID of an org segment.
+type TargetingCondition struct {
+	Attribute string            `json:"attribute"`
+	Operator  TargetingOperator `json:"operator"`
+	Value     json.RawMessage   `json:"value"`
+}
+
+// EvaluationContext is what a flag is evaluated against. Attributes are
+// supplied by the caller and are only used by targeting rules. Segments must
+// hold every segment the flag's rules reference, keyed by ID.
+type EvaluationContext struct {
+	AccountID   int64
+	AccountUUID string
+	Attributes  map[string]any
+	Segments    map[int64]*OrgSegment
+}
+
+func (r *TargetingRule) Validate() error {
+	if len(r.Conditions) == 0 {
+		return fmt.Errorf("%w: rule must have at least one condition", ErrInvalidState)
+	}
+	for i := range r.Conditions {
+		if err := r.Conditions[i].Validate(); err != nil {
+			return fmt.Errorf("condition %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether every condition matches the evaluation context
+func (r *TargetingRule) Matches(evalCtx EvaluationContext) bool {
+	for i := range r.Conditions {
+		if !r.Conditions[i].Matches(evalCtx) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *TargetingCondition) Validate() error {
+	if c.Operator.IsSegment() {
+		if _, ok := c.SegmentID(); !ok {
+			return fmt.Errorf("%w: '%s' value must be a segment ID", ErrInvalidState, c.Operator)
+		}
+		return nil
+	}
+
+	if c.Attribute == "" {
+		return fmt.Errorf("%w: attribute cannot be empty", ErrInvalidState)
+	}
+
+	var value any
+	if err := json.Unmarshal(c.Value, &value); err != nil {
+		return fmt.Errorf("%w: invalid value for '%s': %v", ErrInvalidState, c.Operator, err)
+	}
+
+	switch c.Operator {
+	case TargetingOperatorEquals, TargetingOperatorNotEquals:
+		if !isScalar(value) {
+			return fmt.Errorf("%w: '%s' value must be a string, number, or boolean", ErrInvalidState, c.Operator)
+		}
+	case TargetingOperatorIn, TargetingOperatorNotIn:
+		values, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%w: '%s' value must be an array", ErrInvalidState, c.Operator)
+		}
+		for _, v := range values {
+			if !isScalar(v) {
+				return fmt.Errorf("%w: '%s' values must be strings, numbers, or booleans", ErrInvalidState, c.Operator)
+			}
+		}
+	case TargetingOperatorContains, TargetingOperatorStartsWith, TargetingOperatorEndsWith:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%w: '%s' value must be a string", ErrInvalidState, c.Operator)
+		}
+	case TargetingOperatorRegex:
+		pattern, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%w: '%s' value must be a string", ErrInvalidState, c.Operator)
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%w: invalid regex: %v", ErrInvalidState, err)
+		}
+	case TargetingOperatorGT, TargetingOperatorGTE, TargetingOperatorLT, TargetingOperatorLTE:
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%w: '%s' value must be a number", ErrInvalidState, c.Operator)
+		}
+	case TargetingOperatorSemverEq,
+		TargetingOperatorSemverGT,
+		TargetingOperatorSemverGTE,
+		TargetingOperatorSemverLT,
+		TargetingOperatorSemverLTE:
+		version, _ := value.(string)
+		if _, ok := parseSemver(version); !ok {
+			return fmt.Errorf("%w: '%s' value must be a semantic version", ErrInvalidState, c.Operator)
+		}
+	case TargetingOperatorBefore, TargetingOperatorAfter:
+		timestamp, _ := value.(string)
+		if _, err := time.Parse(time.RFC3339, timestamp); err != nil {
+			return fmt.Errorf("%w: '%s' value must be an RFC 3339 timestamp", ErrInvalidState, c.Operator)
+		}
+	default:
+		return fmt.Errorf("%w: invalid operator '%s'", ErrInvalidState, c.Operator)
+	}
+
+	return nil
+}
+
+// SegmentID returns the segment referenced by an in_segment/not_in_segment
+// condition
+func (c *TargetingCondition) SegmentID() (int64, bool) {
+	var segmentID int64
+	if err := json.Unmarshal(c.Value, &segmentID); err != nil || segmentID < 1 {
+		return 0, false
+	}
+	return segmentID, true
+}
+
+// Matches evaluates the condition against the evaluation context. Conditions
+// on attributes that are missing, or of the wrong type, never match, and
+// neither do conditions on segments missing from the context.
+func (c *TargetingCondition) Matches(evalCtx EvaluationContext) bool {
+	if c.Operator.IsSegment() {
+		segmentID, _ := c.SegmentID()
+		segment, ok := evalCtx.Segments[segmentID]
+		if !ok {
+			return false
+		}
+		return segment.Contains(evalCtx) == (c.Operator == TargetingOperatorInSegment)
+	}
+
+	actual, ok := evalCtx.Attributes[c.Attribute]
+	if !ok || actual == nil {
+		return false
+	}
+
+	var expected any
+	if err := json.Unmarshal(c.Value, &expected); err != nil {
+		return false
+	}
+
+	switch c.Operator {
+	case TargetingOperatorEquals:
+		return scalarEqual(actual, expected)
+	case TargetingOperatorNotEquals:
+		return !scalarEqual(actual, expected)
+	case TargetingOperatorIn, TargetingOperatorNotIn:
+		values, _ := expected.([]any)
+		found := false
+		for _, v := range values {
+			if scalarEqual(actual, v) {
+				found = true
+				break
+			}
+		}
+		return found == (c.Operator == TargetingOperatorIn)
+	case TargetingOperatorContains:
+		if list, ok := actual.([]any); ok {
+			for _, v := range list {
+				if scalarEqual(v, expected) {
+					return true
+				}
+			}
+			return false
+		}
+		return stringsMatch(actual, expected, strings.Contains)
+	case TargetingOperatorStartsWith:
+		return stringsMatch(actual, expected, strings.HasPrefix)
+	case TargetingOperatorEndsWith:
+		return stringsMatch(actual, expected, strings.HasSuffix)
+	case TargetingOperatorRegex:
+		s, ok := actual.(string)
+		pattern, _ := expected.(string)
+		if !ok {
+			return false
+		}
+		re, err := compileTargetingRegex(pattern)
+		return err == nil && re.MatchString(s)
+	case TargetingOperatorGT, TargetingOperatorGTE, TargetingOperatorLT, TargetingOperatorLTE:
+		a, ok := toFloat(actual)
+		b, _ := toFloat(expected)
+		if !ok {
+			return false
+		}
+		return compareMatches(c.Operator, compareFloats(a, b))
+	case TargetingOperatorSemverEq,
+		TargetingOperatorSemverGT,
+		TargetingOperatorSemverGTE,
+		TargetingOperatorSemverLT,
+		TargetingOperatorSemverLTE:
+		s, _ := actual.(string)
+		e, _ := expected.(string)
+		a, ok := parseSemver(s)
+		b, _ := parseSemver(e)
+		if !ok {
+			return false
+		}
+		return compareMatches(c.Operator, a.compare(b))
+	case TargetingOperatorBefore, TargetingOperatorAfter:
+		a, ok := toTime(actual)
+		e, _ := expected.(string)
+		b, err := time.Parse(time.RFC3339, e)
+		if !ok || err != nil {
+			return false
+		}
+		if c.Operator == TargetingOperatorBefore {
+			return a.Before(b)
+		}
+		return a.After(b)
+	}
+
+	return false
+}
+
+func compareMatches(operator TargetingOperator, cmp int) bool {
+	switch operator {
+	case TargetingOperatorSemverEq:
+		return cmp == 0
+	case TargetingOperatorGT, TargetingOperatorSemverGT:
+		return cmp > 0
+	case TargetingOperatorGTE, TargetingOperatorSemverGTE:
+		return cmp >= 0
+	case TargetingOperatorLT, TargetingOperatorSemverLT:
+		return cmp < 0
+	case TargetingOperatorLTE, TargetingOperatorSemverLTE:
+		return cmp <= 0
+	}
+	return false
+}
+
+func compareFloats(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func isScalar(v any) bool {
+	switch v.(type) {
+	case string, float64, bool:
+		return true
+	}
+	return false
+}
+
+// scalarEqual compares attribute values, treating every numeric type alike
+// since attributes may come from JSON or straight from Go callers
+func scalarEqual(a any, b any) bool {
+	if af, ok := toFloat(a); ok {
+		bf, ok := toFloat(b)
+		return ok && af == bf
+	}
+	switch av := a.(type) {
+	case string:
+		bv, ok := b.(string)
+		return ok && av == bv
+	case bool:
+		bv, ok := b.(bool)
+		return ok && av == bv
+	}
+	return false
+}
+
+func stringsMatch(actual any, expected any, match func(string, string) bool) bool {
+	a, ok := actual.(string)
+	e, _ := expected.(string)
+	return ok && match(a, e)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toTime accepts RFC 3339 strings, unix timestamps in seconds, and time.Time
+func toTime(v any) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case string:
+		parsed, err := time.Parse(time.RFC3339, t)
+		return parsed, err == nil
+	}
+	if seconds, ok := toFloat(v); ok {
+		return time.Unix(int64(seconds), 0), true
+	}
+	return time.Time{}, false
+}
+
+// targetingRegexCacheSize bounds the compiled patterns kept for evaluation.
+// Once full the cache starts over rather than growing with every pattern.
+const targetingRegexCacheSize = 256
+
+var targetingRegexCache = struct {
+	sync.Mutex
+	patterns map[string]*regexp.Regexp
+}{patterns: map[string]*regexp.Regexp{}}
+
+func compileTargetingRegex(pattern string) (*regexp.Regexp, error) {
+	targetingRegexCache.Lock()
+	re, ok := targetingRegexCache.patterns[pattern]
+	targetingRegexCache.Unlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	targetingRegexCache.Lock()
+	if len(targetingRegexCache.patterns) >= targetingRegexCacheSize {
+		clear(targetingRegexCache.patterns)
+	}
+	targetingRegexCache.patterns[pattern] = re
+	targetingRegexCache.Unlock()
+
+	return re, nil
+}
+
+type semver struct {
+	major      int
+	minor      int
+	patch      int
+	prerelease string
+}
+
+// parseSemver parses MAJOR.MINOR.PATCH versions with an optional leading v,
+// prerelease, and build metadata. Build metadata is ignored.
+func parseSemver(version string) (semver, bool) {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexByte(version, '+'); i >= 0 {
+		version = version[:i]
+	}
+
+	var v semver
+	if i := strings.IndexByte(version, '-'); i >= 0 {
+		v.prerelease = version[i+1:]
+		version = version[:i]
+		if v.prerelease == "" {
+			return semver{}, false
+		}
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return semver{}, false
+	}
+
+	nums := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+		nums[i] = n
+	}
+	v.major, v.minor, v.patch = nums[0], nums[1], nums[2]
+
+	return v, true
+}
+
+// compare orders versions per the semver spec, where a prerelease sorts
+// before its release
+func (v semver) compare(other semver) int {
+	for _, diff := range []int{
+		v.major - other.major,
+		v.minor - other.minor,
+		v.patch - other.patch,
+	} {
+		if diff != 0 {
+			return compareFloats(float64(diff), 0)
+		}
+	}
+
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	}
+
+	a := strings.Split(v.prerelease, ".")
+	b := strings.Split(other.prerelease, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		an, aErr := strconv.Atoi(a[i])
+		bn, bErr := strconv.Atoi(b[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			return compareFloats(float64(an), float64(bn))
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		}
+		return strings.Compare(a[i], b[i])
+	}
+
+	return compareFloats(float64(len(a)), float64(len(b)))
+}
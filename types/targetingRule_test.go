@@ -0,0 +1,190 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTargetingConditionMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator TargetingOperator
+		value    string
+		actual   any
+		want     bool
+	}{
+		{"equals string", TargetingOperatorEquals, `"DE"`, "DE", true},
+		{"equals other string", TargetingOperatorEquals, `"DE"`, "FR", false},
+		{"equals int against JSON number", TargetingOperatorEquals, `3`, 3, true},
+		{"equals bool", TargetingOperatorEquals, `true`, true, true},
+		{"equals string against number", TargetingOperatorEquals, `"3"`, 3, false},
+		{"not_equals", TargetingOperatorNotEquals, `"DE"`, "FR", true},
+		{"not_equals same", TargetingOperatorNotEquals, `"DE"`, "DE", false},
+
+		{"in", TargetingOperatorIn, `["DE","FR"]`, "FR", true},
+		{"in missing", TargetingOperatorIn, `["DE","FR"]`, "US", false},
+		{"in numbers", TargetingOperatorIn, `[1,2]`, int64(2), true},
+		{"not_in", TargetingOperatorNotIn, `["DE","FR"]`, "US", true},
+		{"not_in present", TargetingOperatorNotIn, `["DE","FR"]`, "DE", false},
+
+		{"contains substring", TargetingOperatorContains, `"@example.com"`, "ann@example.com", true},
+		{"contains missing substring", TargetingOperatorContains, `"@example.com"`, "ann@example.org", false},
+		{"contains list element", TargetingOperatorContains, `"beta"`, []any{"alpha", "beta"}, true},
+		{"contains missing list element", TargetingOperatorContains, `"beta"`, []any{"alpha"}, false},
+		{"contains number", TargetingOperatorContains, `"1"`, 1, false},
+		{"starts_with", TargetingOperatorStartsWith, `"ann"`, "ann@example.com", true},
+		{"ends_with", TargetingOperatorEndsWith, `".org"`, "ann@example.com", false},
+
+		{"regex", TargetingOperatorRegex, `"^[a-z]+@example\\.com$"`, "ann@example.com", true},
+		{"regex no match", TargetingOperatorRegex, `"^[a-z]+@example\\.com$"`, "Ann@example.com", false},
+		{"regex invalid pattern", TargetingOperatorRegex, `"("`, "(", false},
+		{"regex number", TargetingOperatorRegex, `"1"`, 1, false},
+
+		{"gt", TargetingOperatorGT, `10`, 11, true},
+		{"gt equal", TargetingOperatorGT, `10`, 10, false},
+		{"gte equal", TargetingOperatorGTE, `10`, 10.0, true},
+		{"lt float", TargetingOperatorLT, `1.5`, 1.25, true},
+		{"lte greater", TargetingOperatorLTE, `10`, uint(11), false},
+		{"gt json.Number", TargetingOperatorGT, `10`, json.Number("12"), true},
+		{"gt string", TargetingOperatorGT, `10`, "11", false},
+
+		{"semver_eq", TargetingOperatorSemverEq, `"1.2.3"`, "v1.2.3", true},
+		{"semver_eq ignores build", TargetingOperatorSemverEq, `"1.2.3"`, "1.2.3+build.7", true},
+		{"semver_gt minor", TargetingOperatorSemverGT, `"1.2.3"`, "1.10.0", true},
+		{"semver_gt prerelease of same version", TargetingOperatorSemverGT, `"1.2.3"`, "1.2.3-rc.1", false},
+		{"semver_gte", TargetingOperatorSemverGTE, `"1.2.3"`, "1.2.3", true},
+		{"semver_lt prerelease", TargetingOperatorSemverLT, `"1.2.3"`, "1.2.3-alpha", true},
+		{"semver_lt numeric prerelease", TargetingOperatorSemverLT, `"1.0.0-rc.10"`, "1.0.0-rc.2", true},
+		{"semver_lt numeric before alphanumeric", TargetingOperatorSemverLT, `"1.0.0-alpha.beta"`, "1.0.0-alpha.1", true},
+		{"semver_lte shorter prerelease", TargetingOperatorSemverLTE, `"1.0.0-alpha.1"`, "1.0.0-alpha", true},
+		{"semver invalid actual", TargetingOperatorSemverGT, `"1.2.3"`, "1.2", false},
+
+		{"before", TargetingOperatorBefore, `"2026-01-01T00:00:00Z"`, "2025-12-31T23:59:59Z", true},
+		{"before later", TargetingOperatorBefore, `"2026-01-01T00:00:00Z"`, "2026-01-01T00:00:01Z", false},
+		{"after unix seconds", TargetingOperatorAfter, `"2026-01-01T00:00:00Z"`, 1767225601, true},
+		{"after time.Time", TargetingOperatorAfter, `"2026-01-01T00:00:00Z"`, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), false},
+		{"after invalid timestamp", TargetingOperatorAfter, `"2026-01-01T00:00:00Z"`, "yesterday", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := TargetingCondition{Attribute: "attr", Operator: tt.operator, Value: json.RawMessage(tt.value)}
+			evalCtx := EvaluationContext{Attributes: map[string]any{"attr": tt.actual}}
+			if got := condition.Matches(evalCtx); got != tt.want {
+				t.Errorf("%s %s against %v: got %t, want %t", tt.operator, tt.value, tt.actual, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargetingConditionMatchesMissingAttribute(t *testing.T) {
+	for _, operator := range []TargetingOperator{TargetingOperatorNotEquals, TargetingOperatorNotIn} {
+		condition := TargetingCondition{Attribute: "country", Operator: operator, Value: json.RawMessage(`["DE"]`)}
+		if condition.Matches(EvaluationContext{Attributes: map[string]any{"plan": "pro"}}) {
+			t.Errorf("%s matched a missing attribute", operator)
+		}
+		if condition.Matches(EvaluationContext{Attributes: map[string]any{"country": nil}}) {
+			t.Errorf("%s matched a nil attribute", operator)
+		}
+	}
+}
+
+func TestTargetingConditionMatchesSegment(t *testing.T) {
+	segment := &OrgSegment{ID: 4, AccountIDs: []int64{7}}
+	evalCtx := EvaluationContext{AccountID: 7, Segments: map[int64]*OrgSegment{4: segment}}
+
+	in := TargetingCondition{Operator: TargetingOperatorInSegment, Value: json.RawMessage(`4`)}
+	notIn := TargetingCondition{Operator: TargetingOperatorNotInSegment, Value: json.RawMessage(`4`)}
+	if !in.Matches(evalCtx) || notIn.Matches(evalCtx) {
+		t.Error("account listed in the segment did not match in_segment only")
+	}
+
+	// A segment missing from the context never matches, either way
+	missing := EvaluationContext{AccountID: 7}
+	if in.Matches(missing) || notIn.Matches(missing) {
+		t.Error("condition on a segment missing from the context matched")
+	}
+}
+
+func TestTargetingConditionValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		attribute string
+		operator  TargetingOperator
+		value     string
+		valid     bool
+	}{
+		{"equals string", "country", TargetingOperatorEquals, `"DE"`, true},
+		{"equals array", "country", TargetingOperatorEquals, `["DE"]`, false},
+		{"empty attribute", "", TargetingOperatorEquals, `"DE"`, false},
+		{"invalid JSON", "country", TargetingOperatorEquals, `DE`, false},
+		{"unknown operator", "country", TargetingOperator("like"), `"DE"`, false},
+		{"in array", "country", TargetingOperatorIn, `["DE",1,true]`, true},
+		{"in scalar", "country", TargetingOperatorIn, `"DE"`, false},
+		{"in nested array", "country", TargetingOperatorNotIn, `[["DE"]]`, false},
+		{"contains string", "email", TargetingOperatorContains, `"@example.com"`, true},
+		{"starts_with number", "email", TargetingOperatorStartsWith, `1`, false},
+		{"regex", "email", TargetingOperatorRegex, `"^ann"`, true},
+		{"regex invalid", "email", TargetingOperatorRegex, `"("`, false},
+		{"regex number", "email", TargetingOperatorRegex, `1`, false},
+		{"gt number", "age", TargetingOperatorGT, `18`, true},
+		{"gt string", "age", TargetingOperatorGT, `"18"`, false},
+		{"semver", "version", TargetingOperatorSemverGTE, `"v1.2.3-rc.1+build"`, true},
+		{"semver partial", "version", TargetingOperatorSemverGTE, `"1.2"`, false},
+		{"semver empty prerelease", "version", TargetingOperatorSemverLT, `"1.2.3-"`, false},
+		{"before RFC 3339", "signedUp", TargetingOperatorBefore, `"2026-01-01T00:00:00Z"`, true},
+		{"after date only", "signedUp", TargetingOperatorAfter, `"2026-01-01"`, false},
+		{"in_segment", "", TargetingOperatorInSegment, `4`, true},
+		{"in_segment zero", "", TargetingOperatorInSegment, `0`, false},
+		{"not_in_segment string", "", TargetingOperatorNotInSegment, `"4"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := TargetingCondition{Attribute: tt.attribute, Operator: tt.operator, Value: json.RawMessage(tt.value)}
+			err := condition.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("expected valid, got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidState) {
+				t.Errorf("expected ErrInvalidState, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTargetingRuleValidate(t *testing.T) {
+	if err := (&TargetingRule{IsEnabled: true}).Validate(); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("rule without conditions: expected ErrInvalidState, got %v", err)
+	}
+
+	rule := TargetingRule{Conditions: []TargetingCondition{
+		{Attribute: "country", Operator: TargetingOperatorEquals, Value: json.RawMessage(`"DE"`)},
+		{Attribute: "age", Operator: TargetingOperatorGT, Value: json.RawMessage(`"18"`)},
+	}}
+	if err := rule.Validate(); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("rule with an invalid condition: expected ErrInvalidState, got %v", err)
+	}
+
+	rule.Conditions[1].Value = json.RawMessage(`18`)
+	if err := rule.Validate(); err != nil {
+		t.Errorf("expected valid, got %v", err)
+	}
+}
+
+func TestCompileTargetingRegexIsBounded(t *testing.T) {
+	for i := 0; i < targetingRegexCacheSize*2; i++ {
+		if _, err := compileTargetingRegex(fmt.Sprintf("^user-%d$", i)); err != nil {
+			t.Fatalf("compileTargetingRegex: %v", err)
+		}
+	}
+
+	targetingRegexCache.Lock()
+	defer targetingRegexCache.Unlock()
+	if len(targetingRegexCache.patterns) > targetingRegexCacheSize {
+		t.Errorf("cache holds %d patterns, want at most %d", len(targetingRegexCache.patterns), targetingRegexCacheSize)
+	}
+}
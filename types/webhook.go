@@ -0,0 +1,97 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookEventType string
+
+// Flag and group flag events match the flag event types they are sent for
+const (
+	WebhookEventFlagCreated          WebhookEventType = WebhookEventType(FlagEventFlagCreated)
+	WebhookEventFlagUpdated          WebhookEventType = WebhookEventType(FlagEventFlagUpdated)
+	WebhookEventFlagDeleted          WebhookEventType = WebhookEventType(FlagEventFlagDeleted)
+	WebhookEventGroupFlagCreated     WebhookEventType = WebhookEventType(FlagEventGroupFlagCreated)
+	WebhookEventGroupFlagUpdated     WebhookEventType = WebhookEventType(FlagEventGroupFlagUpdated)
+	WebhookEventGroupFlagDeleted     WebhookEventType = WebhookEventType(FlagEventGroupFlagDeleted)
+	WebhookEventGroupAccountsChanged WebhookEventType = "group.accounts.changed"
+)
+
+var webhookEventTypes = []WebhookEventType{
+	WebhookEventFlagCreated,
+	WebhookEventFlagUpdated,
+	WebhookEventFlagDeleted,
+	WebhookEventGroupFlagCreated,
+	WebhookEventGroupFlagUpdated,
+	WebhookEventGroupFlagDeleted,
+	WebhookEventGroupAccountsChanged,
+}
+
+func (t WebhookEventType) IsValid() bool {
+	for _, eventType := range webhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook subscribes a URL to changes in an org. An empty EventTypes
+// subscribes to every event. The secret signs deliveries and is never
+// returned.
+type Webhook struct {
+	OrgID      int64              `json:"orgId" db:"org_id"`
+	ID         int64              `json:"id" db:"id"`
+	UUID       string             `json:"uuid" db:"uuid"`
+	URL        string             `json:"url" db:"url"`
+	Secret     string             `json:"-" db:"secret"`
+	EventTypes []WebhookEventType `json:"eventTypes" db:"event_types"`
+	IsEnabled  bool               `json:"isEnabled" db:"is_enabled"`
+	Created    time.Time          `json:"created" db:"created"`
+	CreatedBy  int64              `json:"createdBy" db:"created_by"`
+	Modified   *time.Time         `json:"modified" db:"modified"`
+	ModifiedBy *int64             `json:"modifiedBy" db:"modified_by"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is a single event sent to a webhook. Pending deliveries are
+// retried by the job queue until they succeed or run out of attempts.
+// ResponseStatus and Error describe the latest attempt.
+type WebhookDelivery struct {
+	OrgID          int64                 `json:"orgId" db:"org_id"`
+	WebhookID      int64                 `json:"webhookId" db:"webhook_id"`
+	ID             int64                 `json:"id" db:"id"`
+	UUID           string                `json:"uuid" db:"uuid"`
+	EventType      WebhookEventType      `json:"eventType" db:"event_type"`
+	Payload        json.RawMessage       `json:"payload" db:"payload"`
+	Status         WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts       int                   `json:"attempts" db:"attempts"`
+	ResponseStatus *int                  `json:"responseStatus" db:"response_status"`
+	Error          *string               `json:"error" db:"error"`
+	Delivered      *time.Time            `json:"delivered" db:"delivered"`
+	Created        time.Time             `json:"created" db:"created"`
+}
+
+// WebhookPayload is the JSON body POSTed to webhooks. Data is the flag event
+// for flag and group flag events, and a WebhookGroupAccountsChange for
+// membership changes.
+type WebhookPayload struct {
+	EventType WebhookEventType `json:"eventType"`
+	OrgID     int64            `json:"orgId"`
+	Data      any              `json:"data"`
+	Created   time.Time        `json:"created"`
+}
+
+type WebhookGroupAccountsChange struct {
+	GroupID           int64   `json:"groupId"`
+	AddedAccountIDs   []int64 `json:"addedAccountIds"`
+	RemovedAccountIDs []int64 `json:"removedAccountIds"`
+}